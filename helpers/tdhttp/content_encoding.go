@@ -0,0 +1,78 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdhttp
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// decodeContentEncoding decodes body according to contentEncoding,
+// the value of a Content-Encoding header. It returns the decoded body
+// and true if body has been decoded. If contentEncoding is empty,
+// "identity" or contains an unsupported encoding, body is returned
+// as is with false.
+//
+// As several encodings can be listed, in the order they have been
+// applied, they are undone in the reverse order.
+func decodeContentEncoding(contentEncoding string, body []byte) ([]byte, bool, error) {
+	var encodings []string
+	for _, enc := range strings.Split(contentEncoding, ",") {
+		enc = strings.ToLower(strings.TrimSpace(enc))
+		switch enc {
+		case "", "identity":
+		case "gzip", "x-gzip", "deflate":
+			encodings = append(encodings, enc)
+		default:
+			return body, false, nil
+		}
+	}
+	if len(encodings) == 0 {
+		return body, false, nil
+	}
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		var err error
+		if encodings[i] == "deflate" {
+			body, err = inflate(body)
+		} else {
+			body, err = gunzip(body)
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("%s decoding failed: %w", encodings[i], err)
+		}
+	}
+	return body, true, nil
+}
+
+func gunzip(body []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// inflate decodes a "deflate" encoded body. RFC 9110 says it is a
+// zlib stream, but some servers send a raw deflate one, so both are
+// accepted.
+func inflate(body []byte) ([]byte, error) {
+	if r, err := zlib.NewReader(bytes.NewReader(body)); err == nil {
+		defer r.Close()
+		return io.ReadAll(r)
+	}
+
+	r := flate.NewReader(bytes.NewReader(body))
+	defer r.Close()
+	return io.ReadAll(r)
+}
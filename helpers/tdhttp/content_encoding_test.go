@@ -0,0 +1,239 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdhttp_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/helpers/tdutil"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func encodeBody(t *testing.T, encoding, body string) []byte {
+	t.Helper()
+
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+		err error
+	)
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		w, err = flate.NewWriter(&buf, flate.DefaultCompression)
+		if err != nil {
+			t.Fatal(err)
+		}
+	default:
+		t.Fatalf("unknown encoding %q", encoding)
+	}
+	io.WriteString(w, body) //nolint: errcheck
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestAutoDecodeBody(t *testing.T) {
+	body := `{"name":"Bob","age":42,"padding":"` +
+		strings.Repeat("0123456789", 20) + `"}`
+
+	gzipBody := encodeBody(t, "gzip", body)
+	deflateBody := encodeBody(t, "deflate", body)
+	rawDeflateBody := encodeBody(t, "raw-deflate", body)
+	gzipDeflateBody := encodeBody(t, "deflate", string(gzipBody))
+
+	mux := http.NewServeMux()
+	handle := func(path, encoding string, b []byte) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if encoding != "" {
+				w.Header().Set("Content-Encoding", encoding)
+			}
+			if req.Method != "HEAD" {
+				w.Write(b) //nolint: errcheck
+			}
+		})
+	}
+	handle("/gzip", "gzip", gzipBody)
+	handle("/x-gzip", "x-gzip", gzipBody)
+	handle("/deflate", "deflate", deflateBody)
+	handle("/raw-deflate", "deflate", rawDeflateBody)
+	handle("/gzip-deflate", "gzip, deflate", gzipDeflateBody)
+	handle("/identity", "identity", []byte(body))
+	handle("/none", "", []byte(body))
+	handle("/unknown", "br", []byte("brotli!"))
+	handle("/bad", "gzip", []byte("not gzip"))
+
+	type Person struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	t.Run("Decoded", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		for _, path := range []string{
+			"/gzip", "/x-gzip", "/deflate", "/raw-deflate", "/gzip-deflate",
+			"/identity", "/none",
+		} {
+			td.CmpFalse(t,
+				ta.Get(path).
+					CmpStatus(http.StatusOK).
+					CmpJSONBody(td.SStruct(Person{Name: "Bob", Age: 42})).
+					CmpBody(body).
+					Failed(),
+				path)
+		}
+
+		td.CmpFalse(t,
+			ta.Get("/gzip").
+				CmpHeader(td.Not(td.ContainsKey("Content-Encoding"))).
+				CmpRawBody(gzipBody).
+				CmpRawBody(td.Len(td.Lt(len(body)))).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/none").
+				CmpRawBody([]byte(body)).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/unknown").
+				CmpBody("brotli!").
+				CmpRawBody([]byte("brotli!")).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Head("/gzip").
+				CmpStatus(http.StatusOK).
+				NoBody().
+				Failed())
+
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux).AutoDecodeBody(false)
+
+		td.CmpFalse(t,
+			ta.Get("/gzip").
+				CmpHeader(td.SuperMapOf(http.Header{
+					"Content-Encoding": {"gzip"},
+				}, nil)).
+				CmpBody(gzipBody).
+				CmpRawBody(gzipBody).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Clone().Get("/gzip").
+				CmpBody(gzipBody).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.AutoDecodeBody().Get("/gzip").
+				CmpBody(body).
+				Failed())
+
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("Bad encoding", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpTrue(t,
+			ta.Get("/bad").
+				CmpStatus(http.StatusOK).
+				CmpRawBody([]byte("not gzip")).
+				CmpBody("not gzip"). // body kept as is
+				Failed())
+		td.Cmp(t, mockT.LogBuf(), td.All(
+			td.Contains("Failed test 'body decoding'"),
+			td.Contains("Response.Body: should NOT be an error"),
+			td.Contains("gzip decoding failed: "),
+		))
+	})
+
+	t.Run("Raw body mismatch", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpTrue(t,
+			ta.Get("/gzip").
+				CmpRawBody(td.Len(td.Gt(1000))).
+				Failed())
+		td.CmpContains(t, mockT.LogBuf(), "len(Response.RawBody): values differ")
+
+		mockT = tdutil.NewT("test")
+		ta = tdhttp.NewTestAPI(mockT, mux)
+		td.CmpTrue(t, ta.CmpRawBody(nil).Failed())
+		td.CmpContains(t, mockT.LogBuf(), "A request must be sent before testing")
+	})
+
+	t.Run("CmpResponse", func(t *testing.T) {
+		td.CmpTrue(t,
+			tdhttp.CmpJSONResponse(t,
+				tdhttp.NewRequest("GET", "/gzip", nil),
+				mux.ServeHTTP,
+				tdhttp.Response{
+					Status: http.StatusOK,
+					Header: td.Not(td.ContainsKey("Content-Encoding")),
+					Body:   td.SStruct(Person{Name: "Bob", Age: 42}),
+				}))
+	})
+
+	t.Run("Over the network", func(t *testing.T) {
+		srv := httptest.NewServer(mux)
+		defer srv.Close()
+
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPIClient(mockT, srv.URL, nil)
+
+		// Accept-Encoding explicitly set, so the client does not
+		// decompress the body itself
+		td.CmpFalse(t,
+			ta.Get("/gzip", "Accept-Encoding", "gzip").
+				CmpHeader(td.All(
+					td.Not(td.ContainsKey("Content-Encoding")),
+					td.Not(td.ContainsKey("Content-Length")),
+				)).
+				CmpRawBody(gzipBody).
+				CmpBody(td.HasPrefix(`{"name":"Bob"`)).
+				Failed())
+		td.CmpFalse(t,
+			ta.Clone().AutoDecodeBody(false).
+				Get("/gzip", "Accept-Encoding", "gzip").
+				CmpHeader(td.SuperMapOf(http.Header{
+					"Content-Encoding": {"gzip"},
+					"Content-Length":   {strconv.Itoa(len(gzipBody))},
+				}, nil)).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/deflate").
+				CmpJSONBody(td.SStruct(Person{Name: "Bob", Age: 42})).
+				Failed())
+
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+}
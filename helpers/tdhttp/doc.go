@@ -39,6 +39,36 @@
 //
 // See the full example below.
 //
+// To test a real server over the network instead of an
+// [http.Handler], use [NewTestAPIClient]:
+//
+//	srv := httptest.NewTLSServer(mux)
+//	defer srv.Close()
+//
+//	ta := tdhttp.NewTestAPIClient(t, srv.URL, srv.Client())
+//
+// Responses can also be checked against an OpenAPI 3 document, using
+// [TestAPI.CmpOpenAPI] or, for all requests, [TestAPI.AutoCmpOpenAPI]:
+//
+//	ta := tdhttp.NewTestAPI(t, mux).AutoCmpOpenAPI("testdata/openapi.yaml")
+//
+// # Mocking outgoing requests
+//
+// To test code sending HTTP requests, use [NewMockTransport] or
+// [NewMockServer]. Expected requests are registered with canned
+// responses, and are checked using any [td] operator:
+//
+//	mt := tdhttp.NewMockTransport(t)
+//	mt.Expect("POST", "/person").
+//	  Query(tdhttp.Q{"dry": true}).
+//	  JSONBody(td.JSON(`{"name": "Bob", "age": $1}`, td.Between(40, 45))).
+//	  ReplyJSON(http.StatusCreated, map[string]any{"id": 42})
+//
+//	client := NewMyAPIClient("https://my.api.example.org", mt.Client())
+//
+// Unexpected requests are reported as soon as they are received,
+// unmet expectations at the end of the test.
+//
 // # Cmp…Response functions
 //
 // Historically, it was the only way to test HTTP APIs using
@@ -26,6 +26,12 @@ func init() {
 // Response is used by Cmp*Response functions to make the HTTP
 // response match easier. Each field, can be a [td.TestDeep] operator
 // as well as the exact expected value.
+//
+// As with [TestAPI], a body encoded using gzip or deflate, as stated
+// by its Content-Encoding header, is decoded before being compared
+// against Body. Content-Encoding and Content-Length headers are then
+// removed before Header is compared. Use [TestAPI.AutoDecodeBody] and
+// [TestAPI.CmpRawBody] to check the body as received.
 type Response struct {
 	Status  any // is the expected status (ignored if nil)
 	Header  any // is the expected header (ignored if nil)
@@ -62,9 +62,21 @@ func backquote(b []byte) ([]byte, bool) {
 // backquotes then falling back to double-quotes.
 func DumpResponse(t testing.TB, resp *http.Response) {
 	t.Helper()
-
-	const label = "Received response:\n"
 	b, _ := httputil.DumpResponse(resp, true)
+	dump(t, "Received response:\n", b)
+}
+
+// DumpRequest logs "req" using Logf method of "t", as [DumpResponse]
+// does for responses.
+func DumpRequest(t testing.TB, req *http.Request) {
+	t.Helper()
+	b, _ := httputil.DumpRequest(req, true)
+	dump(t, "Received request:\n", b)
+}
+
+func dump(t testing.TB, label string, b []byte) {
+	t.Helper()
+
 	if canBackquote(b) {
 		bodyPos := bytes.Index(b, []byte("\r\n\r\n"))
 
@@ -10,6 +10,8 @@ import (
 	"bytes"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/maxatome/go-testdeep/helpers/tdhttp/internal"
@@ -106,3 +108,26 @@ lines
 		td.Re(`Received response:
 "HTTP/1.0 200 OK\\r\\nA: foo\\r\\nB: bar\\r\\n\\r\\n(\\u007f|\\x7f)"`))
 }
+
+func TestDumpRequest(t *testing.T) {
+	tb := test.NewTestingTB("TestDumpRequest")
+
+	req := httptest.NewRequest("POST", "/path?a=1", strings.NewReader("multi\nlines"))
+	req.Header.Set("A", "foo")
+	internal.DumpRequest(tb, req)
+	td.Cmp(t, tb.LastMessage(),
+		`Received request:
+`+inBQ(`POST /path?a=1 HTTP/1.1
+Host: example.com
+A: foo
+
+multi
+lines`))
+
+	tb.ResetMessages()
+	req = httptest.NewRequest("GET", "/path", strings.NewReader("he`o"))
+	internal.DumpRequest(tb, req)
+	td.Cmp(t, tb.LastMessage(),
+		`Received request:
+"GET /path HTTP/1.1\r\nHost: example.com\r\n\r\nhe`+"`"+`o"`)
+}
@@ -0,0 +1,480 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/helpers/tdhttp/internal"
+	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/internal/util"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// ExpectedRequest is a request expected by a [MockTransport] or a
+// [MockServer], registered using [MockTransport.Expect]. Its methods
+// allow to check the request further and to set the response
+// returned when it matches.
+type ExpectedRequest struct {
+	method string
+	path   any
+
+	query  any
+	header any
+	body   any
+	isJSON bool
+
+	status     int
+	respHeader http.Header
+	respBody   []byte
+	respErr    error
+
+	times int // < 0 means any number of times
+	count int
+}
+
+// Query sets the expected query parameters of the request. expected
+// can be a [Q], a [url.Values] or a [td.TestDeep] operator matching
+// a [url.Values]:
+//
+//	mt.Expect("GET", "/person").Query(tdhttp.Q{"id": 42})
+//	mt.Expect("GET", "/person").Query(td.ContainsKey("id"))
+func (e *ExpectedRequest) Query(expected any) *ExpectedRequest {
+	if q, ok := expected.(Q); ok {
+		expected = q.Values()
+	}
+	e.query = expected
+	return e
+}
+
+// Header sets the expected header of the request. expected can be a
+// [http.Header] or a [td.TestDeep] operator matching a
+// [http.Header]. When it is a [http.Header], only its keys are
+// checked, as if [td.SuperMapOf] was used, so headers added by the
+// [http.Client] are ignored:
+//
+//	mt.Expect("GET", "/person").
+//	  Header(http.Header{"Authorization": {"Bearer 1234"}})
+func (e *ExpectedRequest) Header(expected any) *ExpectedRequest {
+	if h, ok := expected.(http.Header); ok {
+		expected = td.SuperMapOf(h, nil)
+	}
+	e.header = expected
+	return e
+}
+
+// Body sets the expected raw body of the request. expected can be a
+// string, a []byte or a [td.TestDeep] operator.
+//
+//	mt.Expect("POST", "/upload").Body(td.HasPrefix("%PDF-"))
+func (e *ExpectedRequest) Body(expected any) *ExpectedRequest {
+	e.body = expected
+	e.isJSON = false
+	return e
+}
+
+// JSONBody sets the expected body of the request, once
+// [json.Unmarshal]'ed. expected can be any type one can
+// [json.Unmarshal] into, or a [td.TestDeep] operator, as
+// [TestAPI.CmpJSONBody] does:
+//
+//	mt.Expect("POST", "/person").
+//	  JSONBody(td.JSON(`{"name": "Bob", "age": $1}`, td.Between(40, 45)))
+func (e *ExpectedRequest) JSONBody(expected any) *ExpectedRequest {
+	e.body = expected
+	e.isJSON = true
+	return e
+}
+
+// Reply sets the response returned when the request matches. body
+// can be a string or a []byte. headers is a list of header key/value
+// pairs, [http.Header] or cookies, as in [NewRequest].
+//
+// Without Reply call, an empty 200 response is returned.
+func (e *ExpectedRequest) Reply(status int, body any, headers ...any) *ExpectedRequest {
+	e.status = status
+	switch b := body.(type) {
+	case nil:
+		e.respBody = nil
+	case string:
+		e.respBody = []byte(b)
+	case []byte:
+		e.respBody = b
+	default:
+		panic(fmt.Sprintf("Reply only accepts a string or a []byte body, not a %T", body))
+	}
+
+	header, _, cookies, _, err := collateRequestParams(headers)
+	if err != nil {
+		panic(err)
+	}
+	if header == nil {
+		header = http.Header{}
+	}
+	for _, c := range cookies {
+		header.Add("Set-Cookie", c.String())
+	}
+	e.respHeader = header
+	return e
+}
+
+// ReplyJSON sets the response returned when the request matches, its
+// body being body [json.Marshal]'ed. The Content-Type header is
+// automatically set to "application/json", if not already in
+// headers.
+func (e *ExpectedRequest) ReplyJSON(status int, body any, headers ...any) *ExpectedRequest {
+	b, err := json.Marshal(body)
+	if err != nil {
+		panic(fmt.Sprintf("ReplyJSON body cannot be marshaled: %s", err))
+	}
+	e.Reply(status, b, headers...)
+	if e.respHeader.Get("Content-Type") == "" {
+		e.respHeader.Set("Content-Type", "application/json")
+	}
+	return e
+}
+
+// ReplyError sets the error returned by [MockTransport.RoundTrip]
+// when the request matches, to simulate a network error. A
+// [MockServer] replies a 502 Bad Gateway status instead.
+func (e *ExpectedRequest) ReplyError(err error) *ExpectedRequest {
+	e.respErr = err
+	return e
+}
+
+// Times sets how many times the request is expected. The default
+// is 1. A negative times means any number of times, even never.
+func (e *ExpectedRequest) Times(times int) *ExpectedRequest {
+	e.times = times
+	return e
+}
+
+// String returns the method and the path of e.
+func (e *ExpectedRequest) String() string {
+	if s, ok := e.path.(string); ok {
+		return e.method + " " + s
+	}
+	return e.method + " " + util.ToString(e.path)
+}
+
+func (e *ExpectedRequest) exhausted() bool {
+	return e.times >= 0 && e.count >= e.times
+}
+
+func (e *ExpectedRequest) response(req *http.Request) *http.Response {
+	status := e.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	header := e.respHeader.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.respBody)),
+		ContentLength: int64(len(e.respBody)),
+		Request:       req,
+	}
+}
+
+// MockTransport is a [http.RoundTripper] checking the requests sent
+// by the code under test against expected ones, and returning canned
+// responses. See [NewMockTransport].
+type MockTransport struct {
+	t *td.T
+
+	mu       sync.Mutex
+	expected []*ExpectedRequest
+	verified bool
+}
+
+var _ http.RoundTripper = (*MockTransport)(nil)
+
+// NewMockTransport returns a new [*MockTransport] reporting failures
+// to tb. Each expected request is registered using
+// [MockTransport.Expect]:
+//
+//	mt := tdhttp.NewMockTransport(t)
+//	mt.Expect("POST", "/person").
+//	  Header(http.Header{"Content-Type": {"application/json"}}).
+//	  JSONBody(td.JSON(`{"name": "Bob", "age": $1}`, td.Between(40, 45))).
+//	  ReplyJSON(http.StatusCreated, map[string]any{"id": 42})
+//
+//	client := NewMyAPIClient("https://my.api.example.org", mt.Client())
+//	id, err := client.CreatePerson("Bob", 42)
+//	td.Cmp(t, err, nil)
+//	td.Cmp(t, id, 42)
+//
+// A request not matching any expectation method and path, or
+// matching an already exhausted one, is reported as unexpected and
+// [MockTransport.RoundTrip] returns an error. A request matching an
+// expectation but whose query, header or body do not match is
+// reported using the usual go-testdeep format, then the canned
+// response is returned anyway.
+//
+// Unmet expectations are reported when tb is cleaned up, unless
+// [MockTransport.Verify] has already been called.
+//
+// Note that tb can be a [*testing.T] as well as a [*td.T].
+//
+// See also [NewMockServer].
+func NewMockTransport(tb testing.TB) *MockTransport {
+	mt := &MockTransport{t: td.NewT(tb).Assert()}
+	mt.t.Cleanup(func() {
+		mt.mu.Lock()
+		verified := mt.verified
+		mt.mu.Unlock()
+		if !verified {
+			mt.t.Helper()
+			mt.Verify()
+		}
+	})
+	return mt
+}
+
+// Expect registers and returns a new [*ExpectedRequest] matching
+// requests using method and whose URL path matches path. path can be
+// a string or a [td.TestDeep] operator:
+//
+//	mt.Expect("GET", "/person/42")
+//	mt.Expect("GET", td.Re(`^/person/\d+\z`))
+//
+// When several expectations match a request, the first registered
+// not exhausted one wins.
+func (mt *MockTransport) Expect(method string, path any) *ExpectedRequest {
+	e := &ExpectedRequest{
+		method: method,
+		path:   path,
+		times:  1,
+	}
+
+	mt.mu.Lock()
+	mt.expected = append(mt.expected, e)
+	mt.mu.Unlock()
+
+	return e
+}
+
+// Client returns a new [*http.Client] using mt as transport.
+func (mt *MockTransport) Client() *http.Client {
+	return &http.Client{Transport: mt}
+}
+
+// RoundTrip implements [http.RoundTripper] interface.
+func (mt *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	mt.t.Helper()
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	mt.mu.Lock()
+	e, exhausted := mt.match(req)
+	mt.mu.Unlock()
+
+	if e == nil {
+		reason := "unexpected request"
+		if exhausted != nil {
+			reason = fmt.Sprintf("%s expected %d time(s), but called once more", exhausted, exhausted.times)
+		}
+		mt.t.Errorf("%s: %s %s", reason, req.Method, req.URL.RequestURI())
+		mt.dumpRequest(req, body)
+		return nil, fmt.Errorf("tdhttp: %s: %s %s", reason, req.Method, req.URL)
+	}
+
+	if !mt.check(e, req, body) {
+		mt.dumpRequest(req, body)
+	}
+
+	if e.respErr != nil {
+		return nil, e.respErr
+	}
+	return e.response(req), nil
+}
+
+// match returns the first expectation matching req. If none matches,
+// it returns the first exhausted one matching req method and path,
+// if any.
+func (mt *MockTransport) match(req *http.Request) (*ExpectedRequest, *ExpectedRequest) {
+	var exhausted *ExpectedRequest
+	for _, e := range mt.expected {
+		if e.method != req.Method || !td.EqDeeply(req.URL.Path, e.path) {
+			continue
+		}
+		if e.exhausted() {
+			if exhausted == nil {
+				exhausted = e
+			}
+			continue
+		}
+		e.count++
+		return e, nil
+	}
+	return nil, exhausted
+}
+
+func (mt *MockTransport) check(e *ExpectedRequest, req *http.Request, body []byte) bool {
+	mt.t.Helper()
+
+	name := e.String() + ": "
+	ok := true
+
+	if e.query != nil &&
+		!mt.t.RootName("Request.Query").
+			Cmp(req.URL.Query(), e.query, name+"query should match") {
+		ok = false
+	}
+
+	if e.header != nil &&
+		!mt.t.RootName("Request.Header").
+			Cmp(req.Header, e.header, name+"header should match") {
+		ok = false
+	}
+
+	if e.body == nil {
+		return ok
+	}
+
+	if !e.isJSON {
+		var got any = body
+		if _, isString := e.body.(string); isString {
+			got = string(body)
+		} else if op, isOp := e.body.(td.TestDeep); isOp && op.TypeBehind() == types.String {
+			got = string(body)
+		}
+		if !mt.t.RootName("Request.Body").Cmp(got, e.body, name+"body should match") {
+			ok = false
+		}
+		return ok
+	}
+
+	bodyType := reflect.TypeOf(e.body)
+	if op, isOp := e.body.(td.TestDeep); isOp {
+		bodyType = op.TypeBehind()
+	}
+	if bodyType == nil {
+		bodyType = types.Interface
+	}
+	bodyPtr := reflect.New(bodyType)
+
+	if !mt.t.RootName("unmarshal(Request.Body)").
+		CmpNoError(json.Unmarshal(body, bodyPtr.Interface()), name+"body unmarshaling") ||
+		!mt.t.RootName("Request.Body").
+			Cmp(bodyPtr.Elem().Interface(), e.body, name+"body contents is OK") {
+		ok = false
+	}
+	return ok
+}
+
+func (mt *MockTransport) dumpRequest(req *http.Request, body []byte) {
+	mt.t.Helper()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	internal.DumpRequest(mt.t, req)
+	req.Body = io.NopCloser(bytes.NewReader(body))
+}
+
+// Verify checks that all expected requests have been received the
+// expected number of times. It is automatically called when the
+// [testing.TB] instance passed to [NewMockTransport] is cleaned up,
+// unless already called. It returns true if all expectations are
+// met, false otherwise.
+func (mt *MockTransport) Verify() bool {
+	mt.t.Helper()
+
+	mt.mu.Lock()
+	mt.verified = true
+	var unmet []string
+	for _, e := range mt.expected {
+		if e.times >= 0 && e.count != e.times {
+			unmet = append(unmet,
+				fmt.Sprintf("%s expected %d time(s), but received %d time(s)", e, e.times, e.count))
+		}
+	}
+	mt.mu.Unlock()
+
+	for _, msg := range unmet {
+		mt.t.Error("unmet expectation: " + msg)
+	}
+	return len(unmet) == 0
+}
+
+// MockServer is a [httptest.Server] checking the requests it
+// receives against expected ones, and returning canned responses. It
+// works as [MockTransport] does, but over the network. See
+// [NewMockServer].
+type MockServer struct {
+	*MockTransport
+	*httptest.Server
+}
+
+// NewMockServer starts and returns a new [*MockServer] reporting
+// failures to tb. The server is closed and unmet expectations are
+// reported when tb is cleaned up.
+//
+//	ms := tdhttp.NewMockServer(t)
+//	ms.Expect("GET", "/person/42").
+//	  ReplyJSON(http.StatusOK, map[string]any{"id": 42, "name": "Bob"})
+//
+//	client := NewMyAPIClient(ms.URL, ms.Client())
+//	person, err := client.GetPerson(42)
+//
+// Note that tb can be a [*testing.T] as well as a [*td.T].
+//
+// See [NewMockTransport] for details.
+func NewMockServer(tb testing.TB) *MockServer {
+	ms := &MockServer{MockTransport: NewMockTransport(tb)}
+	ms.Server = httptest.NewServer(http.HandlerFunc(ms.serveHTTP))
+	ms.t.Cleanup(ms.Server.Close)
+	return ms
+}
+
+// Client returns a [*http.Client] configured to send requests to
+// ms.
+func (ms *MockServer) Client() *http.Client {
+	return ms.Server.Client()
+}
+
+func (ms *MockServer) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	// Server requests have a path only URL
+	req.URL.Scheme = "http"
+	req.URL.Host = req.Host
+
+	resp, err := ms.RoundTrip(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body) //nolint: errcheck
+}
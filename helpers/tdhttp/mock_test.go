@@ -0,0 +1,311 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdhttp_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	_ "github.com/maxatome/go-testdeep/helpers/nocolor"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// cleanupTB allows to run cleanup functions on demand.
+type cleanupTB struct {
+	*test.TestingTB
+	cleanups []func()
+}
+
+func newCleanupTB(name string) *cleanupTB {
+	return &cleanupTB{TestingTB: test.NewTestingTB(name)}
+}
+
+func (t *cleanupTB) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *cleanupTB) runCleanups() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+	t.cleanups = nil
+}
+
+func (t *cleanupTB) messages() string {
+	return strings.Join(t.Messages, "\n")
+}
+
+func TestMockTransport(t *testing.T) {
+	type Person struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		tb := newCleanupTB(t.Name())
+		mt := tdhttp.NewMockTransport(tb)
+
+		mt.Expect("POST", "/person").
+			Query(tdhttp.Q{"dry": true}).
+			Header(http.Header{"Content-Type": {"application/json"}}).
+			JSONBody(td.JSON(`{"name": "Bob", "age": $1}`, td.Between(40, 45))).
+			ReplyJSON(http.StatusCreated, map[string]any{"id": 42},
+				"X-Id", "42")
+		mt.Expect("GET", td.Re(`^/person/\d+\z`)).
+			Reply(http.StatusOK, "Bob").
+			Times(2)
+		mt.Expect("PUT", "/raw").
+			Body(td.HasPrefix("raw"))
+		mt.Expect("DELETE", "/person/42").
+			Times(-1)
+
+		client := mt.Client()
+
+		resp, err := client.Post("http://api.example.com/person?dry=true",
+			"application/json", strings.NewReader(`{"name":"Bob","age":42}`))
+		if test.NoError(t, err) {
+			test.EqualInt(t, resp.StatusCode, http.StatusCreated)
+			test.EqualStr(t, resp.Header.Get("Content-Type"), "application/json")
+			test.EqualStr(t, resp.Header.Get("X-Id"), "42")
+			body, _ := io.ReadAll(resp.Body)
+			test.EqualStr(t, string(body), `{"id":42}`)
+		}
+
+		for _, id := range []string{"1", "2"} {
+			resp, err = client.Get("http://api.example.com/person/" + id)
+			if test.NoError(t, err) {
+				test.EqualInt(t, resp.StatusCode, http.StatusOK)
+				body, _ := io.ReadAll(resp.Body)
+				test.EqualStr(t, string(body), "Bob")
+			}
+		}
+
+		req, _ := http.NewRequest("PUT", "http://api.example.com/raw",
+			strings.NewReader("raw content"))
+		resp, err = client.Do(req)
+		if test.NoError(t, err) {
+			test.EqualInt(t, resp.StatusCode, http.StatusOK)
+		}
+
+		test.IsTrue(t, mt.Verify())
+		tb.runCleanups()
+		test.IsFalse(t, tb.Failed(), tb.messages())
+	})
+
+	t.Run("Mismatches", func(t *testing.T) {
+		tb := newCleanupTB(t.Name())
+		mt := tdhttp.NewMockTransport(tb)
+
+		mt.Expect("POST", "/person").
+			Query(td.ContainsKey("dry")).
+			Header(http.Header{"X-Token": {"secret"}}).
+			JSONBody(Person{Name: "Bob", Age: 42}).
+			Reply(http.StatusCreated, nil)
+
+		resp, err := mt.Client().Post("http://api.example.com/person",
+			"application/json", strings.NewReader(`{"name":"Alice","age":42}`))
+		if test.NoError(t, err) {
+			test.EqualInt(t, resp.StatusCode, http.StatusCreated)
+		}
+
+		test.IsTrue(t, tb.Failed())
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"POST /person: query should match",
+			"Request.Query: does not contain key",
+			"POST /person: header should match",
+			"comparing hash keys of Request.Header",
+			`Missing key: ("X-Token")`,
+			"POST /person: body contents is OK",
+			"Request.Body.Name: values differ",
+			`got: "Alice"`,
+			`expected: "Bob"`,
+			"Received request:",
+			"POST /person HTTP/1.1",
+			`{"name":"Alice","age":42}`,
+		))
+	})
+
+	t.Run("Bad JSON body", func(t *testing.T) {
+		tb := newCleanupTB(t.Name())
+		mt := tdhttp.NewMockTransport(tb)
+
+		mt.Expect("POST", "/person").JSONBody(Person{Name: "Bob"})
+
+		_, err := mt.Client().Post("http://api.example.com/person",
+			"application/json", strings.NewReader(`{"name":`))
+		test.NoError(t, err)
+
+		test.IsTrue(t, tb.Failed())
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"POST /person: body unmarshaling",
+			"unmarshal(Request.Body): should NOT be an error",
+		))
+	})
+
+	t.Run("Raw body mismatch", func(t *testing.T) {
+		tb := newCleanupTB(t.Name())
+		mt := tdhttp.NewMockTransport(tb)
+
+		mt.Expect("PUT", "/raw").Body("foo")
+		mt.Expect("PUT", "/bytes").Body([]byte("foo"))
+
+		client := mt.Client()
+		req, _ := http.NewRequest("PUT", "http://api.example.com/raw",
+			strings.NewReader("bar"))
+		_, err := client.Do(req)
+		test.NoError(t, err)
+		req, _ = http.NewRequest("PUT", "http://api.example.com/bytes",
+			strings.NewReader("foo"))
+		_, err = client.Do(req)
+		test.NoError(t, err)
+
+		test.IsTrue(t, tb.Failed())
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"PUT /raw: body should match",
+			"Request.Body: values differ",
+			`got: "bar"`,
+			`expected: "foo"`,
+		))
+		test.IsTrue(t, mt.Verify())
+	})
+
+	t.Run("Unexpected request", func(t *testing.T) {
+		tb := newCleanupTB(t.Name())
+		mt := tdhttp.NewMockTransport(tb)
+
+		mt.Expect("GET", "/person/42")
+
+		_, err := mt.Client().Get("http://api.example.com/person/12")
+		if test.Error(t, err) {
+			test.IsTrue(t, strings.Contains(err.Error(),
+				"tdhttp: unexpected request: GET http://api.example.com/person/12"),
+				err.Error())
+		}
+		test.IsTrue(t, tb.Failed())
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"unexpected request: GET /person/12",
+			"Received request:",
+			"GET /person/12 HTTP/1.1",
+		))
+	})
+
+	t.Run("Too many requests", func(t *testing.T) {
+		tb := newCleanupTB(t.Name())
+		mt := tdhttp.NewMockTransport(tb)
+
+		mt.Expect("GET", "/person/42")
+
+		client := mt.Client()
+		_, err := client.Get("http://api.example.com/person/42")
+		test.NoError(t, err)
+		test.IsFalse(t, tb.Failed())
+
+		_, err = client.Get("http://api.example.com/person/42")
+		test.Error(t, err)
+		test.IsTrue(t, tb.Failed())
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"GET /person/42 expected 1 time(s), but called once more: GET /person/42"))
+	})
+
+	t.Run("Unmet expectations", func(t *testing.T) {
+		tb := newCleanupTB(t.Name())
+		mt := tdhttp.NewMockTransport(tb)
+
+		mt.Expect("GET", "/person/42").Times(2)
+		mt.Expect("GET", td.HasPrefix("/other"))
+		mt.Expect("GET", "/maybe").Times(-1)
+
+		_, err := mt.Client().Get("http://api.example.com/person/42")
+		test.NoError(t, err)
+
+		tb.runCleanups()
+		test.IsTrue(t, tb.Failed())
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"unmet expectation: GET /person/42 expected 2 time(s), but received 1 time(s)",
+			`unmet expectation: GET HasPrefix("/other") expected 1 time(s), but received 0 time(s)`,
+		))
+	})
+
+	t.Run("ReplyError", func(t *testing.T) {
+		tb := newCleanupTB(t.Name())
+		mt := tdhttp.NewMockTransport(tb)
+
+		boom := errors.New("boom")
+		mt.Expect("GET", "/person/42").ReplyError(boom)
+
+		_, err := mt.Client().Get("http://api.example.com/person/42")
+		if test.Error(t, err) {
+			test.IsTrue(t, errors.Is(err, boom))
+		}
+
+		tb.runCleanups()
+		test.IsFalse(t, tb.Failed(), tb.messages())
+	})
+
+	t.Run("Reply panics", func(t *testing.T) {
+		mt := tdhttp.NewMockTransport(newCleanupTB(t.Name()))
+
+		td.CmpPanic(t,
+			func() { mt.Expect("GET", "/").Reply(200, 42) },
+			"Reply only accepts a string or a []byte body, not a int")
+		td.CmpPanic(t,
+			func() { mt.Expect("GET", "/").ReplyJSON(200, func() {}) },
+			td.HasPrefix("ReplyJSON body cannot be marshaled: "))
+		td.CmpPanic(t,
+			func() { mt.Expect("GET", "/").Reply(200, "", 42) },
+			td.Isa((*error)(nil)))
+	})
+}
+
+func TestMockServer(t *testing.T) {
+	tb := newCleanupTB(t.Name())
+	ms := tdhttp.NewMockServer(tb)
+
+	ms.Expect("GET", "/person/42").
+		Header(td.SuperMapOf(http.Header{}, td.MapEntries{
+			"User-Agent": td.Contains(td.HasPrefix("Go-http-client/")),
+		})).
+		ReplyJSON(http.StatusOK, map[string]any{"id": 42, "name": "Bob"})
+	ms.Expect("GET", "/down").ReplyError(errors.New("boom"))
+
+	client := ms.Client()
+
+	resp, err := client.Get(ms.URL + "/person/42")
+	if test.NoError(t, err) {
+		defer resp.Body.Close()
+		test.EqualInt(t, resp.StatusCode, http.StatusOK)
+		test.EqualStr(t, resp.Header.Get("Content-Type"), "application/json")
+		body, _ := io.ReadAll(resp.Body)
+		test.EqualStr(t, string(body), `{"id":42,"name":"Bob"}`)
+	}
+
+	resp, err = client.Get(ms.URL + "/down")
+	if test.NoError(t, err) {
+		resp.Body.Close()
+		test.EqualInt(t, resp.StatusCode, http.StatusBadGateway)
+	}
+	test.IsFalse(t, tb.Failed(), tb.messages())
+
+	resp, err = client.Get(ms.URL + "/unknown")
+	if test.NoError(t, err) {
+		resp.Body.Close()
+		test.EqualInt(t, resp.StatusCode, http.StatusBadGateway)
+	}
+	test.IsTrue(t, tb.Failed())
+	td.CmpEmpty(t, tb.ContainsMessages("unexpected request: GET /unknown"))
+
+	tb.runCleanups()
+
+	// Server is closed
+	_, err = client.Get(ms.URL + "/person/42")
+	test.Error(t, err)
+}
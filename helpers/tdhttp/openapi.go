@@ -0,0 +1,621 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/jsonschema"
+	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/internal/yaml"
+)
+
+// OpenAPISpec is an OpenAPI 3 document, as loaded by [LoadOpenAPI].
+// It is safe for concurrent use.
+type OpenAPISpec struct {
+	filename  string
+	doc       map[string]any
+	basePaths []string
+	paths     []openAPIPath
+
+	mu         sync.Mutex
+	validators map[string]*jsonschema.Validator
+}
+
+type openAPIPath struct {
+	template string
+	re       *regexp.Regexp
+	literals int // number of literal chars, the more the better
+}
+
+// openAPICache is a cached [*OpenAPISpec], valid as long as its file
+// is not modified.
+type openAPICache struct {
+	modTime time.Time
+	size    int64
+	spec    *OpenAPISpec
+}
+
+var openAPISpecs sync.Map // absolute filename → openAPICache
+
+// LoadOpenAPI loads the OpenAPI 3.0 or 3.1 document contained in
+// filename. JSON documents are supported, as well as YAML ones when
+// filename ends with ".yaml" or ".yml". In this last case, only the
+// YAML subset supported by [td.YAML] operator can be used.
+//
+// Only local references ("#/components/…") are supported.
+//
+// See [TestAPI.CmpOpenAPI].
+func LoadOpenAPI(filename string) (*OpenAPISpec, error) {
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		doc, err = yaml.Parse(buf)
+	default:
+		err = json.Unmarshal(buf, &doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+
+	m, _ := doc.(map[string]any)
+	if version, _ := m["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("%s: not an OpenAPI 3 document", filename)
+	}
+
+	spec := &OpenAPISpec{
+		filename:   filename,
+		doc:        m,
+		validators: map[string]*jsonschema.Validator{},
+	}
+
+	spec.basePaths = append(spec.basePaths, "")
+	servers, _ := m["servers"].([]any)
+	for _, server := range servers {
+		if base := serverBasePath(server); base != "" {
+			spec.basePaths = append(spec.basePaths, base)
+		}
+	}
+
+	paths, _ := m["paths"].(map[string]any)
+	for tmpl := range paths {
+		p, err := newOpenAPIPath(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("%s: path %q: %s", filename, tmpl, err)
+		}
+		spec.paths = append(spec.paths, p)
+	}
+	sort.Slice(spec.paths, func(i, j int) bool {
+		if spec.paths[i].literals != spec.paths[j].literals {
+			return spec.paths[i].literals > spec.paths[j].literals
+		}
+		return spec.paths[i].template < spec.paths[j].template
+	})
+
+	return spec, nil
+}
+
+// loadOpenAPI returns the [*OpenAPISpec] corresponding to spec, a
+// filename or a [*OpenAPISpec]. Loaded files are cached, and
+// reloaded as soon as they are modified.
+func loadOpenAPI(spec any) (*OpenAPISpec, error) {
+	switch spec := spec.(type) {
+	case *OpenAPISpec:
+		if spec == nil {
+			return nil, errors.New("OpenAPI spec is nil")
+		}
+		return spec, nil
+
+	case string:
+		abs, err := filepath.Abs(spec)
+		if err != nil {
+			return nil, err
+		}
+		fi, err := os.Stat(abs)
+		if err != nil {
+			return nil, err
+		}
+		if c, ok := openAPISpecs.Load(abs); ok {
+			c := c.(openAPICache)
+			if c.modTime.Equal(fi.ModTime()) && c.size == fi.Size() {
+				return c.spec, nil
+			}
+		}
+		s, err := LoadOpenAPI(spec)
+		if err != nil {
+			return nil, err
+		}
+		openAPISpecs.Store(abs, openAPICache{
+			modTime: fi.ModTime(),
+			size:    fi.Size(),
+			spec:    s,
+		})
+		return s, nil
+
+	default:
+		return nil, fmt.Errorf("OpenAPI spec must be a filename or a *tdhttp.OpenAPISpec, not a %T", spec)
+	}
+}
+
+// serverBasePath returns the path of a server object URL, using the
+// default values of its variables.
+func serverBasePath(server any) string {
+	s, _ := server.(map[string]any)
+	raw, _ := s["url"].(string)
+	vars, _ := s["variables"].(map[string]any)
+	for name, v := range vars {
+		def, _ := v.(map[string]any)["default"].(string)
+		raw = strings.ReplaceAll(raw, "{"+name+"}", def)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(u.Path, "/")
+}
+
+var openAPIParamRe = regexp.MustCompile(`\{[^}/]+\}`)
+
+func newOpenAPIPath(tmpl string) (openAPIPath, error) {
+	var (
+		re       strings.Builder
+		literals int
+		prev     int
+	)
+	re.WriteByte('^')
+	for _, loc := range openAPIParamRe.FindAllStringIndex(tmpl, -1) {
+		re.WriteString(regexp.QuoteMeta(tmpl[prev:loc[0]]))
+		re.WriteString(`[^/]+`)
+		literals += loc[0] - prev
+		prev = loc[1]
+	}
+	re.WriteString(regexp.QuoteMeta(tmpl[prev:]))
+	re.WriteString(`\z`)
+	literals += len(tmpl) - prev
+
+	r, err := regexp.Compile(re.String())
+	if err != nil {
+		return openAPIPath{}, err
+	}
+	return openAPIPath{template: tmpl, re: r, literals: literals}, nil
+}
+
+// findPath returns the path template matching path, or "" if none
+// matches. Templates with more literal chars win, as
+// "/person/me" against "/person/{id}".
+func (s *OpenAPISpec) findPath(path string) string {
+	for _, p := range s.paths {
+		for _, base := range s.basePaths {
+			if rest := strings.TrimPrefix(path, base); (base == "" || rest != path) && p.re.MatchString(rest) {
+				return p.template
+			}
+		}
+	}
+	return ""
+}
+
+// deref follows the $ref of obj, located at pointer, and returns the
+// referenced object with its own pointer.
+func (s *OpenAPISpec) deref(obj any, pointer string) (map[string]any, string, error) {
+	for i := 0; i < 32; i++ {
+		m, ok := obj.(map[string]any)
+		if !ok {
+			return nil, pointer, fmt.Errorf("%s is not an object", pointer)
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return m, pointer, nil
+		}
+		target, err := jsonschema.Resolve(s.doc, ref)
+		if err != nil {
+			return nil, pointer, fmt.Errorf("%s/$ref: %s", pointer, err)
+		}
+		obj, pointer = target, ref
+	}
+	return nil, pointer, fmt.Errorf("%s: too many nested $ref", pointer)
+}
+
+func (s *OpenAPISpec) validator(pointer string) (*jsonschema.Validator, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v, ok := s.validators[pointer]; ok {
+		return v, nil
+	}
+	v, err := jsonschema.New(s.doc, pointer)
+	if err != nil {
+		return nil, err
+	}
+	s.validators[pointer] = v
+	return v, nil
+}
+
+// openAPIResponse is the part of an OpenAPI document describing a
+// response.
+type openAPIResponse struct {
+	operation string // as "GET /person/{id}"
+	status    string // as "200", "2XX" or "default"
+	obj       map[string]any
+	pointer   string
+}
+
+// findOperation returns the response object, without its status,
+// common to all responses of the request method & path. It returns
+// nil and a ctxerr.Error if the operation is not described.
+func (s *OpenAPISpec) findOperation(method, path string) (*openAPIResponse, *ctxerr.Error) {
+	tmpl := s.findPath(path)
+	if tmpl == "" {
+		return nil, &ctxerr.Error{
+			Message:  "%% path is not described in OpenAPI spec",
+			Got:      types.RawString(method + " " + path),
+			Expected: types.RawString("one of described paths"),
+		}
+	}
+
+	pointer := "#/paths/" + jsonschema.EscapePointer(tmpl)
+	item, pointer, err := s.deref(s.doc["paths"].(map[string]any)[tmpl], pointer)
+	if err != nil {
+		return nil, openAPIUserError(err)
+	}
+
+	op, ok := item[strings.ToLower(method)]
+	if !ok {
+		return nil, &ctxerr.Error{
+			Message:  "%% method is not described in OpenAPI spec",
+			Got:      types.RawString(method + " " + path),
+			Expected: types.RawString(describedKeys(item, httpMethods...) + " " + tmpl),
+		}
+	}
+
+	opObj, _ := op.(map[string]any)
+	return &openAPIResponse{
+		operation: method + " " + tmpl,
+		obj:       opObj,
+		pointer:   pointer + "/" + strings.ToLower(method),
+	}, nil
+}
+
+// findResponse returns the response object describing the response
+// of status to the operation op, as returned by
+// [OpenAPISpec.findOperation]. It returns nil and a ctxerr.Error if
+// not found.
+func (s *OpenAPISpec) findResponse(op *openAPIResponse, status int) (*openAPIResponse, *ctxerr.Error) {
+	responses, _ := op.obj["responses"].(map[string]any)
+	code := strconv.Itoa(status)
+	for _, key := range []string{code, code[:1] + "XX", code[:1] + "xx", "default"} {
+		if obj, ok := responses[key]; ok {
+			resp := &openAPIResponse{operation: op.operation, status: key}
+			var err error
+			resp.obj, resp.pointer, err = s.deref(obj,
+				op.pointer+"/responses/"+jsonschema.EscapePointer(key))
+			if err != nil {
+				return nil, openAPIUserError(err)
+			}
+			return resp, nil
+		}
+	}
+
+	return nil, &ctxerr.Error{
+		Message:  "%% is not described in OpenAPI spec",
+		Got:      status,
+		Expected: types.RawString(describedKeys(responses) + " for " + op.operation),
+	}
+}
+
+var httpMethods = []string{
+	"get", "put", "post", "delete", "options", "head", "patch", "trace",
+}
+
+// describedKeys returns the keys of m, restricted to only if
+// non-empty, as "one of A, B or C".
+func describedKeys(m map[string]any, only ...string) string {
+	var keys []string
+	if len(only) > 0 {
+		for _, k := range only {
+			if _, ok := m[k]; ok {
+				keys = append(keys, strings.ToUpper(k))
+			}
+		}
+	} else {
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+	}
+
+	switch len(keys) {
+	case 0:
+		return "nothing"
+	case 1:
+		return keys[0]
+	}
+	return "one of " + strings.Join(keys[:len(keys)-1], ", ") + " or " + keys[len(keys)-1]
+}
+
+func openAPIUserError(err error) *ctxerr.Error {
+	return &ctxerr.Error{
+		Message: "bad OpenAPI spec",
+		Summary: ctxerr.NewSummary(err.Error()),
+		User:    true,
+	}
+}
+
+// openAPIViolations collects the violations of an OpenAPI spec,
+// grouped by location.
+type openAPIViolations struct {
+	paths []ctxerr.Path
+	lines map[string][]string
+}
+
+// add records the violation described by line at path.
+func (v *openAPIViolations) add(path ctxerr.Path, line string) {
+	key := path.String()
+	if v.lines == nil {
+		v.lines = map[string][]string{}
+	}
+	if _, ok := v.lines[key]; !ok {
+		v.paths = append(v.paths, path)
+	}
+	v.lines[key] = append(v.lines[key], line)
+}
+
+// addSchemaErrors records errs, the violations of a schema by
+// instance located at root.
+func (v *openAPIViolations) addSchemaErrors(root ctxerr.Path, instance any, errs []jsonschema.Error) {
+	for _, e := range errs {
+		v.add(pointerPath(root, instance, e.InstancePath),
+			e.Message+" ("+e.SchemaPath+")")
+	}
+}
+
+// error returns the chain of errors, one per location, or nil if no
+// violation has been recorded. r is the described response.
+func (v *openAPIViolations) error(r *openAPIResponse) *ctxerr.Error {
+	var head, last *ctxerr.Error
+	for _, path := range v.paths {
+		err := &ctxerr.Error{
+			Context: ctxerr.Context{Path: path, Depth: path.Len()},
+			Message: "%% does not fit OpenAPI spec of " + r.operation + " " + r.status + " response",
+			Summary: ctxerr.NewSummary(strings.Join(v.lines[path.String()], "\n")),
+		}
+		if head == nil {
+			head = err
+		} else {
+			last.Next = err
+		}
+		last = err
+	}
+	return head
+}
+
+// pointerPath returns root followed by the location of the value
+// designated by the JSON pointer pointer in instance, as
+// root.items[2].price.
+func pointerPath(root ctxerr.Path, instance any, pointer string) ctxerr.Path {
+	if pointer == "" {
+		return root
+	}
+	path := root
+	for _, tok := range strings.Split(pointer[1:], "/") {
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		switch v := instance.(type) {
+		case []any:
+			if i, err := strconv.Atoi(tok); err == nil && i >= 0 && i < len(v) {
+				path = path.AddArrayIndex(i)
+				instance = v[i]
+				continue
+			}
+		case map[string]any:
+			instance = v[tok]
+		}
+		if isIdentifier(tok) {
+			path = path.AddField(tok)
+		} else {
+			path = path.AddMapKey(tok)
+		}
+	}
+	return path
+}
+
+// isIdentifier returns true if s can be displayed as a field in a
+// path.
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// checkHeader checks header against the headers described in r. root
+// is the path of header.
+func (s *OpenAPISpec) checkHeader(r *openAPIResponse, header http.Header, root ctxerr.Path) *ctxerr.Error {
+	headers, _ := r.obj["headers"].(map[string]any)
+
+	var violations openAPIViolations
+	for _, name := range sortedKeys(headers) {
+		if strings.EqualFold(name, "Content-Type") {
+			continue // ignored, as stated by the specification
+		}
+		pointer := r.pointer + "/headers/" + jsonschema.EscapePointer(name)
+		h, pointer, err := s.deref(headers[name], pointer)
+		if err != nil {
+			return openAPIUserError(err)
+		}
+
+		key := http.CanonicalHeaderKey(name)
+		values, ok := header[key]
+		if !ok {
+			if required, _ := h["required"].(bool); required {
+				violations.add(root.AddMapKey(key),
+					"missing required header ("+pointer+"/required)")
+			}
+			continue
+		}
+
+		if _, ok := h["schema"]; !ok {
+			continue
+		}
+		v, err := s.validator(pointer + "/schema")
+		if err != nil {
+			return openAPIUserError(err)
+		}
+		value := headerValue(strings.Join(values, ","), v.Schema(), s.doc)
+		violations.addSchemaErrors(root.AddMapKey(key), value, v.Validate(value))
+	}
+
+	return violations.error(r)
+}
+
+// headerValue converts the header value raw depending on the type of
+// schema, to be validated against it.
+func headerValue(raw string, schema, doc any) any {
+	for i := 0; i < 32; i++ {
+		s, _ := schema.(map[string]any)
+		ref, ok := s["$ref"].(string)
+		if !ok {
+			break
+		}
+		schema, _ = jsonschema.Resolve(doc, ref)
+	}
+
+	s, _ := schema.(map[string]any)
+	switch s["type"] {
+	case "integer", "number":
+		if f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(strings.TrimSpace(raw)); err == nil {
+			return b
+		}
+	case "array":
+		parts := strings.Split(raw, ",")
+		items := make([]any, len(parts))
+		for i, part := range parts {
+			items[i] = headerValue(strings.TrimSpace(part), s["items"], doc)
+		}
+		return items
+	}
+	return raw
+}
+
+// findMediaType returns the media type key of the content of r
+// matching contentType, with its pointer. It returns "" if not found.
+func findMediaType(r *openAPIResponse, contentType string) (string, string) {
+	content, _ := r.obj["content"].(map[string]any)
+
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", ""
+	}
+
+	keys := map[string]string{} // parsed media type → key
+	for key := range content {
+		if kmt, _, err := mime.ParseMediaType(key); err == nil {
+			keys[kmt] = key
+		}
+	}
+
+	candidates := []string{mt}
+	if slash := strings.IndexByte(mt, '/'); slash > 0 {
+		candidates = append(candidates, mt[:slash]+"/*")
+	}
+	candidates = append(candidates, "*/*")
+	for _, c := range candidates {
+		if key, ok := keys[c]; ok {
+			return key, r.pointer + "/content/" + jsonschema.EscapePointer(key)
+		}
+	}
+	return "", ""
+}
+
+func isJSONMediaType(mediaType string) bool {
+	mt, _, err := mime.ParseMediaType(mediaType)
+	return err == nil && (mt == "application/json" || strings.HasSuffix(mt, "+json"))
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkBody checks the body, received with the Content-Type header
+// contentType, against the content described in r. root is the path
+// of body.
+func (s *OpenAPISpec) checkBody(r *openAPIResponse, contentType string, body []byte, root ctxerr.Path) *ctxerr.Error {
+	content, _ := r.obj["content"].(map[string]any)
+	if len(content) == 0 {
+		if len(body) == 0 {
+			return nil
+		}
+		return &ctxerr.Error{
+			Message:  "%% should be empty as stated by OpenAPI spec of " + r.operation + " " + r.status + " response",
+			Got:      types.RawString("not empty"),
+			Expected: types.RawString("empty"),
+		}
+	}
+
+	key, pointer := findMediaType(r, contentType)
+	if key == "" {
+		if contentType == "" {
+			contentType = "no Content-Type"
+		}
+		return &ctxerr.Error{
+			Message:  "%% Content-Type is not described in OpenAPI spec of " + r.operation + " " + r.status + " response",
+			Got:      types.RawString(contentType),
+			Expected: types.RawString(describedKeys(content)),
+		}
+	}
+
+	media, _ := content[key].(map[string]any)
+	if _, ok := media["schema"]; !ok || !isJSONMediaType(contentType) {
+		return nil
+	}
+
+	v, err := s.validator(pointer + "/schema")
+	if err != nil {
+		return openAPIUserError(err)
+	}
+
+	var got any
+	if err := json.Unmarshal(body, &got); err != nil {
+		return &ctxerr.Error{
+			Message: "%% is not valid JSON",
+			Summary: ctxerr.NewSummary(err.Error()),
+		}
+	}
+
+	var violations openAPIViolations
+	violations.addSchemaErrors(root, got, v.Validate(got))
+	return violations.error(r)
+}
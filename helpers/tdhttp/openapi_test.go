@@ -0,0 +1,353 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdhttp_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/helpers/tdutil"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+const openAPIJSON = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Persons", "version": "1.0"},
+  "servers": [{"url": "https://api.example.com/{version}",
+               "variables": {"version": {"default": "v1"}}}],
+  "paths": {
+    "/person/{id}": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "OK",
+            "headers": {
+              "X-Rate-Limit": {
+                "required": true,
+                "schema": {"type": "integer", "minimum": 0}
+              }
+            },
+            "content": {
+              "application/json": {
+                "schema": {"$ref": "#/components/schemas/Person"}
+              }
+            }
+          },
+          "404": {"$ref": "#/components/responses/NotFound"}
+        }
+      },
+      "delete": {
+        "responses": {
+          "204": {"description": "Deleted"}
+        }
+      }
+    },
+    "/person/me": {
+      "get": {
+        "responses": {
+          "2XX": {
+            "description": "OK",
+            "content": {"text/*": {"schema": {"type": "string"}}}
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Person": {
+        "type": "object",
+        "required": ["id", "name"],
+        "properties": {
+          "id": {"type": "integer", "format": "int64"},
+          "name": {"type": "string", "minLength": 1},
+          "children": {
+            "type": "array",
+            "items": {"$ref": "#/components/schemas/Person"}
+          }
+        }
+      }
+    },
+    "responses": {
+      "NotFound": {
+        "description": "Not found",
+        "content": {
+          "application/problem+json": {
+            "schema": {
+              "type": "object",
+              "required": ["title"],
+              "properties": {"title": {"type": "string"}}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const openAPIYAML = `
+openapi: 3.1.0
+info:
+  title: Persons
+  version: "1.0"
+paths:
+  /person/{id}:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id, name]
+                properties:
+                  id: {type: integer}
+                  name: {type: string}
+`
+
+func openAPIServer() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	reply := func(w http.ResponseWriter, status int, ct, body string) {
+		if ct != "" {
+			w.Header().Set("Content-Type", ct)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body)) //nolint: errcheck
+	}
+
+	mux.HandleFunc("/person/", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodDelete {
+			reply(w, http.StatusNoContent, "", "")
+			return
+		}
+		w.Header().Set("X-Rate-Limit", "10")
+		switch req.URL.Path[len("/person/"):] {
+		case "me":
+			reply(w, http.StatusOK, "text/plain", "Bob")
+		case "42":
+			reply(w, http.StatusOK, "application/json",
+				`{"id":42,"name":"Bob","children":[{"id":43,"name":"Alice"}]}`)
+		case "bad":
+			reply(w, http.StatusOK, "application/json",
+				`{"id":"12","children":[{"id":43,"name":""}]}`)
+		case "no-limit":
+			w.Header().Del("X-Rate-Limit")
+			reply(w, http.StatusOK, "application/json", `{"id":1,"name":"Bob"}`)
+		case "bad-limit":
+			w.Header().Set("X-Rate-Limit", "-3")
+			reply(w, http.StatusOK, "application/json", `{"id":1,"name":"Bob"}`)
+		case "xml":
+			reply(w, http.StatusOK, "application/xml", `<person/>`)
+		case "missing":
+			reply(w, http.StatusNotFound, "application/problem+json", `{"title":"Not found"}`)
+		case "teapot":
+			reply(w, http.StatusTeapot, "text/plain", "I'm a teapot")
+		default:
+			reply(w, http.StatusNotFound, "application/problem+json", `{}`)
+		}
+	})
+
+	mux.HandleFunc("/v1/person/42", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Rate-Limit", "10")
+		reply(w, http.StatusOK, "application/json", `{"id":42,"name":"Bob"}`)
+	})
+
+	return mux
+}
+
+func writeOpenAPI(t *testing.T, name, content string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestCmpOpenAPI(t *testing.T) {
+	mux := openAPIServer()
+	specFile := writeOpenAPI(t, "openapi.json", openAPIJSON)
+
+	// violation matches the report of violations at path
+	violation := func(path, violations string) td.TestDeep {
+		return td.Contains(path +
+			" does not fit OpenAPI spec of GET /person/{id} 200 response\n        \t" +
+			violations)
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		for _, path := range []string{
+			"/person/42", "/person/me", "/person/missing", "/v1/person/42",
+		} {
+			td.CmpFalse(t, ta.Get(path).CmpOpenAPI(specFile).Failed(), path)
+		}
+		td.CmpFalse(t, ta.Delete("/person/42", nil).CmpOpenAPI(specFile).Failed())
+
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		spec, err := tdhttp.LoadOpenAPI(writeOpenAPI(t, "openapi.yaml", openAPIYAML))
+		td.Require(t).CmpNoError(err)
+
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpFalse(t, ta.Get("/person/42").CmpOpenAPI(spec).Failed())
+		td.CmpTrue(t, ta.Get("/person/bad").CmpOpenAPI(spec).Failed())
+		td.Cmp(t, mockT.LogBuf(), td.All(
+			td.Contains("Failed test 'body should fit OpenAPI spec'"),
+			td.Contains("Response.Body does not fit OpenAPI spec of GET /person/{id} 200 response"),
+			td.Contains(`missing required property "name" (#/paths/~1person~1{id}/get/responses/200/content/application~1json/schema/required)`),
+		))
+	})
+
+	t.Run("Body violations", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpTrue(t, ta.Get("/person/bad").CmpOpenAPI(specFile).Failed())
+		td.Cmp(t, mockT.LogBuf(), td.All(
+			violation(`Response.Body`,
+				`missing required property "name" (#/components/schemas/Person/required)`),
+			violation(`Response.Body.id`,
+				`expected integer, got string (#/components/schemas/Person/properties/id/type)`),
+			violation(`Response.Body.children[0].name`,
+				`length 0 should be ≥ 1 (#/components/schemas/Person/properties/name/minLength)`),
+		))
+
+		mockT = tdutil.NewT("test")
+		ta = tdhttp.NewTestAPI(mockT, mux)
+		td.CmpTrue(t, ta.Get("/person/unknown").CmpOpenAPI(specFile).Failed())
+		td.Cmp(t, mockT.LogBuf(), td.All(
+			td.Contains("GET /person/{id} 404 response"),
+			td.Contains(`missing required property "title" (#/components/responses/NotFound/content/application~1problem+json/schema/required)`),
+		))
+	})
+
+	t.Run("Header violations", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpTrue(t, ta.Get("/person/no-limit").CmpOpenAPI(specFile).Failed())
+		td.Cmp(t, mockT.LogBuf(), td.All(
+			td.Contains("Failed test 'header should fit OpenAPI spec'"),
+			violation(`Response.Header["X-Rate-Limit"]`,
+				`missing required header (#/paths/~1person~1{id}/get/responses/200/headers/X-Rate-Limit/required)`),
+		))
+		td.CmpNot(t, mockT.LogBuf(), td.Contains("Response.Body"))
+
+		mockT = tdutil.NewT("test")
+		ta = tdhttp.NewTestAPI(mockT, mux)
+		td.CmpTrue(t, ta.Get("/person/bad-limit").CmpOpenAPI(specFile).Failed())
+		td.Cmp(t, mockT.LogBuf(), violation(`Response.Header["X-Rate-Limit"]`,
+			`-3 should be ≥ 0 (#/paths/~1person~1{id}/get/responses/200/headers/X-Rate-Limit/schema/minimum)`))
+	})
+
+	t.Run("Content-Type violations", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpTrue(t, ta.Get("/person/xml").CmpOpenAPI(specFile).Failed())
+		td.Cmp(t, mockT.LogBuf(), td.All(
+			td.Contains("Response.Body Content-Type is not described in OpenAPI spec of GET /person/{id} 200 response"),
+			td.Contains("application/xml"),
+			td.Contains("application/json"),
+		))
+	})
+
+	t.Run("Not described", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpTrue(t, ta.Get("/unknown").CmpOpenAPI(specFile).Failed())
+		td.Cmp(t, mockT.LogBuf(), td.All(
+			td.Contains("Failed test 'response should be described in OpenAPI spec'"),
+			td.Contains("Request path is not described in OpenAPI spec"),
+			td.Contains("GET /unknown"),
+		))
+
+		mockT = tdutil.NewT("test")
+		ta = tdhttp.NewTestAPI(mockT, mux)
+		td.CmpTrue(t, ta.Post("/person/42", nil).CmpOpenAPI(specFile).Failed())
+		td.Cmp(t, mockT.LogBuf(), td.All(
+			td.Contains("Request method is not described in OpenAPI spec"),
+			td.Contains("one of GET or DELETE /person/{id}"),
+		))
+
+		mockT = tdutil.NewT("test")
+		ta = tdhttp.NewTestAPI(mockT, mux)
+		td.CmpTrue(t, ta.Get("/person/teapot").CmpOpenAPI(specFile).Failed())
+		td.Cmp(t, mockT.LogBuf(), td.All(
+			td.Contains("Response.Status is not described in OpenAPI spec"),
+			td.Contains("418"),
+			td.Contains("one of 200 or 404 for GET /person/{id}"),
+		))
+	})
+
+	t.Run("AutoCmpOpenAPI", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux).AutoCmpOpenAPI(specFile)
+
+		td.CmpFalse(t, ta.Get("/person/42").Failed())
+		td.CmpFalse(t, ta.Clone().Get("/person/me").Failed())
+		td.CmpTrue(t, ta.Get("/person/bad").Failed())
+		td.CmpContains(t, mockT.LogBuf(), "body should fit OpenAPI spec")
+
+		mockT = tdutil.NewT("test")
+		ta = ta.With(mockT)
+		td.CmpFalse(t, ta.AutoCmpOpenAPI(nil).Get("/person/bad").Failed())
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("Modified file", func(t *testing.T) {
+		file := writeOpenAPI(t, "modified.json", openAPIJSON)
+
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+		td.CmpFalse(t, ta.Get("/person/42").CmpOpenAPI(file).Failed())
+
+		err := os.WriteFile(file, []byte(`{"openapi":"3.0.3","paths":{}}`), 0o644)
+		td.Require(t).CmpNoError(err)
+		future := time.Now().Add(time.Hour)
+		td.Require(t).CmpNoError(os.Chtimes(file, future, future))
+
+		td.CmpTrue(t, ta.Get("/person/42").CmpOpenAPI(file).Failed())
+		td.CmpContains(t, mockT.LogBuf(), "Request path is not described in OpenAPI spec")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := tdhttp.LoadOpenAPI(filepath.Join(t.TempDir(), "nope.json"))
+		td.CmpError(t, err)
+
+		_, err = tdhttp.LoadOpenAPI(writeOpenAPI(t, "bad.json", `{`))
+		td.CmpError(t, err)
+
+		_, err = tdhttp.LoadOpenAPI(writeOpenAPI(t, "swagger.json", `{"swagger":"2.0"}`))
+		td.CmpContains(t, err, "not an OpenAPI 3 document")
+
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+		td.CmpTrue(t, mockT.CatchFailNow(func() { ta.Get("/person/42").CmpOpenAPI(42) }))
+		td.CmpContains(t, mockT.LogBuf(),
+			"OpenAPI spec must be a filename or a *tdhttp.OpenAPISpec, not a int")
+
+		mockT = tdutil.NewT("test")
+		ta = tdhttp.NewTestAPI(mockT, mux)
+		td.CmpTrue(t, ta.CmpOpenAPI(specFile).Failed())
+		td.CmpContains(t, mockT.LogBuf(), "A request must be sent before testing")
+	})
+}
@@ -8,6 +8,7 @@ package tdhttp
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
@@ -23,6 +24,27 @@ import (
 	"github.com/maxatome/go-testdeep/internal/types"
 )
 
+// targetInfoKey is the context key of the [targetInfo] of requests
+// built by newRequest.
+type targetInfoKey struct{}
+
+// targetInfo keeps the details of the target passed to newRequest
+// that cannot be retrieved from the built request.
+type targetInfo struct {
+	// defaultHost is the Host set by httptest.NewRequest, if it has
+	// not been explicitly set by the caller
+	defaultHost string
+	// https is true if the target is a "https://…" URL
+	https bool
+}
+
+// getTargetInfo returns the [targetInfo] of req. It is empty if req
+// has not been built by newRequest.
+func getTargetInfo(req *http.Request) targetInfo {
+	info, _ := req.Context().Value(targetInfoKey{}).(targetInfo)
+	return info
+}
+
 func newRequest(method string, target string, body io.Reader, params []any) (*http.Request, error) {
 	header, qp, cookies, hook, err := collateRequestParams(params)
 	if err != nil {
@@ -36,6 +58,7 @@ func newRequest(method string, target string, body io.Reader, params []any) (*ht
 		return nil, errors.New(color.Bad("target is not a valid path: %s", err))
 	}
 	host := u.Host
+	info := targetInfo{https: u.Scheme == "https"}
 	u.Host = ""
 	u.Scheme = ""
 	if len(qp) > 0 {
@@ -57,7 +80,12 @@ func newRequest(method string, target string, body io.Reader, params []any) (*ht
 	}
 	if host != "" {
 		req.Host = host
+	} else {
+		// req.Host defaults to "example.com", remember it so it can be
+		// distinguished from a host explicitly set by the caller
+		info.defaultHost = req.Host
 	}
+	req = req.WithContext(context.WithValue(req.Context(), targetInfoKey{}, info))
 
 	for _, c := range cookies {
 		req.AddCookie(c)
@@ -0,0 +1,171 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdhttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/helpers/tdutil"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func sessionServer() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/login", func(w http.ResponseWriter, req *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/"})
+		http.SetCookie(w, &http.Cookie{Name: "secure", Value: "s", Path: "/", Secure: true})
+		http.SetCookie(w, &http.Cookie{Name: "admin", Value: "a", Path: "/admin"})
+		http.SetCookie(w, &http.Cookie{Name: "other", Value: "o", Path: "/", Domain: "other.org"})
+	})
+
+	mux.HandleFunc("/logout", func(w http.ResponseWriter, req *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Path: "/", MaxAge: -1})
+	})
+
+	whoami := func(w http.ResponseWriter, req *http.Request) {
+		var names []string
+		for _, c := range req.Cookies() {
+			names = append(names, c.Name+"="+c.Value)
+		}
+		sort.Strings(names)
+		w.Write([]byte(strings.Join(names, ";"))) //nolint: errcheck
+	}
+	mux.HandleFunc("/whoami", whoami)
+	mux.HandleFunc("/admin/whoami", whoami)
+
+	return mux
+}
+
+func TestSession(t *testing.T) {
+	mux := sessionServer()
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		ta.Get("/login").CmpStatus(http.StatusOK)
+		td.CmpFalse(t, ta.Get("/whoami").CmpBody("").Failed())
+		td.CmpNil(t, ta.SessionCookies("/"))
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux).Session()
+
+		ta.Get("/login").CmpStatus(http.StatusOK)
+
+		td.CmpFalse(t, ta.Get("/whoami").CmpBody("session=abc").Failed())
+		td.CmpFalse(t,
+			ta.Get("https://example.com/whoami").
+				CmpBody("secure=s;session=abc").
+				Failed())
+		td.CmpFalse(t,
+			ta.Get("/admin/whoami").
+				CmpBody("admin=a;session=abc").
+				Failed())
+		td.CmpFalse(t,
+			ta.Get("http://other.org/whoami").
+				CmpBody("").
+				Failed())
+
+		// Explicitly set cookies are not overridden
+		td.CmpFalse(t,
+			ta.Get("/whoami", &http.Cookie{Name: "session", Value: "xyz"}).
+				CmpBody("session=xyz").
+				Failed())
+		td.CmpFalse(t,
+			ta.Clone().
+				DefaultRequestParams(&http.Cookie{Name: "session", Value: "dflt"}).
+				Get("/whoami").
+				CmpBody("session=dflt").
+				Failed())
+
+		td.Cmp(t, ta.SessionCookies("/admin/"), td.Bag(
+			td.Struct(&http.Cookie{Name: "session", Value: "abc"}, nil),
+			td.Struct(&http.Cookie{Name: "admin", Value: "a"}, nil),
+		))
+		td.Cmp(t, ta.SessionCookies("https://example.com/"), td.Len(2))
+
+		// Session() keeps the current jar
+		td.Cmp(t, ta.Session(true).SessionCookies("/"), td.Len(1))
+
+		// Expired cookies are removed
+		ta.Get("/logout").CmpStatus(http.StatusOK)
+		td.CmpFalse(t, ta.Get("/whoami").CmpBody("").Failed())
+		td.CmpEmpty(t, ta.SessionCookies("/"))
+
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("Clear and disable", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux).Session()
+
+		ta.Get("/login")
+		td.Cmp(t, ta.SessionCookies("/"), td.Len(1))
+
+		td.CmpEmpty(t, ta.ClearSession().SessionCookies("/"))
+		td.CmpFalse(t, ta.Get("/whoami").CmpBody("").Failed())
+
+		ta.Get("/login")
+		td.Cmp(t, ta.SessionCookies("/"), td.Len(1))
+		td.CmpNil(t, ta.Session(false).SessionCookies("/"))
+		td.CmpFalse(t, ta.Get("/whoami").CmpBody("").Failed())
+
+		// ClearSession enables the session mode
+		ta.ClearSession().Get("/login")
+		td.CmpFalse(t, ta.Get("/whoami").CmpBody("session=abc").Failed())
+
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("Shared jar", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux).Session()
+
+		ta.Get("/login")
+
+		td.CmpFalse(t,
+			ta.With(mockT).Get("/whoami").CmpBody("session=abc").Failed())
+
+		ta.Run("sub", func(ta *tdhttp.TestAPI) {
+			ta.Get("/whoami").CmpBody("session=abc")
+			ta.Get("/logout")
+		})
+		td.CmpFalse(t, ta.Get("/whoami").CmpBody("").Failed())
+
+		// ClearSession gives its own jar to the instance
+		ta.Get("/login")
+		td.CmpFalse(t,
+			ta.Clone().ClearSession().Get("/whoami").CmpBody("").Failed())
+		td.CmpFalse(t, ta.Get("/whoami").CmpBody("session=abc").Failed())
+
+		td.CmpNot(t, mockT.LogBuf(), td.Contains("Failed test"))
+	})
+
+	t.Run("Over the network", func(t *testing.T) {
+		srv := httptest.NewServer(mux)
+		defer srv.Close()
+
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPIClient(mockT, srv.URL, nil).Session()
+
+		// Note that the cookie jar considers loopback addresses as secure
+		ta.Get("/login").CmpStatus(http.StatusOK)
+		td.CmpFalse(t, ta.Get("/whoami").CmpBody("secure=s;session=abc").Failed())
+		td.Cmp(t, ta.SessionCookies("/admin/whoami"), td.Len(3))
+
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SSEEvent is a Server-Sent Event, as parsed by
+// [TestAPI.CmpSSEEvents] from a "text/event-stream" response body.
+type SSEEvent struct {
+	Event string `json:"event,omitempty"` // Event is the "event" field, "" if not set
+	ID    string `json:"id,omitempty"`    // ID is the "id" field, "" if not set
+	Data  string `json:"data,omitempty"`  // Data is the "data" field lines, joined by "\n"
+	Retry int    `json:"retry,omitempty"` // Retry is the "retry" field in milliseconds, 0 if not set
+}
+
+var sseEventType = reflect.TypeOf(SSEEvent{})
+
+// parseSSE parses body as a "text/event-stream" and returns the
+// events it contains. Contrary to what a browser does, events without
+// data field are not ignored, and ID is not inherited from previous
+// events, so the events are returned as sent. As a browser does, an
+// event not terminated by an empty line is discarded.
+func parseSSE(body []byte) []SSEEvent {
+	s := strings.TrimPrefix(string(body), "\ufeff")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+
+	events := []SSEEvent{}
+	var (
+		cur     SSEEvent
+		data    []string
+		pending bool
+	)
+	for s != "" {
+		end := strings.IndexByte(s, '\n')
+		if end < 0 {
+			break // incomplete line, so incomplete event
+		}
+		line := s[:end]
+		s = s[end+1:]
+
+		if line == "" {
+			if pending {
+				cur.Data = strings.Join(data, "\n")
+				events = append(events, cur)
+				cur, data, pending = SSEEvent{}, nil, false
+			}
+			continue
+		}
+
+		if line[0] == ':' {
+			continue // comment
+		}
+
+		field, value := line, ""
+		if colon := strings.IndexByte(line, ':'); colon >= 0 {
+			field = line[:colon]
+			value = strings.TrimPrefix(line[colon+1:], " ")
+		}
+
+		switch field {
+		case "event":
+			cur.Event = value
+		case "id":
+			cur.ID = value
+		case "data":
+			data = append(data, value)
+		case "retry":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				continue
+			}
+			cur.Retry = n
+		default:
+			continue // unknown fields are ignored
+		}
+		pending = true
+	}
+	return events
+}
+
+// unmarshalSSE parses body as a "text/event-stream" and stores the
+// events it contains in target. If target does not point to a
+// []SSEEvent, an any or a slice of interfaces, events are
+// [json.Marshal]'ed then [json.Unmarshal]'ed into target.
+func unmarshalSSE(body []byte, target any) error {
+	events := parseSSE(body)
+
+	vtarget := reflect.ValueOf(target).Elem()
+	vevents := reflect.ValueOf(events)
+	if vevents.Type().AssignableTo(vtarget.Type()) {
+		vtarget.Set(vevents)
+		return nil
+	}
+	if vtarget.Kind() == reflect.Slice &&
+		sseEventType.AssignableTo(vtarget.Type().Elem()) {
+		s := reflect.MakeSlice(vtarget.Type(), len(events), len(events))
+		for i := range events {
+			s.Index(i).Set(vevents.Index(i))
+		}
+		vtarget.Set(s)
+		return nil
+	}
+
+	b, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, target)
+}
+
+// unmarshalJSONLines parses body as newline delimited JSON (NDJSON,
+// aka JSON Lines), and [json.Unmarshal]s it into target as if it was
+// a JSON array. Empty lines are ignored.
+func unmarshalJSONLines(body []byte, target any) error {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	num := 0
+	for i, line := range bytes.Split(body, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if !json.Valid(line) {
+			return fmt.Errorf("line %d is not valid JSON: %s", i+1, line)
+		}
+		if num > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(line)
+		num++
+	}
+	buf.WriteByte(']')
+	return json.Unmarshal(buf.Bytes(), target)
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdhttp_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/helpers/tdutil"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func streamServer() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, "\ufeff: welcome\r\n\r\n") //nolint: errcheck
+		io.WriteString(w, "retry: 3000\n")           //nolint: errcheck
+		io.WriteString(w, "event: start\nid: 1\n\n") //nolint: errcheck
+		w.(http.Flusher).Flush()
+		io.WriteString(w, "event:progress\ndata: {\"percent\":50}\n\n") //nolint: errcheck
+		w.(http.Flusher).Flush()
+		io.WriteString(w, "event: end\rid: 2\rdata: line1\rdata\rdata: line3\r\r") //nolint: errcheck
+		io.WriteString(w, "retry: bad\nunknown: field\n\n")                        //nolint: errcheck
+		io.WriteString(w, "data: incomplete\n")                                    //nolint: errcheck
+	})
+
+	mux.HandleFunc("/ndjson", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		io.WriteString(w, `{"step":1,"status":"running"}`+"\n") //nolint: errcheck
+		w.(http.Flusher).Flush()
+		io.WriteString(w, "\n")                                   //nolint: errcheck
+		io.WriteString(w, `{"step":2,"status":"done"}`+"\r\n")    //nolint: errcheck
+		io.WriteString(w, ` {"step":3,"status":"cleaned"} `+"\n") //nolint: errcheck
+	})
+
+	mux.HandleFunc("/ndjson/bad", func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, `{"step":1}`+"\n"+`{"step":`+"\n") //nolint: errcheck
+	})
+
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, req *http.Request) {})
+
+	return mux
+}
+
+func TestCmpSSEEvents(t *testing.T) {
+	mux := streamServer()
+
+	expected := []tdhttp.SSEEvent{
+		{Retry: 3000, Event: "start", ID: "1"},
+		{Event: "progress", Data: `{"percent":50}`},
+		{Event: "end", ID: "2", Data: "line1\n\nline3"},
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpFalse(t,
+			ta.Get("/sse").
+				CmpStatus(http.StatusOK).
+				CmpSSEEvents(expected).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/sse").
+				CmpSSEEvents(td.Bag(
+					td.Smuggle("Event", "end"),
+					td.Smuggle("Data", td.HasPrefix(`{"percent":`)),
+					td.Ignore(),
+				)).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/sse").
+				CmpSSEEvents(td.JSON(`[
+  {"event": "start", "id": "1", "retry": 3000},
+  {"event": "progress", "data": NotEmpty()},
+  {"event": "end", "id": "2", "data": "line1\n\nline3"}
+]`)).
+				Failed())
+
+		type Event struct {
+			Name string `json:"event"`
+		}
+		td.CmpFalse(t,
+			ta.Get("/sse").
+				CmpSSEEvents([]Event{{"start"}, {"progress"}, {"end"}}).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/empty").
+				CmpSSEEvents([]tdhttp.SSEEvent{}).
+				Failed())
+
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpTrue(t,
+			ta.Get("/sse").
+				CmpSSEEvents(td.Bag(
+					td.Smuggle("Event", "end"),
+					td.Smuggle("Event", "progress"),
+					td.Smuggle("Event", "unknown"),
+				)).
+				Failed())
+		td.CmpContains(t, mockT.LogBuf(), "Response.Body")
+
+		mockT = tdutil.NewT("test")
+		ta = tdhttp.NewTestAPI(mockT, mux)
+		td.CmpTrue(t,
+			ta.Get("/sse").
+				CmpSSEEvents([]tdhttp.SSEEvent{
+					expected[0],
+					{Event: "progress", Data: `{"percent":100}`},
+					expected[2],
+				}).
+				Failed())
+		td.CmpContains(t, mockT.LogBuf(), "Response.Body[1].Data: values differ")
+	})
+}
+
+func TestCmpJSONLines(t *testing.T) {
+	mux := streamServer()
+
+	type Progress struct {
+		Step   int    `json:"step"`
+		Status string `json:"status"`
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpFalse(t,
+			ta.Get("/ndjson").
+				CmpStatus(http.StatusOK).
+				CmpJSONLines([]Progress{
+					{Step: 1, Status: "running"},
+					{Step: 2, Status: "done"},
+					{Step: 3, Status: "cleaned"},
+				}).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/ndjson").
+				CmpJSONLines(td.JSON(`[
+  {"step": 1, "status": "running"},
+  {"step": 2, "status": "done"},
+  {"step": 3, "status": NotEmpty()}
+]`)).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/ndjson").
+				CmpJSONLines(td.All(
+					td.Isa([]Progress{}),
+					td.ArrayEach(td.Smuggle("Status", td.Re(`^(running|done|cleaned)\z`))),
+				)).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/ndjson").
+				CmpJSONLines(td.Len(3)).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/empty").
+				CmpJSONLines([]Progress{}).
+				Failed())
+
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpTrue(t,
+			ta.Get("/ndjson").
+				CmpJSONLines(td.All(
+					td.Isa([]Progress{}),
+					td.ArrayEach(td.Smuggle("Status", td.Re(`^(running|done)\z`))),
+				)).
+				Failed())
+		td.CmpContains(t, mockT.LogBuf(), "Response.Body<All#2/2>[2].Status: does not match Regexp")
+	})
+
+	t.Run("Bad JSON line", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPI(mockT, mux)
+
+		td.CmpTrue(t,
+			ta.Get("/ndjson/bad").
+				CmpJSONLines([]Progress{}).
+				Failed())
+		td.CmpContains(t, mockT.LogBuf(), `line 2 is not valid JSON: {"step":`)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
@@ -44,16 +45,35 @@ const (
 type TestAPI struct {
 	t       *td.T
 	handler http.Handler
+	client  *http.Client
+	baseURL *url.URL
 	name    string
 
 	sentAt   time.Time
 	response *httptest.ResponseRecorder
+	rawBody  []byte // body as received, if decoded
 	failed   failed
 
 	// autoDumpResponse dumps the received response when a test fails.
 	autoDumpResponse bool
 	responseDumped   bool
 
+	// noDecodeBody disables the Content-Encoding decoding of the
+	// received response body.
+	noDecodeBody bool
+
+	// jar, if non-nil, stores the received cookies and sends them
+	// back in the next requests.
+	jar http.CookieJar
+
+	// openAPI, if non-nil, is used to check each received response.
+	openAPI *OpenAPISpec
+
+	// reqMethod and reqPath are the method and the path of the last
+	// sent request.
+	reqMethod string
+	reqPath   string
+
 	defaultHeader  http.Header
 	defaultQParams url.Values
 	defaultCookies []*http.Cookie
@@ -88,12 +108,62 @@ func NewTestAPI(tb testing.TB, handler http.Handler) *TestAPI {
 	}
 }
 
+// NewTestAPIClient creates a [TestAPI] that sends its requests over
+// the network to the server listening at baseURL, using client. If
+// client is nil, [http.DefaultClient] is used.
+//
+// Contrary to [NewTestAPI], the tested server is real, so middlewares
+// depending on real connections (TLS, HTTP/2, connection hijacking,
+// client-side redirects…) can be exercised. All other [TestAPI]
+// features work the same way:
+//
+//	srv := httptest.NewTLSServer(mux)
+//	defer srv.Close()
+//
+//	ta := tdhttp.NewTestAPIClient(t, srv.URL, srv.Client())
+//
+//	ta.Get("/person/42").
+//	  CmpStatus(http.StatusOK).
+//	  CmpJSONBody(Person{
+//	    ID:   ta.A(td.NotZero(), uint64(0)).(uint64),
+//	    Name: "Bob",
+//	  })
+//
+// The path and query of each request target are appended to
+// baseURL. As with [NewTestAPI], a host in the target, as in
+// "https://example.org/path", or a "Host" header only set the Host
+// header of the request. Otherwise, the host of baseURL is used.
+//
+// Note that tb can be a [*testing.T] as well as a [*td.T].
+func NewTestAPIClient(tb testing.TB, baseURL string, client *http.Client) *TestAPI {
+	t := td.NewT(tb)
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("NewTestAPIClient: bad base URL: %s", err)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &TestAPI{
+		t:       t,
+		client:  client,
+		baseURL: u,
+	}
+}
+
 // Clone creates a new [*TestAPI] instance copied from ta. The
 // returned instance is independent from ta, sharing only the same
 // handler. The header values, query params, cookies and hooks defined
 // using [TestAPI.DefaultRequestParams] or
 // [TestAPI.AddDefaultRequestParams] are also copied.
 //
+// In session mode, the cookie jar is not copied but shared, so
+// cookies received by one instance are sent by the other, see
+// [TestAPI.Session].
+//
 // It is typically used when the [TestAPI] instance is “reused” with
 // additionnal configuration, as in:
 //
@@ -105,13 +175,24 @@ func NewTestAPI(tb testing.TB, handler http.Handler) *TestAPI {
 //	  taWithHeaders.Get("/test").CmpStatus(200)
 //	}
 func (ta *TestAPI) Clone() *TestAPI {
-	nta := &TestAPI{
-		t:                ta.t,
+	return ta.newChild(ta.t).DefaultRequestParams(
+		ta.defaultHeader, ta.defaultQParams, ta.defaultCookies, ta.defaultHook)
+}
+
+// newChild returns a new [*TestAPI] based on t and sharing the
+// configuration of ta, but neither its default request params nor its
+// last request & response.
+func (ta *TestAPI) newChild(t *td.T) *TestAPI {
+	return &TestAPI{
+		t:                t,
 		handler:          ta.handler,
+		client:           ta.client,
+		baseURL:          ta.baseURL,
 		autoDumpResponse: ta.autoDumpResponse,
+		noDecodeBody:     ta.noDecodeBody,
+		jar:              ta.jar,
+		openAPI:          ta.openAPI,
 	}
-	return nta.DefaultRequestParams(
-		ta.defaultHeader, ta.defaultQParams, ta.defaultCookies, ta.defaultHook)
 }
 
 // With creates a new [*TestAPI] instance copied from ta, but
@@ -153,10 +234,15 @@ func (ta *TestAPI) T() *td.T {
 	return ta.t
 }
 
-// Run runs f as a subtest of t called name.
+// Run runs f as a subtest of t called name. The [*TestAPI] instance
+// passed to f shares the configuration of ta, but not the header
+// values, query params, cookies and hooks defined using
+// [TestAPI.DefaultRequestParams] or [TestAPI.AddDefaultRequestParams].
+// In session mode, the cookie jar is shared with ta, see
+// [TestAPI.Session].
 func (ta *TestAPI) Run(name string, f func(ta *TestAPI)) bool {
 	return ta.t.Run(name, func(tdt *td.T) {
-		f(NewTestAPI(tdt, ta.handler))
+		f(ta.newChild(tdt))
 	})
 }
 
@@ -172,6 +258,137 @@ func (ta *TestAPI) AutoDumpResponse(enable ...bool) *TestAPI {
 	return ta
 }
 
+// AutoDecodeBody allows to enable or disable the automatic decoding
+// of the received response body, depending on its Content-Encoding
+// header. It is enabled by default.
+//
+//	ta.AutoDecodeBody(false)
+//
+// disables it, so all Cmp*Body methods see the body as received.
+//
+// gzip (and x-gzip) and deflate encodings are supported, other ones
+// are ignored and the body is kept as is. As the net/http client does
+// when it transparently decompresses a response, the Content-Encoding
+// and Content-Length headers are removed once the body decoded, so
+// disable the decoding to check them. The body as received remains
+// available through [TestAPI.CmpRawBody].
+func (ta *TestAPI) AutoDecodeBody(enable ...bool) *TestAPI {
+	ta.noDecodeBody = len(enable) > 0 && !enable[0]
+	return ta
+}
+
+// AutoCmpOpenAPI enables the automatic check of each received
+// response against the OpenAPI 3 document spec, as
+// [TestAPI.CmpOpenAPI] does, just after the request is sent. spec
+// can be a filename or a [*OpenAPISpec] returned by [LoadOpenAPI]. A
+// nil spec disables the automatic check.
+//
+//	ta := tdhttp.NewTestAPI(t, mux).AutoCmpOpenAPI("testdata/openapi.yaml")
+//
+//	ta.Get("/person/42").
+//	  CmpStatus(http.StatusOK). // response already checked against the spec
+//	  CmpJSONBody(td.JSON(`{"id": 42, "name": "Bob"}`))
+//
+// It fatals if spec cannot be loaded.
+func (ta *TestAPI) AutoCmpOpenAPI(spec any) *TestAPI {
+	ta.t.Helper()
+
+	if spec == nil {
+		ta.openAPI = nil
+		return ta
+	}
+	s, err := loadOpenAPI(spec)
+	if err != nil {
+		ta.t.Fatal(err)
+	}
+	ta.openAPI = s
+	return ta
+}
+
+// Session allows to enable or disable the session mode. In session
+// mode, cookies received in responses are stored in a cookie jar,
+// then automatically sent in the next requests, as a browser does.
+//
+//	ta := tdhttp.NewTestAPI(t, mux).Session()
+//
+//	ta.PostForm("/login", tdhttp.Q{"user": "bob", "password": "secret"}).
+//	  CmpStatus(http.StatusOK)
+//
+//	ta.Get("/profile"). // session cookie automatically sent
+//	  CmpStatus(http.StatusOK)
+//
+// The cookie jar is a [cookiejar.Jar], so Path, Domain, Expires,
+// Max-Age and Secure cookie attributes are respected. With
+// [NewTestAPI], the domain of the requests is "example.com" unless
+// another host is set in the target or using the "Host" header, and
+// Secure cookies are only sent to "https://…" targets.
+//
+// A cookie already set in the request, explicitly or using
+// [TestAPI.DefaultRequestParams], is never overridden by the jar
+// one.
+//
+//	ta.Session()
+//	ta.Session(true)
+//
+// both enable the session mode, keeping the current jar if the
+// session mode is already enabled.
+//
+//	ta.Session(false)
+//
+// disables it and forgets the jar.
+//
+// The jar is not copied but shared with instances returned by
+// [TestAPI.Clone] and [TestAPI.With], and with the ones passed to
+// [TestAPI.Run] functions: cookies received by any of them are sent
+// by all the others. Use [TestAPI.ClearSession] on an instance to
+// give it its own empty jar.
+//
+// See also [TestAPI.SessionCookies] and [TestAPI.ClearSession].
+func (ta *TestAPI) Session(enable ...bool) *TestAPI {
+	if len(enable) > 0 && !enable[0] {
+		ta.jar = nil
+	} else if ta.jar == nil {
+		ta.ClearSession()
+	}
+	return ta
+}
+
+// ClearSession forgets all the cookies stored in the session jar,
+// enabling the session mode if it is not already.
+//
+// Note that instances sharing the previous jar, created by
+// [TestAPI.Clone], [TestAPI.With] or [TestAPI.Run], keep using it.
+//
+// See [TestAPI.Session].
+func (ta *TestAPI) ClearSession() *TestAPI {
+	ta.jar, _ = cookiejar.New(nil) // never fails with nil options
+	return ta
+}
+
+// SessionCookies returns the cookies stored in the session jar that
+// would be sent in a request to target. target is a path or a URL,
+// as for [TestAPI.Get]. As returned by [http.CookieJar], only the
+// Name and Value fields of each cookie are set.
+//
+//	ta.Get("/login").CmpStatus(http.StatusOK)
+//	td.Cmp(t, ta.SessionCookies("/"),
+//	  td.Bag(td.Struct(&http.Cookie{Name: "session_id"}, nil)))
+//
+// It returns nil if the session mode is not enabled.
+//
+// See [TestAPI.Session].
+func (ta *TestAPI) SessionCookies(target string) []*http.Cookie {
+	if ta.jar == nil {
+		return nil
+	}
+	ta.t.Helper()
+	req, err := newRequest(http.MethodGet, target, nil, nil)
+	if err != nil {
+		ta.t.Fatal(err)
+	}
+	return ta.jar.Cookies(ta.requestURL(req))
+}
+
 // DefaultRequestParams allows to define header values, query params,
 // cookies and hooks to be automatically set for each future requests
 // sent by [TestAPI.Request], [TestAPI.Get], [TestAPI.Head],
@@ -354,6 +571,15 @@ func (ta *TestAPI) Request(req *http.Request) *TestAPI {
 			req.AddCookie(c)
 		}
 	}
+	var jarURL *url.URL
+	if ta.jar != nil {
+		jarURL = ta.requestURL(req)
+		for _, c := range ta.jar.Cookies(jarURL) {
+			if _, err := req.Cookie(c.Name); err != nil {
+				req.AddCookie(c)
+			}
+		}
+	}
 	if ta.defaultHook != nil {
 		if err := ta.defaultHook(req); err != nil {
 			ta.t.Fatalf("hook failed: %s", err)
@@ -361,16 +587,145 @@ func (ta *TestAPI) Request(req *http.Request) *TestAPI {
 	}
 
 	ta.response = httptest.NewRecorder()
+	ta.rawBody = nil
 
 	ta.failed = 0
 	ta.sentAt = time.Now().Truncate(0)
 	ta.responseDumped = false
+	ta.reqMethod, ta.reqPath = req.Method, req.URL.Path
 
-	ta.handler.ServeHTTP(ta.response, req)
+	if ta.client == nil {
+		ta.handler.ServeHTTP(ta.response, req)
+	} else if err := ta.sendRequest(req); err != nil {
+		ta.response = nil
+		ta.t.Fatalf("request failed: %s", err)
+	}
+
+	if ta.jar != nil {
+		ta.jar.SetCookies(jarURL, ta.response.Result().Cookies())
+	}
 
+	if !ta.noDecodeBody {
+		ta.decodeBody()
+	}
+
+	if ta.openAPI != nil {
+		ta.cmpOpenAPI(ta.openAPI)
+	}
 	return ta
 }
 
+// decodeBody decodes the received response body depending on its
+// Content-Encoding header. If the decoding fails, the body is kept
+// as is and the failure is reported.
+func (ta *TestAPI) decodeBody() {
+	ta.t.Helper()
+
+	raw := ta.response.Body.Bytes()
+	if len(raw) == 0 { // HEAD requests or 204/304 responses
+		return
+	}
+	body, decoded, err := decodeContentEncoding(
+		ta.response.Header().Get("Content-Encoding"), raw)
+	if !ta.t.RootName("Response.Body").
+		CmpNoError(err, ta.name+"body decoding") {
+		ta.failed |= bodyFailed
+		return
+	}
+	if decoded {
+		ta.rawBody = raw
+		ta.response = decodedRecorder(ta.response, body)
+	}
+}
+
+// decodedRecorder returns a copy of rec with body as body and without
+// the Content-Encoding and Content-Length headers, which do not apply
+// to the decoded body anymore.
+func decodedRecorder(rec *httptest.ResponseRecorder, body []byte) *httptest.ResponseRecorder {
+	res := rec.Result()
+
+	nrec := httptest.NewRecorder()
+	header := nrec.Header()
+	for k, v := range res.Header {
+		header[k] = v
+	}
+	header.Del("Content-Encoding")
+	header.Del("Content-Length")
+	nrec.WriteHeader(res.StatusCode)
+	nrec.Write(body) //nolint: errcheck
+
+	// Trailers are set after the body, as a handler does
+	for k, v := range res.Trailer {
+		header[k] = v
+	}
+	return nrec
+}
+
+// requestURL returns the absolute URL req targets. When ta sends its
+// requests over the network, it is the base URL followed by the path
+// and query of req. Otherwise, as the request is served locally, the
+// host comes from req.Host and the scheme is "https" for "https://…"
+// targets or if req.TLS is set.
+func (ta *TestAPI) requestURL(req *http.Request) *url.URL {
+	if ta.baseURL != nil {
+		u := *ta.baseURL
+		u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + req.URL.EscapedPath()
+		u.Path = strings.TrimSuffix(u.Path, "/") + req.URL.Path
+		u.RawQuery = req.URL.RawQuery
+		u.Fragment = ""
+		return &u
+	}
+
+	u := url.URL{
+		Scheme:   "http",
+		Host:     req.Host,
+		Path:     req.URL.Path,
+		RawQuery: req.URL.RawQuery,
+	}
+	if req.TLS != nil || getTargetInfo(req).https {
+		u.Scheme = "https"
+	}
+	return &u
+}
+
+// sendRequest sends req over the network using ta.client and records
+// the received response in ta.response, as if it was returned by a
+// local handler.
+func (ta *TestAPI) sendRequest(req *http.Request) error {
+	req.URL = ta.requestURL(req)
+	if host := getTargetInfo(req).defaultHost; host != "" && req.Host == host {
+		req.Host = "" // not set by the caller, so use the one of req.URL
+	}
+	req.RequestURI = ""
+
+	resp, err := ta.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	header := ta.response.Header()
+	for k, v := range resp.Header {
+		header[k] = v
+	}
+	for k := range resp.Trailer {
+		header.Add("Trailer", k)
+	}
+	ta.response.WriteHeader(resp.StatusCode)
+	ta.response.Write(body) //nolint: errcheck
+
+	// Trailers are now available, as the body has been entirely read
+	for k, v := range resp.Trailer {
+		header[k] = v
+	}
+	return nil
+}
+
 func (ta *TestAPI) checkRequestSent() bool {
 	ta.t.Helper()
 
@@ -1339,6 +1694,254 @@ func (ta *TestAPI) CmpXMLBody(expectedBody any) *TestAPI {
 	return ta.CmpMarshaledBody(xml.Unmarshal, expectedBody)
 }
 
+// CmpSSEEvents tests that the last request response body, parsed as
+// a "text/event-stream" (aka Server-Sent Events), matches
+// expectedEvents. expectedEvents can be a []SSEEvent, any other type
+// one can [json.Unmarshal] the JSON representation of a []SSEEvent
+// into, or a [td.TestDeep] operator.
+//
+// Comments are ignored. Contrary to what a browser does, events
+// without data field are kept, and an event ID is not inherited from
+// the previous events. An event not terminated by an empty line is
+// discarded, as a browser does.
+//
+//	ta := tdhttp.NewTestAPI(t, mux)
+//
+//	ta.Get("/events").
+//	  CmpStatus(http.StatusOK).
+//	  CmpHeader(td.SuperMapOf(http.Header{
+//	    "Content-Type": {"text/event-stream"},
+//	  }, nil)).
+//	  CmpSSEEvents([]tdhttp.SSEEvent{
+//	    {Event: "start", ID: "1"},
+//	    {Event: "progress", Data: `{"percent":50}`},
+//	    {Event: "end", ID: "2", Data: "done"},
+//	  })
+//
+// Using operators, failures report the index of the failing event,
+// as in Response.Body[1].Data:
+//
+//	ta.Get("/events").
+//	  CmpSSEEvents(td.Bag(
+//	    td.Smuggle("Event", "end"),
+//	    td.Smuggle("Data", td.HasPrefix(`{"percent":`)),
+//	    td.Ignore(),
+//	  ))
+//
+//	ta.Get("/events").
+//	  CmpSSEEvents(td.JSON(`[
+//	    {"event": "start", "id": "1"},
+//	    {"event": "progress", "data": NotEmpty()},
+//	    {"event": "end", "id": "2", "data": "done"}
+//	  ]`))
+//
+// Note that with [td.JSON], only fields set in an event appear in
+// its JSON representation.
+//
+// It fails if no request has been sent yet.
+func (ta *TestAPI) CmpSSEEvents(expectedEvents any) *TestAPI {
+	ta.t.Helper()
+	return ta.cmpMarshaledBody(true, unmarshalSSE, expectedEvents)
+}
+
+// CmpJSONLines tests that the last request response body, parsed as
+// newline delimited JSON (aka NDJSON or JSON Lines), matches
+// expectedLines. The body is [json.Unmarshal]'ed as if it was a JSON
+// array of all its non-empty lines, so expectedLines can be any slice
+// type one can [json.Unmarshal] into, or a [td.TestDeep] operator.
+//
+//	ta := tdhttp.NewTestAPI(t, mux)
+//
+//	ta.Get("/progress").
+//	  CmpStatus(http.StatusOK).
+//	  CmpJSONLines([]Progress{
+//	    {Step: 1, Status: "running"},
+//	    {Step: 2, Status: "done"},
+//	  })
+//
+//	ta.Get("/progress").
+//	  CmpJSONLines(td.JSON(`[
+//	    {"step": 1, "status": "running"},
+//	    {"step": 2, "status": "done"}
+//	  ]`))
+//
+//	ta.Get("/progress").
+//	  CmpJSONLines(td.All(
+//	    td.Isa([]Progress{}),
+//	    td.ArrayEach(td.Smuggle("Status", td.Re(`^(running|done)\z`))),
+//	  ))
+//
+// Failures report the index of the failing line, as in
+// Response.Body[1].Status.
+//
+// It fails if no request has been sent yet.
+func (ta *TestAPI) CmpJSONLines(expectedLines any) *TestAPI {
+	ta.t.Helper()
+	return ta.cmpMarshaledBody(true, unmarshalJSONLines, expectedLines)
+}
+
+// CmpRawBody tests the last request response body, as received
+// before any Content-Encoding decoding, against
+// expectedBody. expectedBody can be a []byte or a [td.TestDeep]
+// operator. It is typically useful to check the compressed size of
+// a body:
+//
+//	ta := tdhttp.NewTestAPI(t, gzipMiddleware(mux))
+//
+//	ta.Get("/big", "Accept-Encoding", "gzip").
+//	  CmpStatus(http.StatusOK).
+//	  CmpRawBody(td.Len(td.Lt(1024))).
+//	  CmpJSONBody(td.Len(1000))
+//
+// If the body has not been decoded, it is the same as [TestAPI.CmpBody]
+// with a []byte.
+//
+// It fails if no request has been sent yet.
+//
+// See also [TestAPI.AutoDecodeBody].
+func (ta *TestAPI) CmpRawBody(expectedBody any) *TestAPI {
+	defer ta.t.AnchorsPersistTemporarily()()
+
+	ta.t.Helper()
+
+	if !ta.checkRequestSent() {
+		ta.failed |= bodyFailed
+		return ta
+	}
+
+	raw := ta.rawBody
+	if raw == nil {
+		raw = ta.response.Body.Bytes()
+	}
+
+	if !ta.t.RootName("Response.RawBody").
+		Cmp(raw, expectedBody, ta.name+"raw body should match") {
+		ta.failed |= bodyFailed
+
+		if ta.autoDumpResponse {
+			ta.dumpResponse()
+		}
+	}
+
+	return ta
+}
+
+// CmpOpenAPI tests the last request response against the OpenAPI 3
+// document spec. spec can be a filename or a [*OpenAPISpec] returned
+// by [LoadOpenAPI]. Files are cached, and reloaded as soon as they
+// are modified.
+//
+// The path and the method of the request select the operation in
+// the document, then the status code selects its response, trying
+// the exact code, then its range (as "2XX"), then "default". The
+// response has to fit this description:
+//
+//   - required headers must be present and all described headers must
+//     fit their schema;
+//   - the Content-Type header must match one of the described media
+//     types, or the body must be empty if no content is described;
+//   - for JSON media types, the body must fit the schema.
+//
+// For example:
+//
+//	ta.Get("/person/42").
+//	  CmpOpenAPI("testdata/openapi.yaml").
+//	  CmpJSONBody(td.JSON(`{"id": 42, "name": "Bob"}`))
+//
+// Schema violations are reported as one error per faulty value, at
+// its location in the body or the header, with the JSON pointer of
+// the violated keyword in the document, as in:
+//
+//	Response.Body.children[1].age does not fit OpenAPI spec of GET /person/{id} 200 response
+//		expected integer, got string (#/components/schemas/Person/properties/age/type)
+//
+// It fails if no request has been sent yet, and fatals if spec
+// cannot be loaded.
+//
+// See also [TestAPI.AutoCmpOpenAPI].
+func (ta *TestAPI) CmpOpenAPI(spec any) *TestAPI {
+	ta.t.Helper()
+
+	s, err := loadOpenAPI(spec)
+	if err != nil {
+		ta.t.Fatal(err)
+	}
+	return ta.cmpOpenAPI(s)
+}
+
+func (ta *TestAPI) cmpOpenAPI(spec *OpenAPISpec) *TestAPI {
+	defer ta.t.AnchorsPersistTemporarily()()
+
+	ta.t.Helper()
+
+	if !ta.checkRequestSent() {
+		ta.failed |= responseFailed
+		return ta
+	}
+
+	fail := func(f failed) {
+		ta.failed |= f
+		if ta.autoDumpResponse {
+			ta.dumpResponse()
+		}
+	}
+
+	var op, r *openAPIResponse
+	if !ta.t.RootName("Request").
+		Code(ta.reqMethod+" "+ta.reqPath,
+			func(string) error {
+				var cErr *ctxerr.Error
+				op, cErr = spec.findOperation(ta.reqMethod, ta.reqPath)
+				return ctxErrorOrNil(cErr)
+			},
+			ta.name+"response should be described in OpenAPI spec") {
+		fail(responseFailed)
+		return ta
+	}
+
+	if !ta.t.RootName("Response.Status").
+		Code(ta.response.Code,
+			func(status int) error {
+				var cErr *ctxerr.Error
+				r, cErr = spec.findResponse(op, status)
+				return ctxErrorOrNil(cErr)
+			},
+			ta.name+"response should be described in OpenAPI spec") {
+		fail(statusFailed)
+		return ta
+	}
+
+	header := ta.response.Header()
+	if !ta.t.RootName("Response.Header").
+		Code(header,
+			func(header http.Header) error {
+				return ctxErrorOrNil(spec.checkHeader(r, header, ctxerr.NewPath("Response.Header")))
+			},
+			ta.name+"header should fit OpenAPI spec") {
+		fail(headerFailed)
+	}
+
+	if !ta.t.RootName("Response.Body").
+		Code(ta.response.Body.Bytes(),
+			func(body []byte) error {
+				return ctxErrorOrNil(spec.checkBody(r, header.Get("Content-Type"), body,
+					ctxerr.NewPath("Response.Body")))
+			},
+			ta.name+"body should fit OpenAPI spec") {
+		fail(bodyFailed)
+	}
+
+	return ta
+}
+
+// ctxErrorOrNil returns cErr as an error, or nil if cErr is nil.
+func ctxErrorOrNil(cErr *ctxerr.Error) error {
+	if cErr == nil {
+		return nil
+	}
+	return cErr
+}
+
 // NoBody tests that the last request response body is empty.
 //
 // It fails if no request has been sent yet.
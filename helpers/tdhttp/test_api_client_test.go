@@ -0,0 +1,214 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdhttp_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/helpers/tdutil"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestNewTestAPIClient(t *testing.T) {
+	mux := server()
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, req *http.Request) {
+		http.Redirect(w, req, "/any", http.StatusFound)
+	})
+	mux.HandleFunc("/proto", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Proto", req.Proto)
+		if req.TLS != nil {
+			w.Header().Set("X-TLS", "yes")
+		}
+	})
+
+	mux.HandleFunc("/host", func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, req.Host) //nolint: errcheck
+	})
+	mux.HandleFunc("/path/", func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, req.URL.EscapedPath()) //nolint: errcheck
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	containsKey := td.ContainsKey("X-Testdeep-Method")
+
+	t.Run("No error", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPIClient(mockT, srv.URL, nil)
+
+		td.CmpFalse(t,
+			ta.Get("/any").
+				CmpStatus(200).
+				CmpHeader(containsKey).
+				CmpBody("GET!").
+				Failed())
+
+		type Person struct {
+			Name string `json:"name"`
+			Age  int    `json:"age"`
+		}
+		td.CmpFalse(t,
+			ta.PostJSON("/mirror/json", Person{Name: "Bob", Age: 42}).
+				CmpStatus(200).
+				CmpJSONBody(Person{
+					Name: ta.A(td.HasPrefix("Bo"), "").(string),
+					Age:  42,
+				}).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/any/cookies").
+				CmpStatus(200).
+				CmpCookies(td.SuperBagOf(td.Smuggle("Name", "second"))).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/any/trailer").
+				CmpStatus(200).
+				CmpTrailer(http.Header{
+					"X-Testdeep-Method": {"GET"},
+					"X-Testdeep-Foo":    {"bar"},
+				}).
+				CmpBody("Hey!").
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/hq/json", tdhttp.Q{"a": 1}).
+				CmpStatus(200).
+				CmpJSONBody(td.SuperJSONOf(`{"query_params": {"a": ["1"]}}`)).
+				Failed())
+
+		td.CmpFalse(t,
+			ta.Get("/host").
+				CmpBody(strings.TrimPrefix(srv.URL, "http://")).
+				Failed())
+		td.CmpFalse(t,
+			ta.Get("/host", "Host", "example.org").
+				CmpBody("example.org").
+				Failed())
+		td.CmpFalse(t,
+			ta.Get("/host", "Host", "example.com").
+				CmpBody("example.com").
+				Failed())
+		td.CmpFalse(t,
+			ta.Get("http://example.com/host").
+				CmpBody("example.com").
+				Failed())
+
+		// Escaped path segments are kept
+		td.CmpFalse(t,
+			ta.Get("/path/a%2Fb/c").
+				CmpBody("/path/a%2Fb/c").
+				Failed())
+
+		// Redirects are followed by the client
+		td.CmpFalse(t,
+			ta.Get("/redirect").
+				CmpStatus(200).
+				CmpBody("GET!").
+				Failed())
+
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("Base URL with path", func(t *testing.T) {
+		prefixed := http.NewServeMux()
+		prefixed.Handle("/api/", http.StripPrefix("/api", mux))
+		psrv := httptest.NewServer(prefixed)
+		defer psrv.Close()
+
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPIClient(mockT, psrv.URL+"/api/", psrv.Client())
+		td.CmpFalse(t,
+			ta.Get("/any").
+				CmpStatus(200).
+				CmpBody("GET!").
+				Failed())
+		td.CmpFalse(t,
+			ta.Get("/path/a%2Fb").
+				CmpStatus(200).
+				CmpBody("/path/a%2Fb").
+				Failed())
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("TLS & HTTP/2", func(t *testing.T) {
+		tsrv := httptest.NewUnstartedServer(mux)
+		tsrv.EnableHTTP2 = true
+		tsrv.StartTLS()
+		defer tsrv.Close()
+
+		mockT := tdutil.NewT("test")
+		td.CmpFalse(t,
+			tdhttp.NewTestAPIClient(mockT, tsrv.URL, tsrv.Client()).
+				Get("/proto").
+				CmpStatus(200).
+				CmpHeader(td.SuperMapOf(http.Header{
+					"X-Proto": {"HTTP/2.0"},
+					"X-Tls":   {"yes"},
+				}, nil)).
+				Failed())
+		td.CmpEmpty(t, mockT.LogBuf())
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		mockT := tdutil.NewT("test")
+		ta := tdhttp.NewTestAPIClient(mockT, srv.URL, srv.Client()).
+			AutoDumpResponse()
+		td.CmpTrue(t, ta.Get("/any").CmpStatus(400).Failed())
+		td.CmpContains(t, mockT.LogBuf(), "Response.Status: values differ")
+		td.CmpContains(t, mockT.LogBuf(), "Received response:")
+
+		// Or works as usual
+		var body string
+		ta.Or(func(b string) { body = b })
+		td.Cmp(t, body, "GET!")
+
+		// Bad base URL
+		mockT = tdutil.NewT("test")
+		td.CmpTrue(t, mockT.CatchFailNow(func() {
+			tdhttp.NewTestAPIClient(mockT, ":bad", nil)
+		}))
+		td.CmpContains(t, mockT.LogBuf(), "NewTestAPIClient: bad base URL: ")
+
+		// Closed server
+		csrv := httptest.NewServer(mux)
+		csrv.Close()
+		mockT = tdutil.NewT("test")
+		ta = tdhttp.NewTestAPIClient(mockT, csrv.URL, nil)
+		td.CmpTrue(t, mockT.CatchFailNow(func() { ta.Get("/any") }))
+		td.CmpContains(t, mockT.LogBuf(), "request failed: ")
+	})
+
+	t.Run("Clone, With & Run", func(t *testing.T) {
+		ta := tdhttp.NewTestAPIClient(tdutil.NewT("test"), srv.URL, nil)
+
+		td.CmpFalse(t, ta.Clone().Get("/any").CmpStatus(200).Failed())
+
+		mockT := tdutil.NewT("test")
+		td.CmpFalse(t, ta.With(mockT).Get("/any").CmpStatus(200).Failed())
+		td.CmpEmpty(t, mockT.LogBuf())
+
+		td.CmpTrue(t, ta.Run("sub", func(ta *tdhttp.TestAPI) {
+			ta.Get("/any").CmpStatus(200).CmpBody(td.HasSuffix("!"))
+		}))
+
+		// Run inherits the configuration of ta
+		mockT = tdutil.NewT("test")
+		ta = tdhttp.NewTestAPIClient(mockT, srv.URL, nil).AutoDumpResponse()
+		td.CmpFalse(t, ta.Run("sub", func(ta *tdhttp.TestAPI) {
+			ta.Get("/any").CmpStatus(400)
+		}))
+		td.CmpContains(t, mockT.LogBuf(), "Received response:")
+	})
+}
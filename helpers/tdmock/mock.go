@@ -0,0 +1,407 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package tdmock, from [go-testdeep], allows to record calls to
+// fakes and to assert on them using [td] operators.
+//
+// A fake records each call it receives using [Mock.Called]:
+//
+//	type FakeStore struct {
+//	  *tdmock.Mock
+//	}
+//
+//	func (s *FakeStore) Get(id int) (*Person, error) {
+//	  rets := s.Called("Get", id)
+//	  p, _ := rets.Get(0).(*Person)
+//	  return p, rets.Error(1)
+//	}
+//
+// Then expectations, whose arguments can be any [td] operator, set
+// the values returned by the fake and how many times each call is
+// expected:
+//
+//	func TestMyFunc(t *testing.T) {
+//	  store := &FakeStore{Mock: tdmock.New(t)}
+//
+//	  store.Expect("Get", td.Between(1, 10)).
+//	    Return(&Person{Name: "Bob"}, nil).
+//	    Times(2)
+//
+//	  MyFunc(store)
+//	}
+//
+// A call not matching any expectation of the same method is reported
+// as soon as it occurs. Unmet expectations are reported during
+// cleanup of the test.
+//
+// Recorded calls can also be checked afterwards, as a sequence, as a
+// [td.Bag] or using any operator:
+//
+//	store.CmpSequence(
+//	  tdmock.C("Get", 1),
+//	  tdmock.C("Get", td.Gt(1)),
+//	)
+//	store.CmpUnordered(
+//	  tdmock.C("Get", td.Gt(1)),
+//	  tdmock.C("Get", 1),
+//	)
+//	store.CmpCallCount("Get", td.Gte(2))
+//
+// Failures are reported using the usual go-testdeep format, with paths
+// like CALLS[2].Args[1].Name.
+//
+// [go-testdeep]: https://go-testdeep.zetta.rocks/
+package tdmock
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/util"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// Call is a call recorded by [Mock.Called].
+type Call struct {
+	Method string // Method is the name of the called method
+	Args   []any  // Args are the arguments of the call, never nil
+	Rets   []any  // Rets are the values returned to the caller, if any
+}
+
+// String returns a string representation of c, as "Method(args…)".
+func (c Call) String() string {
+	return callString(c.Method, c.Args)
+}
+
+// C returns a [td.TestDeep] operator matching a [Call] of method
+// with arguments args. Each arg can be a [td.TestDeep] operator. The
+// returned values of the call are ignored.
+//
+//	mock.CmpSequence(
+//	  tdmock.C("Open", "/tmp/foo"),
+//	  tdmock.C("Write", td.Len(td.Gt(0))),
+//	  tdmock.C("Close"),
+//	)
+func C(method string, args ...any) td.TestDeep {
+	return td.Struct(Call{}, td.StructFields{
+		"Method": method,
+		"Args":   normArgs(args),
+	})
+}
+
+// Rets are the values to return, as set by [Expectation.Return].
+type Rets []any
+
+// Get returns the i-th value of r, or nil if r has not so many
+// values.
+func (r Rets) Get(i int) any {
+	if i < 0 || i >= len(r) {
+		return nil
+	}
+	return r[i]
+}
+
+// Error returns the i-th value of r as an error, or nil if r has not
+// so many values or if the value is nil.
+//
+// It panics if the i-th value is not nil and not an error.
+func (r Rets) Error(i int) error {
+	v := r.Get(i)
+	if v == nil {
+		return nil
+	}
+	return v.(error)
+}
+
+// Expectation is an expected call registered by [Mock.Expect].
+type Expectation struct {
+	mu     *sync.Mutex // shared with the Mock
+	method string
+	args   []any
+	rets   Rets
+	times  any // int or td.TestDeep
+	max    int // < 0 means unlimited
+	count  int
+}
+
+// Return sets the values returned by [Mock.Called] when e matches.
+func (e *Expectation) Return(rets ...any) *Expectation {
+	e.mu.Lock()
+	e.rets = rets
+	e.mu.Unlock()
+	return e
+}
+
+// Times sets how many times e is expected to be called. expected can
+// be an int or a [td.TestDeep] operator as [td.Between]. When
+// expected is an int, e does not match anymore once called expected
+// times. When it is an operator, e always matches and the number of
+// calls is only checked at verification time. The default is 1.
+//
+//	mock.Expect("Get", 12).Times(3)
+//	mock.Expect("Get", 13).Times(td.Between(1, 3))
+func (e *Expectation) Times(expected any) *Expectation {
+	e.mu.Lock()
+	e.times = expected
+	if n, ok := expected.(int); ok {
+		e.max = n
+	} else {
+		e.max = -1
+	}
+	e.mu.Unlock()
+	return e
+}
+
+// Maybe is a shortcut for Times(td.Gte(0)): e can be called any
+// number of times, even never.
+func (e *Expectation) Maybe() *Expectation {
+	return e.Times(td.Gte(0))
+}
+
+// String returns a string representation of e, as "Method(args…)".
+func (e *Expectation) String() string {
+	return callString(e.method, e.args)
+}
+
+// Mock records calls and checks them against expectations. It is safe
+// for concurrent use. It is created by [New].
+type Mock struct {
+	t *td.T
+
+	mu           sync.Mutex
+	calls        []Call
+	expectations []*Expectation
+	verified     bool
+}
+
+// New returns a new [*Mock] reporting failures to t, which can be a
+// [*td.T] (its configuration is then inherited) or any [testing.TB]
+// implementation. Unmet expectations are checked when t is cleaned
+// up, unless [Mock.Verify] has already been called.
+func New(t testing.TB) *Mock {
+	m := &Mock{t: td.NewT(t)}
+	m.t.Cleanup(func() {
+		m.mu.Lock()
+		verified := m.verified
+		m.mu.Unlock()
+		if !verified {
+			m.t.Helper()
+			m.Verify()
+		}
+	})
+	return m
+}
+
+// Expect registers and returns a new [*Expectation] for a call of
+// method with arguments args. Each arg can be a [td.TestDeep]
+// operator. By default, the expectation has to be called once, see
+// [Expectation.Times] and [Expectation.Maybe] to change this.
+//
+// When several expectations match a call, the first registered not
+// exhausted one wins.
+func (m *Mock) Expect(method string, args ...any) *Expectation {
+	e := &Expectation{
+		mu:     &m.mu,
+		method: method,
+		args:   normArgs(args),
+		times:  1,
+		max:    1,
+	}
+
+	m.mu.Lock()
+	m.expectations = append(m.expectations, e)
+	m.mu.Unlock()
+
+	return e
+}
+
+// Called records a call of method with arguments args and returns the
+// values set by the matching expectation, if any.
+//
+// If no expectation has been registered for method, the call is
+// only recorded (the mock is then a simple spy) and nil is
+// returned. Otherwise, a call not matching any expectation is
+// reported as an error, with the reason why it does not match the
+// first expectation of the same method.
+func (m *Mock) Called(method string, args ...any) Rets {
+	m.t.Helper()
+
+	args = normArgs(args)
+
+	m.mu.Lock()
+
+	idx := len(m.calls)
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+
+	var candidates []*Expectation
+	for _, e := range m.expectations {
+		if e.method == method {
+			candidates = append(candidates, e)
+		}
+	}
+	m.mu.Unlock()
+
+	if candidates == nil {
+		return nil
+	}
+
+	// Match without holding the lock, as an operator can use m again
+	for _, e := range candidates {
+		if !td.EqDeeply(args, e.args) {
+			continue
+		}
+
+		m.mu.Lock()
+		if e.max >= 0 && e.count >= e.max {
+			m.mu.Unlock()
+			continue
+		}
+		e.count++
+		m.calls[idx].Rets = e.rets
+		rets := e.rets
+		m.mu.Unlock()
+		return rets
+	}
+
+	first := candidates[0]
+	name := fmt.Sprintf("unexpected call %s", Call{Method: method, Args: args})
+	m.mu.Lock()
+	times := first.times
+	m.mu.Unlock()
+
+	// Report without holding the lock, as reporting can use m again
+	t := m.t.Assert().RootName(fmt.Sprintf("CALLS[%d].Args", idx))
+	if t.Cmp(args, first.args, name) {
+		t.Errorf("%s: %s called more than %v times", name, first, times)
+	}
+	return nil
+}
+
+// Calls returns a copy of all recorded calls.
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsOf returns the recorded calls of method.
+func (m *Mock) CallsOf(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []Call
+	for _, c := range m.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// CmpCalls compares all recorded calls, as a []Call, against
+// expected. It returns true if the comparison succeeds, false
+// otherwise. args are used to name the test, as in [td.Cmp].
+//
+//	mock.CmpCalls(td.Len(3))
+//	mock.CmpCalls(td.SuperBagOf(tdmock.C("Close")))
+func (m *Mock) CmpCalls(expected any, args ...any) bool {
+	m.t.Helper()
+	return m.t.RootName("CALLS").Cmp(m.Calls(), expected, args...)
+}
+
+// CmpSequence checks that recorded calls match expected, in the
+// same order. Each expected item is typically built by [C].
+//
+//	mock.CmpSequence(tdmock.C("Open", "/tmp/foo"), tdmock.C("Close"))
+//
+// See also [Mock.CmpUnordered].
+func (m *Mock) CmpSequence(expected ...any) bool {
+	m.t.Helper()
+	return m.CmpCalls(td.List(expected...), "calls sequence")
+}
+
+// CmpUnordered checks that recorded calls match expected, whatever
+// their order. It uses [td.Bag] semantics, so each call must match
+// exactly one expected item.
+//
+//	mock.CmpUnordered(tdmock.C("Get", 2), tdmock.C("Get", 1))
+//
+// See also [Mock.CmpSequence].
+func (m *Mock) CmpUnordered(expected ...any) bool {
+	m.t.Helper()
+	return m.CmpCalls(td.Bag(expected...), "unordered calls")
+}
+
+// CmpCallCount checks the number of recorded calls of method
+// against expected, an int or a [td.TestDeep] operator.
+//
+//	mock.CmpCallCount("Get", 2)
+//	mock.CmpCallCount("Close", td.Gte(1))
+func (m *Mock) CmpCallCount(method string, expected any) bool {
+	m.t.Helper()
+	return m.t.RootName("COUNT").
+		Cmp(len(m.CallsOf(method)), expected, "number of %s calls", method)
+}
+
+// Verify checks that all expectations have been called the expected
+// number of times. It is automatically called during cleanup of the
+// test, unless already called. It returns true if all expectations
+// are met, false otherwise.
+func (m *Mock) Verify() bool {
+	m.t.Helper()
+
+	m.mu.Lock()
+	m.verified = true
+	expectations := append([]*Expectation(nil), m.expectations...)
+	counts := make([]int, len(expectations))
+	times := make([]any, len(expectations))
+	for i, e := range expectations {
+		counts[i] = e.count
+		times[i] = e.times
+	}
+	m.mu.Unlock()
+
+	ok := true
+	t := m.t.Assert().RootName("COUNT")
+	for i, e := range expectations {
+		if !t.Cmp(counts[i], times[i], "number of %s calls", e) {
+			ok = false
+		}
+	}
+	return ok
+}
+
+// Reset forgets all recorded calls and expectations.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	m.calls = nil
+	m.expectations = nil
+	m.verified = false
+	m.mu.Unlock()
+}
+
+func normArgs(args []any) []any {
+	if args == nil {
+		return []any{}
+	}
+	return args
+}
+
+func callString(method string, args []any) string {
+	var b strings.Builder
+	b.WriteString(method)
+	b.WriteByte('(')
+	for i, arg := range args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(util.ToString(arg))
+	}
+	b.WriteByte(')')
+	return b.String()
+}
@@ -0,0 +1,336 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdmock_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	_ "github.com/maxatome/go-testdeep/helpers/nocolor"
+	"github.com/maxatome/go-testdeep/helpers/tdmock"
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// testingTB allows to run cleanup functions on demand.
+type testingTB struct {
+	*test.TestingTB
+	cleanups []func()
+}
+
+func newTestingTB(name string) *testingTB {
+	return &testingTB{TestingTB: test.NewTestingTB(name)}
+}
+
+func (t *testingTB) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *testingTB) runCleanups() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+	t.cleanups = nil
+}
+
+func (t *testingTB) messages() string {
+	return strings.Join(t.Messages, "\n")
+}
+
+type Person struct {
+	Name string
+	Age  int
+}
+
+type FakeStore struct {
+	*tdmock.Mock
+}
+
+func (s *FakeStore) Get(id int) (*Person, error) {
+	rets := s.Called("Get", id)
+	p, _ := rets.Get(0).(*Person)
+	return p, rets.Error(1)
+}
+
+func (s *FakeStore) Save(p Person) error {
+	return s.Called("Save", p).Error(0)
+}
+
+func (s *FakeStore) Close() {
+	s.Called("Close")
+}
+
+func TestMockExpect(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		tb := newTestingTB(t.Name())
+		store := &FakeStore{Mock: tdmock.New(tb)}
+
+		errNotFound := errors.New("not found")
+		store.Expect("Get", td.Between(1, 10)).
+			Return(&Person{Name: "Bob"}, nil).
+			Times(2)
+		store.Expect("Get", td.Gt(10)).Return(nil, errNotFound)
+		store.Expect("Save", td.Struct(Person{}, td.StructFields{"Name": "Bob"})).
+			Times(td.Between(1, 2))
+		store.Expect("Close").Maybe()
+
+		p, err := store.Get(1)
+		test.NoError(t, err)
+		if test.IsTrue(t, p != nil) {
+			test.EqualStr(t, p.Name, "Bob")
+		}
+		_, err = store.Get(2)
+		test.NoError(t, err)
+		_, err = store.Get(12)
+		test.IsTrue(t, err == errNotFound)
+		test.NoError(t, store.Save(Person{Name: "Bob", Age: 42}))
+
+		test.IsTrue(t, store.Verify())
+		tb.runCleanups()
+		test.IsFalse(t, tb.Failed(), tb.messages())
+
+		test.EqualInt(t, len(store.Calls()), 4)
+		test.EqualInt(t, len(store.CallsOf("Get")), 3)
+		test.EqualStr(t, store.Calls()[0].String(), "Get(1)")
+	})
+
+	t.Run("Unexpected call", func(t *testing.T) {
+		tb := newTestingTB(t.Name())
+		store := &FakeStore{Mock: tdmock.New(tb)}
+
+		store.Expect("Save", td.Struct(Person{}, td.StructFields{"Name": "Bob"}))
+
+		test.NoError(t, store.Save(Person{Name: "Bob"}))
+		test.NoError(t, store.Save(Person{Name: "Alice"}))
+		test.IsTrue(t, tb.Failed())
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"Failed test 'unexpected call Save(",
+			"CALLS[1].Args[0].Name: values differ",
+			`got: "Alice"`,
+			`expected: "Bob"`,
+		))
+	})
+
+	t.Run("Called too many times", func(t *testing.T) {
+		tb := newTestingTB(t.Name())
+		store := &FakeStore{Mock: tdmock.New(tb)}
+
+		store.Expect("Get", 1).Times(1)
+		store.Get(1) //nolint: errcheck
+		test.IsFalse(t, tb.Failed())
+		store.Get(1) //nolint: errcheck
+		test.IsTrue(t, tb.Failed())
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"unexpected call Get(1): Get(1) called more than 1 times"))
+	})
+
+	t.Run("Unmet expectation", func(t *testing.T) {
+		tb := newTestingTB(t.Name())
+		store := &FakeStore{Mock: tdmock.New(tb)}
+
+		store.Expect("Get", 1).Times(2)
+		store.Expect("Close")
+		store.Get(1) //nolint: errcheck
+
+		tb.runCleanups()
+		test.IsTrue(t, tb.Failed())
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"number of Get(1) calls",
+			"COUNT: values differ",
+			"got: 1",
+			"expected: 2",
+			"number of Close() calls",
+			"COUNT: values differ",
+			"got: 0",
+			"expected: 1",
+		))
+	})
+
+	t.Run("Spy", func(t *testing.T) {
+		tb := newTestingTB(t.Name())
+		store := &FakeStore{Mock: tdmock.New(tb)}
+
+		p, err := store.Get(3)
+		test.IsTrue(t, p == nil)
+		test.NoError(t, err)
+		store.Close()
+
+		tb.runCleanups()
+		test.IsFalse(t, tb.Failed(), tb.messages())
+		test.EqualInt(t, len(store.Calls()), 2)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		tb := newTestingTB(t.Name())
+		store := &FakeStore{Mock: tdmock.New(tb)}
+
+		store.Expect("Close")
+		store.Close()
+		store.Reset()
+		test.EqualInt(t, len(store.Calls()), 0)
+
+		tb.runCleanups()
+		test.IsFalse(t, tb.Failed(), tb.messages())
+	})
+}
+
+func TestMockCmp(t *testing.T) {
+	newStore := func(t *testing.T) (*testingTB, *FakeStore) {
+		tb := newTestingTB(t.Name())
+		store := &FakeStore{Mock: tdmock.New(tb)}
+		store.Get(1)                               //nolint: errcheck
+		store.Save(Person{Name: "Bob", Age: 42})   //nolint: errcheck
+		store.Save(Person{Name: "Alice", Age: 37}) //nolint: errcheck
+		store.Close()
+		return tb, store
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		tb, store := newStore(t)
+
+		test.IsTrue(t, store.CmpSequence(
+			tdmock.C("Get", 1),
+			tdmock.C("Save", td.Struct(Person{}, td.StructFields{"Name": "Bob"})),
+			tdmock.C("Save", td.Smuggle("Age", td.Lt(40))),
+			tdmock.C("Close"),
+		))
+		test.IsTrue(t, store.CmpUnordered(
+			tdmock.C("Close"),
+			tdmock.C("Save", td.Smuggle("Age", td.Lt(40))),
+			tdmock.C("Save", td.Smuggle("Age", td.Gt(40))),
+			tdmock.C("Get", td.Ignore()),
+		))
+		test.IsTrue(t, store.CmpCalls(td.Len(4)))
+		test.IsTrue(t, store.CmpCallCount("Save", 2))
+		test.IsTrue(t, store.CmpCallCount("Get", td.Gte(1)))
+		test.IsFalse(t, tb.Failed(), tb.messages())
+	})
+
+	t.Run("Sequence failure", func(t *testing.T) {
+		tb, store := newStore(t)
+
+		test.IsFalse(t, store.CmpSequence(
+			tdmock.C("Get", 1),
+			tdmock.C("Save", Person{Name: "Bob", Age: 42}),
+			tdmock.C("Save", td.Struct(Person{}, td.StructFields{"Name": "Bob"})),
+			tdmock.C("Close"),
+		))
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"calls sequence",
+			`CALLS[2].Args[0].Name: values differ`,
+			`got: "Alice"`,
+			`expected: "Bob"`,
+		))
+	})
+
+	t.Run("Unordered failure", func(t *testing.T) {
+		tb, store := newStore(t)
+
+		test.IsFalse(t, store.CmpUnordered(
+			tdmock.C("Close"),
+			tdmock.C("Get", 1),
+			tdmock.C("Save", Person{Name: "Bob", Age: 42}),
+		))
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"unordered calls", "comparing CALLS as a Bag", "Extra item: ((tdmock.Call) Save("))
+	})
+
+	t.Run("Count failure", func(t *testing.T) {
+		tb, store := newStore(t)
+
+		test.IsFalse(t, store.CmpCallCount("Save", 1))
+		td.CmpEmpty(t, tb.ContainsMessages(
+			"number of Save calls",
+			"COUNT: values differ",
+			"got: 2",
+			"expected: 1",
+		))
+	})
+}
+
+func TestMockConcurrency(t *testing.T) {
+	tb := newTestingTB(t.Name())
+	store := &FakeStore{Mock: tdmock.New(tb)}
+	store.Expect("Get", td.Gte(0)).Times(100)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			store.Get(i) //nolint: errcheck
+		}(i)
+	}
+	wg.Wait()
+
+	tb.runCleanups()
+	test.IsFalse(t, tb.Failed(), tb.messages())
+	test.EqualInt(t, len(store.Calls()), 100)
+
+	// Expectation setup while calls occur
+	tb = newTestingTB(t.Name())
+	store = &FakeStore{Mock: tdmock.New(tb)}
+	e := store.Expect("Get", td.Gte(0)).Maybe()
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			store.Get(i) //nolint: errcheck
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			e.Return(&Person{Age: i}, nil).Times(td.Gte(0))
+		}
+	}()
+	wg.Wait()
+
+	tb.runCleanups()
+	test.IsFalse(t, tb.Failed(), tb.messages())
+
+	// Failures are reported without holding the lock
+	tb = newTestingTB(t.Name())
+	var nCalls int
+	store = &FakeStore{Mock: tdmock.New(td.NewT(tb, td.ContextConfig{
+		Reporter: td.ReporterFunc(func(t td.TestingT, f *td.Failure) {
+			nCalls = len(store.Calls())
+			td.DefaultReporter.Report(t, f)
+		}),
+	}))}
+	store.Expect("Get", 1)
+	store.Get(2) //nolint: errcheck
+	test.IsTrue(t, tb.Failed())
+	test.EqualInt(t, nCalls, 1)
+
+	// Arguments are matched without holding the lock
+	tb = newTestingTB(t.Name())
+	store = &FakeStore{Mock: tdmock.New(tb)}
+	store.Expect("Get", td.Code(func(id int) bool {
+		return len(store.CallsOf("Get")) == id
+	})).Times(2)
+	store.Get(1) //nolint: errcheck
+	store.Get(2) //nolint: errcheck
+
+	tb.runCleanups()
+	test.IsFalse(t, tb.Failed(), tb.messages())
+}
+
+func TestRets(t *testing.T) {
+	rets := tdmock.Rets{12, nil, errors.New("boom")}
+	test.IsTrue(t, rets.Get(0) == 12)
+	test.IsTrue(t, rets.Get(3) == nil)
+	test.IsTrue(t, rets.Get(-1) == nil)
+	test.NoError(t, rets.Error(1))
+	test.NoError(t, rets.Error(12))
+	if err := rets.Error(2); test.Error(t, err) {
+		test.EqualStr(t, err.Error(), "boom")
+	}
+}
@@ -13,6 +13,8 @@
 package tdsynctest_test
 
 import (
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -237,3 +239,32 @@ func TestSubtestAssertRequire(t *testing.T) {
 		test.IsTrue(t, req)
 	})
 }
+
+func TestEventuallyConsistently(t *testing.T) {
+	tdsynctest.Test(td.NewT(t), func(t *td.T) {
+		var status atomic.Value
+		go func() {
+			status.Store("running")
+			time.Sleep(975 * time.Millisecond) // just before the poll at 1s
+			status.Store("done")
+		}()
+		tdsynctest.Wait()
+
+		start := time.Now()
+		t.Eventually(func() string { return status.Load().(string) },
+			"done", 2*time.Second, 50*time.Millisecond)
+		test.EqualInt(t.TB.(*testing.T), int(time.Since(start)), int(time.Second))
+
+		start = time.Now()
+		err := td.EqDeeplyError(func() string { return status.Load().(string) },
+			td.Eventually("failed", 2*time.Second, 50*time.Millisecond))
+		test.EqualInt(t.TB.(*testing.T), int(time.Since(start)), int(2*time.Second))
+		test.IsTrue(t.TB.(*testing.T),
+			strings.Contains(err.Error(), "never matched in 41 attempts during 2s"))
+
+		start = time.Now()
+		t.Consistently(func() string { return status.Load().(string) },
+			"done", 500*time.Millisecond, 100*time.Millisecond)
+		test.EqualInt(t.TB.(*testing.T), int(time.Since(start)), int(500*time.Millisecond))
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package bipartite computes maximum matchings of bipartite graphs.
+package bipartite
+
+// Free is the value of a free (unmatched) vertex in matchings.
+const Free = -1
+
+// maxInt is maxInt, not available before go1.17.
+const maxInt = int(^uint(0) >> 1)
+
+// MaxMatching computes a maximum matching of the bipartite graph in
+// which each left vertex i is adjacent to the right vertices adj[i],
+// using the Hopcroft-Karp algorithm.
+//
+// matchLeft (of len(adj) items) and matchRight (of as many items as
+// right vertices) must contain a valid initial matching, [Free] for
+// unmatched vertices. A greedy initial matching often saves a lot of
+// work. Both are updated in place. MaxMatching returns the size of
+// the final matching.
+func MaxMatching(adj [][]int, matchLeft, matchRight []int) int {
+	size := 0
+	for _, r := range matchLeft {
+		if r != Free {
+			size++
+		}
+	}
+
+	dist := make([]int, len(adj))
+	queue := make([]int, 0, len(adj))
+
+	// bfs builds the layers of left vertices, starting from free
+	// ones, and returns true if at least one augmenting path exists
+	bfs := func() bool {
+		queue = queue[:0]
+		for l, r := range matchLeft {
+			if r == Free {
+				dist[l] = 0
+				queue = append(queue, l)
+			} else {
+				dist[l] = maxInt
+			}
+		}
+
+		found := false
+		for i := 0; i < len(queue); i++ {
+			l := queue[i]
+			for _, r := range adj[l] {
+				next := matchRight[r]
+				if next == Free {
+					found = true
+				} else if dist[next] == maxInt {
+					dist[next] = dist[l] + 1
+					queue = append(queue, next)
+				}
+			}
+		}
+		return found
+	}
+
+	// dfs follows the layers to find an augmenting path from l
+	var dfs func(l int) bool
+	dfs = func(l int) bool {
+		for _, r := range adj[l] {
+			next := matchRight[r]
+			if next == Free || (dist[next] == dist[l]+1 && dfs(next)) {
+				matchLeft[l] = r
+				matchRight[r] = l
+				return true
+			}
+		}
+		dist[l] = maxInt // dead end
+		return false
+	}
+
+	for bfs() {
+		for l, r := range matchLeft {
+			if r == Free && dfs(l) {
+				size++
+			}
+		}
+	}
+	return size
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package bipartite_test
+
+import (
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/bipartite"
+	"github.com/maxatome/go-testdeep/internal/test"
+)
+
+func newMatching(n int) []int {
+	m := make([]int, n)
+	for i := range m {
+		m[i] = bipartite.Free
+	}
+	return m
+}
+
+func checkMatching(t *testing.T, adj [][]int, matchLeft, matchRight []int) {
+	t.Helper()
+	for l, r := range matchLeft {
+		if r == bipartite.Free {
+			continue
+		}
+		test.EqualInt(t, matchRight[r], l, "matchRight is consistent")
+		found := false
+		for _, ar := range adj[l] {
+			found = found || ar == r
+		}
+		test.IsTrue(t, found, "left %d is adjacent to right %d", l, r)
+	}
+}
+
+func TestMaxMatching(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		test.EqualInt(t, bipartite.MaxMatching(nil, nil, nil), 0)
+	})
+
+	t.Run("greedy trap", func(t *testing.T) {
+		// Left 0 matches everything, left 1 only right 0
+		adj := [][]int{{0, 1}, {0}}
+		matchLeft, matchRight := newMatching(2), newMatching(2)
+		// Greedy initial matching
+		matchLeft[0], matchRight[0] = 0, 0
+
+		test.EqualInt(t, bipartite.MaxMatching(adj, matchLeft, matchRight), 2)
+		checkMatching(t, adj, matchLeft, matchRight)
+		test.EqualInt(t, matchLeft[0], 1)
+		test.EqualInt(t, matchLeft[1], 0)
+	})
+
+	t.Run("long augmenting path", func(t *testing.T) {
+		// left i matches rights i and i+1, initially matched to i+1
+		const n = 50
+		adj := make([][]int, n)
+		matchLeft, matchRight := newMatching(n), newMatching(n+1)
+		for l := range adj {
+			adj[l] = []int{l, l + 1}
+			matchLeft[l], matchRight[l+1] = l+1, l
+		}
+		adj = append(adj, []int{1}) // one more left only matching right 1
+		matchLeft = append(matchLeft, bipartite.Free)
+
+		test.EqualInt(t, bipartite.MaxMatching(adj, matchLeft, matchRight), n+1)
+		checkMatching(t, adj, matchLeft, matchRight)
+	})
+
+	t.Run("not perfect", func(t *testing.T) {
+		adj := [][]int{{0}, {0}, {0, 1, 2}}
+		matchLeft, matchRight := newMatching(3), newMatching(3)
+
+		test.EqualInt(t, bipartite.MaxMatching(adj, matchLeft, matchRight), 2)
+		checkMatching(t, adj, matchLeft, matchRight)
+		test.IsTrue(t, matchLeft[0] == bipartite.Free || matchLeft[1] == bipartite.Free)
+		test.IsTrue(t, matchLeft[2] != bipartite.Free)
+	})
+}
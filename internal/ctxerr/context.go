@@ -36,6 +36,10 @@ type Context struct {
 	// checked. Can be used to avoid filling Error{} with expensive
 	// computations.
 	BooleanError bool
+	// If true, got is only probed against one of several candidates,
+	// as td.Any or td.Bag operators do, so operators waiting for
+	// something should not wait. Implies BooleanError.
+	Probing bool
 	// See ContextConfig.FailureIsFatal for details.
 	FailureIsFatal bool
 	// See ContextConfig.UseEqual for details.
@@ -46,6 +50,11 @@ type Context struct {
 	IgnoreUnexported bool
 	// See ContextConfig.TestDeepInGotOK for details.
 	TestDeepInGotOK bool
+	// See ContextConfig.UseDiff for details.
+	UseDiff bool
+	// See ContextConfig.Reporter for details. If not nil, it is a
+	// td.Reporter, that cannot be referenced here.
+	Reporter any
 }
 
 // InitErrors initializes [Context] *Errors slice, if MaxErrors < 0 or
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/maxatome/go-testdeep/internal/color"
+	"github.com/maxatome/go-testdeep/internal/diff"
 	"github.com/maxatome/go-testdeep/internal/location"
 	"github.com/maxatome/go-testdeep/internal/types"
 	"github.com/maxatome/go-testdeep/internal/util"
@@ -167,10 +168,13 @@ func (e *Error) Append(buf *strings.Builder, prefix string, colorized bool) {
 		buf.WriteString(color.TitleOff)
 	}
 
-	if e.Summary != nil {
+	switch {
+	case e.Summary != nil:
 		buf.WriteByte('\n')
 		e.Summary.AppendSummary(buf, prefix+"\t", colorized)
-	} else {
+	case e.appendDiff(buf, prefix+"\t", colorized):
+		// unified diff displayed
+	default:
 		writeEolPrefix()
 		if colorized {
 			buf.WriteString(color.BadOnBold)
@@ -208,6 +212,52 @@ func (e *Error) Append(buf *strings.Builder, prefix string, colorized bool) {
 	}
 }
 
+// appendDiff appends to buf the unified diff between got and expected
+// and returns true, if e.Context.UseDiff is true and at least one of
+// them spans several lines. Otherwise it returns false without
+// altering buf.
+//
+// When got and expected are both strings, their raw contents are
+// compared instead of their quoted representations.
+func (e *Error) appendDiff(buf *strings.Builder, indent string, colorized bool) bool {
+	if !e.Context.UseDiff {
+		return false
+	}
+
+	got, gotOK := rawString(e.Got)
+	expected, expectedOK := rawString(e.Expected)
+	if !gotOK || !expectedOK {
+		got, expected = e.GotString(), e.ExpectedString()
+	}
+
+	if !strings.Contains(got, "\n") && !strings.Contains(expected, "\n") {
+		return false
+	}
+
+	var diffBuf strings.Builder
+	if !diff.Append(&diffBuf, got, expected, indent, colorized) {
+		return false
+	}
+	buf.WriteByte('\n')
+	buf.WriteString(diffBuf.String())
+	return true
+}
+
+// rawString returns the string contained in v and true if v is a
+// string or a [reflect.Value] of kind string. It returns false
+// otherwise.
+func rawString(v any) (string, bool) {
+	switch tv := v.(type) {
+	case string:
+		return tv, true
+	case reflect.Value:
+		if tv.IsValid() && tv.Kind() == reflect.String {
+			return tv.String(), true
+		}
+	}
+	return "", false
+}
+
 // GotString returns the string corresponding to the Got
 // field. Returns the empty string if the e Summary field is not nil.
 func (e *Error) GotString() string {
@@ -225,6 +225,93 @@ DATA[13].Field: error message
 		`Too many errors (use TESTDEEP_MAX_ERRORS=-1 to see all)`)
 }
 
+func TestErrorUseDiff(t *testing.T) {
+	defer color.SaveState()()
+
+	err := ctxerr.Error{
+		Context: ctxerr.Context{
+			Path:    ctxerr.NewPath("DATA"),
+			UseDiff: true,
+		},
+		Message:  "values differ",
+		Got:      "foo\nbar\nzip",
+		Expected: reflect.ValueOf("foo\nbaz\nzip"),
+	}
+	test.EqualStr(t, err.Error(),
+		`DATA: values differ
+	--- got
+	+++ expected
+	@@ -1,3 +1,3 @@
+	 foo
+	-bar
+	+baz
+	 zip`)
+
+	// Nested errors are indented as usual
+	err.Origin = &ctxerr.Error{
+		Context:  err.Context,
+		Message:  "origin",
+		Got:      "a\nb",
+		Expected: "a\nc",
+	}
+	test.EqualStr(t, err.Error(),
+		`DATA: values differ
+	--- got
+	+++ expected
+	@@ -1,3 +1,3 @@
+	 foo
+	-bar
+	+baz
+	 zip
+Originates from following error:
+	DATA: origin
+		--- got
+		+++ expected
+		@@ -1,2 +1,2 @@
+		 a
+		-b
+		+c`)
+	err.Origin = nil
+
+	// Not a string: dumps are compared
+	err.Got = []int{1, 2}
+	err.Expected = []int{1, 3}
+	test.EqualStr(t, err.Error(),
+		`DATA: values differ
+	--- got
+	+++ expected
+	@@ -1,4 +1,4 @@
+	 ([]int) (len=2) {
+	  (int) 1,
+	- (int) 2
+	+ (int) 3
+	 }`)
+
+	// Single lines: classic display
+	err.Got = "foo"
+	err.Expected = "bar"
+	test.EqualStr(t, err.Error(),
+		`DATA: values differ
+	     got: "foo"
+	expected: "bar"`)
+
+	// Same dumps: classic display
+	err.Got = []int{1}
+	err.Expected = []int{1}
+	test.EqualStr(t, err.Error(),
+		`DATA: values differ
+	     got: ([]int) (len=1) {
+	           (int) 1
+	          }
+	expected: ([]int) (len=1) {
+	           (int) 1
+	          }`)
+
+	// Summary wins
+	err.Summary = ctxerr.NewSummary("summary")
+	test.EqualStr(t, err.Error(), "DATA: values differ\n\tsummary")
+}
+
 func TestTypeMismatch(t *testing.T) {
 	rErr := ctxerr.TypeMismatch(reflect.TypeOf(0), reflect.TypeOf(""))
 	test.EqualStr(t, rErr.Message, "type mismatch")
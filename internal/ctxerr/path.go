@@ -156,6 +156,29 @@ func (p Path) AddCustomLevel(custom string) Path {
 	})
 }
 
+// ReplaceIndexedPrefix returns a new [Path] where prefix levels
+// followed by an array index level are replaced by the path returned
+// by repl for this index, and true. It returns nil and false if p
+// does not start this way or if repl returns false.
+func (p Path) ReplaceIndexedPrefix(prefix Path, repl func(index int) (Path, bool)) (Path, bool) {
+	n := len(prefix)
+	if len(p) <= n || !p[:n].Equal(prefix) || p[n].Kind != levelArray {
+		return nil, false
+	}
+	index, err := strconv.Atoi(p[n].Content)
+	if err != nil {
+		return nil, false
+	}
+	np, ok := repl(index)
+	if !ok || len(np) == 0 {
+		return nil, false
+	}
+	np = append(np.Copy(), p[n+1:]...)
+	// Pointers of the index level now apply to the last level of repl
+	np[len(np)-len(p)+n].Pointers += p[n].Pointers
+	return np, true
+}
+
 func (p Path) String() string {
 	if len(p) == 0 {
 		return ""
@@ -197,6 +197,33 @@ func TestEqual(t *testing.T) {
 	test.IsFalse(t, path.Equal(ctxerr.NewPath("DATA").AddPtr(2).AddField("field2")))
 }
 
+func TestReplaceIndexedPrefix(t *testing.T) {
+	prefix := ctxerr.NewPath("DATA").AddCustomLevel(".Items[*].Name")
+	repl := func(index int) (ctxerr.Path, bool) {
+		if index > 2 {
+			return nil, false
+		}
+		return ctxerr.NewPath("DATA").AddCustomLevel(".Items[" + string(rune('0'+index)) + "].Name"), true
+	}
+
+	path, ok := prefix.AddArrayIndex(1).AddField("Len").ReplaceIndexedPrefix(prefix, repl)
+	test.IsTrue(t, ok)
+	test.EqualStr(t, path.String(), "DATA.Items[1].Name.Len")
+
+	path, ok = prefix.AddArrayIndex(2).AddPtr(1).ReplaceIndexedPrefix(prefix, repl)
+	test.IsTrue(t, ok)
+	test.EqualStr(t, path.String(), "*DATA.Items[2].Name")
+
+	_, ok = prefix.AddArrayIndex(3).ReplaceIndexedPrefix(prefix, repl)
+	test.IsFalse(t, ok)
+	_, ok = prefix.ReplaceIndexedPrefix(prefix, repl)
+	test.IsFalse(t, ok)
+	_, ok = prefix.AddField("Len").ReplaceIndexedPrefix(prefix, repl)
+	test.IsFalse(t, ok)
+	_, ok = ctxerr.NewPath("DATA").AddArrayIndex(1).ReplaceIndexedPrefix(prefix, repl)
+	test.IsFalse(t, ok)
+}
+
 /*
 func BenchmarkStringString(b *testing.B) {
 	path := ctxerr.NewPath("DATA").
@@ -0,0 +1,337 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package diff renders line-based unified diffs of got & expected
+// dumps, as used in failure reports.
+package diff
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/color"
+)
+
+// Context is the number of unchanged lines displayed around each
+// change.
+const Context = 3
+
+// maxCells is the maximum size of the LCS table. Above, the lines
+// between the common prefix and suffix are considered fully
+// replaced.
+const maxCells = 1 << 22
+
+type opKind uint8
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+	a, b int // line index in got (a) and expected (b)
+}
+
+// lines computes the edit script transforming got lines into
+// expected ones.
+func lines(got, expected []string) []op {
+	// Common prefix
+	pre := 0
+	for pre < len(got) && pre < len(expected) && got[pre] == expected[pre] {
+		pre++
+	}
+	// Common suffix
+	suf := 0
+	for suf < len(got)-pre && suf < len(expected)-pre &&
+		got[len(got)-1-suf] == expected[len(expected)-1-suf] {
+		suf++
+	}
+
+	ops := make([]op, 0, len(got)+len(expected))
+	for i := 0; i < pre; i++ {
+		ops = append(ops, op{kind: opEqual, line: got[i], a: i, b: i})
+	}
+
+	g, e := got[pre:len(got)-suf], expected[pre:len(expected)-suf]
+	n, m := len(g), len(e)
+
+	if n*m > maxCells {
+		for i, l := range g {
+			ops = append(ops, op{kind: opDelete, line: l, a: pre + i, b: pre})
+		}
+		for j, l := range e {
+			ops = append(ops, op{kind: opInsert, line: l, a: pre + n, b: pre + j})
+		}
+	} else {
+		// lcs[i][j] = length of the LCS of g[i:] & e[j:]
+		lcs := make([]int32, (n+1)*(m+1))
+		at := func(i, j int) *int32 { return &lcs[i*(m+1)+j] }
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				switch {
+				case g[i] == e[j]:
+					*at(i, j) = *at(i+1, j+1) + 1
+				case *at(i+1, j) >= *at(i, j+1):
+					*at(i, j) = *at(i+1, j)
+				default:
+					*at(i, j) = *at(i, j+1)
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < n || j < m {
+			switch {
+			case i < n && j < m && g[i] == e[j]:
+				ops = append(ops, op{kind: opEqual, line: g[i], a: pre + i, b: pre + j})
+				i++
+				j++
+			case j == m || (i < n && *at(i+1, j) >= *at(i, j+1)):
+				ops = append(ops, op{kind: opDelete, line: g[i], a: pre + i, b: pre + j})
+				i++
+			default:
+				ops = append(ops, op{kind: opInsert, line: e[j], a: pre + i, b: pre + j})
+				j++
+			}
+		}
+	}
+
+	for k := 0; k < suf; k++ {
+		ops = append(ops, op{
+			kind: opEqual,
+			line: got[len(got)-suf+k],
+			a:    len(got) - suf + k,
+			b:    len(expected) - suf + k,
+		})
+	}
+	return ops
+}
+
+// hunk is a range of ops, with at most [Context] unchanged lines
+// around changes.
+type hunk struct {
+	start, end int // in ops
+}
+
+func hunks(ops []op) []hunk {
+	var hs []hunk
+	for i := 0; i < len(ops); {
+		if ops[i].kind == opEqual {
+			i++
+			continue
+		}
+
+		start := i - Context
+		if start < 0 {
+			start = 0
+		}
+		// Merge with previous hunk if they overlap or touch
+		if len(hs) > 0 && start <= hs[len(hs)-1].end {
+			start = hs[len(hs)-1].start
+			hs = hs[:len(hs)-1]
+		}
+
+		// Find end of changes, allowing up to 2*Context equal lines
+		// between two changes
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != opEqual {
+				end++
+				continue
+			}
+			eq := end
+			for eq < len(ops) && ops[eq].kind == opEqual {
+				eq++
+			}
+			if eq == len(ops) || eq-end > 2*Context {
+				break
+			}
+			end = eq
+		}
+		i = end
+
+		end += Context
+		if end > len(ops) {
+			end = len(ops)
+		}
+		hs = append(hs, hunk{start: start, end: end})
+	}
+	return hs
+}
+
+// hunkRange returns the "start,count" part of a hunk header, as
+// defined by GNU diff unified format.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return strconv.Itoa(start) + ",0"
+	}
+	if count == 1 {
+		return strconv.Itoa(start + 1)
+	}
+	return strconv.Itoa(start+1) + "," + strconv.Itoa(count)
+}
+
+// commonAffixes returns the lengths in bytes of the common prefix
+// and suffix of a and b, without splitting any rune.
+func commonAffixes(a, b string) (int, int) {
+	ra, rb := []rune(a), []rune(b)
+	pre := 0
+	for pre < len(ra) && pre < len(rb) && ra[pre] == rb[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(ra)-pre && suf < len(rb)-pre &&
+		ra[len(ra)-1-suf] == rb[len(rb)-1-suf] {
+		suf++
+	}
+	return len(string(ra[:pre])), len(string(ra[len(ra)-suf:]))
+}
+
+type writer struct {
+	buf       *strings.Builder
+	indent    string
+	colorized bool
+	first     bool
+}
+
+func (w *writer) startLine() {
+	if w.first {
+		w.first = false
+	} else {
+		w.buf.WriteByte('\n')
+	}
+	w.buf.WriteString(w.indent)
+}
+
+// line writes a diff line. If hlEnd > hlStart, the bytes of l between
+// them are highlighted.
+func (w *writer) line(sign byte, l string, hlStart, hlEnd int) {
+	w.startLine()
+
+	var on, bold, off string
+	if w.colorized {
+		switch sign {
+		case '-':
+			on, bold, off = color.BadOn, color.BadOnBold, color.BadOff
+		case '+':
+			on, bold, off = color.OKOn, color.OKOnBold, color.OKOff
+		}
+	}
+
+	w.buf.WriteString(on)
+	w.buf.WriteByte(sign)
+	if hlEnd <= hlStart || bold == "" {
+		w.buf.WriteString(l)
+		w.buf.WriteString(off)
+		return
+	}
+
+	w.buf.WriteString(l[:hlStart])
+	w.buf.WriteString(off)
+	w.buf.WriteString(bold)
+	w.buf.WriteString(l[hlStart:hlEnd])
+	w.buf.WriteString(off)
+	if hlEnd < len(l) {
+		w.buf.WriteString(on)
+		w.buf.WriteString(l[hlEnd:])
+		w.buf.WriteString(off)
+	}
+}
+
+func (w *writer) header(s, on, off string) {
+	w.startLine()
+	if w.colorized {
+		w.buf.WriteString(on)
+		w.buf.WriteString(s)
+		w.buf.WriteString(off)
+	} else {
+		w.buf.WriteString(s)
+	}
+}
+
+// Append appends to buf the unified diff between got and expected,
+// each line being prefixed by indent. The first line is not preceded
+// by a new line and the last one is not followed by a new line. If
+// colorized is true, deleted lines use "got" color, inserted ones use
+// "expected" color and the changed characters of paired lines are
+// highlighted using the bold variants of these colors.
+//
+// It returns false, without appending anything, if got and expected
+// are equal.
+func Append(buf *strings.Builder, got, expected, indent string, colorized bool) bool {
+	if got == expected {
+		return false
+	}
+
+	if colorized {
+		color.Init()
+	}
+
+	ops := lines(strings.Split(got, "\n"), strings.Split(expected, "\n"))
+
+	w := writer{
+		buf:       buf,
+		indent:    indent,
+		colorized: colorized,
+		first:     true,
+	}
+	w.header("--- got", color.BadOnBold, color.BadOff)
+	w.header("+++ expected", color.OKOnBold, color.OKOff)
+
+	for _, h := range hunks(ops) {
+		var na, nb int
+		for _, o := range ops[h.start:h.end] {
+			if o.kind != opInsert {
+				na++
+			}
+			if o.kind != opDelete {
+				nb++
+			}
+		}
+		first := ops[h.start]
+		w.header("@@ -"+hunkRange(first.a, na)+" +"+hunkRange(first.b, nb)+" @@",
+			color.TitleOn, color.TitleOff)
+
+		for k := h.start; k < h.end; {
+			if ops[k].kind == opEqual {
+				w.line(' ', ops[k].line, 0, 0)
+				k++
+				continue
+			}
+
+			// Block of deletions followed by a block of insertions
+			dels := k
+			for k < h.end && ops[k].kind == opDelete {
+				k++
+			}
+			ins := k
+			for k < h.end && ops[k].kind == opInsert {
+				k++
+			}
+			nDel, nIns := ins-dels, k-ins
+
+			// Pair deleted & inserted lines to highlight changed characters
+			hl := make([][2]int, nDel+nIns)
+			for p := 0; p < nDel && p < nIns; p++ {
+				d, i := ops[dels+p].line, ops[ins+p].line
+				pre, suf := commonAffixes(d, i)
+				hl[p] = [2]int{pre, len(d) - suf}
+				hl[nDel+p] = [2]int{pre, len(i) - suf}
+			}
+
+			for p := 0; p < nDel; p++ {
+				w.line('-', ops[dels+p].line, hl[p][0], hl[p][1])
+			}
+			for p := 0; p < nIns; p++ {
+				w.line('+', ops[ins+p].line, hl[nDel+p][0], hl[nDel+p][1])
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/color"
+	"github.com/maxatome/go-testdeep/internal/diff"
+	"github.com/maxatome/go-testdeep/internal/test"
+)
+
+func appendDiff(got, expected, indent string, colorized bool) (string, bool) {
+	var buf strings.Builder
+	ok := diff.Append(&buf, got, expected, indent, colorized)
+	return buf.String(), ok
+}
+
+func TestAppend(t *testing.T) {
+	defer color.SaveState()()
+
+	t.Run("equal", func(t *testing.T) {
+		s, ok := appendDiff("a\nb", "a\nb", "", false)
+		test.IsFalse(t, ok)
+		test.EqualStr(t, s, "")
+	})
+
+	t.Run("one line changed", func(t *testing.T) {
+		s, ok := appendDiff("a\nb\nc", "a\nB\nc", "\t", false)
+		test.IsTrue(t, ok)
+		test.EqualStr(t, s, `	--- got
+	+++ expected
+	@@ -1,3 +1,3 @@
+	 a
+	-b
+	+B
+	 c`)
+	})
+
+	t.Run("insertion & deletion", func(t *testing.T) {
+		s, _ := appendDiff("a\nb\nc\nd", "a\nc\nd\ne", "", false)
+		test.EqualStr(t, s, `--- got
++++ expected
+@@ -1,4 +1,4 @@
+ a
+-b
+ c
+ d
++e`)
+	})
+
+	t.Run("empty sides", func(t *testing.T) {
+		s, _ := appendDiff("", "a\nb", "", false)
+		test.EqualStr(t, s, `--- got
++++ expected
+@@ -1 +1,2 @@
+-
++a
++b`)
+
+		s, _ = appendDiff("a\nb\nc", "a\nb\nc\nd", "", false)
+		test.EqualStr(t, s, `--- got
++++ expected
+@@ -1,3 +1,4 @@
+ a
+ b
+ c
++d`)
+	})
+
+	t.Run("several hunks", func(t *testing.T) {
+		var got, expected []string
+		for i := 0; i < 20; i++ {
+			l := string(rune('a' + i))
+			got = append(got, l)
+			switch i {
+			case 1:
+				expected = append(expected, "X")
+			case 15:
+				expected = append(expected, l, "Y")
+			default:
+				expected = append(expected, l)
+			}
+		}
+
+		s, _ := appendDiff(strings.Join(got, "\n"), strings.Join(expected, "\n"), "", false)
+		test.EqualStr(t, s, `--- got
++++ expected
+@@ -1,5 +1,5 @@
+ a
+-b
++X
+ c
+ d
+ e
+@@ -14,6 +14,7 @@
+ n
+ o
+ p
++Y
+ q
+ r
+ s`)
+
+		// Close changes are merged in the same hunk
+		s, _ = appendDiff("a\nb\nc\nd\ne\nf\ng\nh\ni",
+			"a\nB\nc\nd\ne\nf\ng\nH\ni", "", false)
+		test.EqualStr(t, s, `--- got
++++ expected
+@@ -1,9 +1,9 @@
+ a
+-b
++B
+ c
+ d
+ e
+ f
+ g
+-h
++H
+ i`)
+	})
+
+	t.Run("colorized", func(t *testing.T) {
+		defer color.SaveState(true)()
+
+		s, _ := appendDiff("foo bar\nz", "foo baz\nz", "", true)
+		test.EqualStr(t, s,
+			"\x1b[1;31m--- got\x1b[0m\n"+
+				"\x1b[1;32m+++ expected\x1b[0m\n"+
+				"\x1b[1;36m@@ -1,2 +1,2 @@\x1b[0m\n"+
+				"\x1b[0;31m-foo ba\x1b[0m\x1b[1;31mr\x1b[0m\n"+
+				"\x1b[0;32m+foo ba\x1b[0m\x1b[1;32mz\x1b[0m\n"+
+				" z")
+
+		// Multi-bytes runes are never split
+		s, _ = appendDiff("é\n", "è\n", "", true)
+		test.EqualStr(t, s,
+			"\x1b[1;31m--- got\x1b[0m\n"+
+				"\x1b[1;32m+++ expected\x1b[0m\n"+
+				"\x1b[1;36m@@ -1,2 +1,2 @@\x1b[0m\n"+
+				"\x1b[0;31m-\x1b[0m\x1b[1;31mé\x1b[0m\n"+
+				"\x1b[0;32m+\x1b[0m\x1b[1;32mè\x1b[0m\n"+
+				" ")
+	})
+}
@@ -58,12 +58,25 @@ type json struct {
 	value        any
 	errs         []*Error
 	opts         ParseOpts
+	// pathOp is true if the last token is an operator listed in
+	// opts.PathOperators
+	pathOp bool
+	// pathParam is true if the next token is the first parameter of
+	// an operator listed in opts.PathOperators
+	pathParam bool
 }
 
 type ParseOpts struct {
 	Placeholders       []any
 	PlaceholdersByName map[string]any
 	OpFn               func(Operator, Position) (any, error)
+	// StartPos, if its Line is not 0, is the position of buf first
+	// byte, useful when buf is extracted from a larger document.
+	StartPos Position
+	// PathOperators lists the operators whose first parameter, when it
+	// is a string starting with "$." or "$[", is a path expression as
+	// "$.a.b" or "$[0]" and not a placeholder.
+	PathOperators []string
 }
 
 func Parse(buf []byte, opts ...ParseOpts) (any, error) {
@@ -73,6 +86,10 @@ func Parse(buf []byte, opts ...ParseOpts) (any, error) {
 	}
 	if len(opts) > 0 {
 		j.opts = opts[0]
+		if j.opts.StartPos.Line > 0 {
+			j.pos = j.opts.StartPos
+			j.pos.bpos = 0
+		}
 	}
 
 	if !j.parse() {
@@ -97,7 +114,19 @@ func (j *json) parse() bool {
 
 // Lex implements yyLexer interface.
 func (j *json) Lex(lval *yySymType) int {
-	return j.nextToken(lval)
+	token := j.nextToken(lval)
+
+	j.pathParam = j.pathOp && token == '('
+	j.pathOp = false
+	if token == OPERATOR {
+		for _, op := range j.opts.PathOperators {
+			if lval.string == op {
+				j.pathOp = true
+				break
+			}
+		}
+	}
+	return token
 }
 
 // Error implements yyLexer interface.
@@ -426,6 +455,11 @@ func (j *json) analyzeStringContent(s string, strPos Position, lval *yySymType)
 		lval.string = s[1:]
 		return STRING
 	}
+	// Path expressions as "$.a" or "$[0]" are not placeholders
+	if j.pathParam && (s[1] == '.' || s[1] == '[') {
+		lval.string = s
+		return STRING
+	}
 
 	// Check for placeholder ($1 or $name) or operator call as $^Empty
 	// or $^Re(q<\d+>)
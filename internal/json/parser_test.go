@@ -727,6 +727,68 @@ invalid operator name "Unknown2" at line 1:22 (pos 22)`,
 		}
 	})
 
+	t.Run("start position", func(t *testing.T) {
+		var opPos json.Position
+		_, err := json.Parse([]byte("[1,\n  $^AnyOp, $2]"), json.ParseOpts{
+			StartPos: json.Position{Pos: 100, Line: 10, Col: 5},
+			OpFn: func(op json.Operator, pos json.Position) (any, error) {
+				opPos = pos
+				return "OK", nil
+			},
+		})
+		if test.Error(t, err) {
+			test.EqualStr(t, err.Error(),
+				`numeric placeholder "$2", but no params given at line 11:11 (pos 115)`)
+		}
+		test.EqualInt(t, opPos.Pos, 108)
+		test.EqualInt(t, opPos.Line, 11)
+		test.EqualInt(t, opPos.Col, 4)
+	})
+
+	t.Run("path operators", func(t *testing.T) {
+		var params []any
+		opts := json.ParseOpts{
+			PlaceholdersByName: map[string]any{"name": "Bob"},
+			OpFn: func(op json.Operator, pos json.Position) (any, error) {
+				params = op.Params
+				return "OK", nil
+			},
+			PathOperators: []string{"PathOp"},
+		}
+
+		for _, tst := range []struct {
+			in       string
+			expected []any
+		}{
+			{in: `PathOp("$.a[*]", $name)`, expected: []any{"$.a[*]", "Bob"}},
+			{in: `$^PathOp("$[0]", 1)`, expected: []any{"$[0]", 1.0}},
+			{in: `PathOp("$$.a", $name)`, expected: []any{"$.a", "Bob"}},
+			{in: `PathOp("$name")`, expected: []any{"Bob"}},
+			{in: `PathOp(r<$.a>)`, expected: []any{"$.a"}},
+			{in: `"$^PathOp(\"$.a\")"`, expected: []any{"$.a"}},
+		} {
+			params = nil
+			_, err := json.Parse([]byte(tst.in), opts)
+			if test.NoError(t, err, "json.Parse OK", tst.in) {
+				test.IsTrue(t, reflect.DeepEqual(params, tst.expected),
+					"%s: got %v, expected %v", tst.in, params, tst.expected)
+			}
+		}
+
+		// Only the first parameter of a path operator is concerned
+		for _, js := range []string{
+			`PathOp(1, "$.b")`,
+			`OtherOp("$.a")`,
+			`"$.a"`,
+			`["$[0]"]`,
+		} {
+			_, err := json.Parse([]byte(js), opts)
+			if test.Error(t, err, "json.Parse fails", js) {
+				test.IsTrue(t, strings.Contains(err.Error(), "bad placeholder"), err.Error())
+			}
+		}
+	})
+
 	t.Run("no operators", func(t *testing.T) {
 		_, err := json.Parse([]byte("  Operator"))
 		if test.Error(t, err, "json.Parse fails") {
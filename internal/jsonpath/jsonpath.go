@@ -0,0 +1,901 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package jsonpath selects nodes of JSON values using JSONPath
+// expressions, as [RFC 9535] specifies them.
+//
+// Values are expected in the representation produced by encoding/json
+// when unmarshaling into an any: nil, bool, float64, string, []any
+// and map[string]any.
+//
+// As Go maps are not ordered, object members are always visited in
+// the lexical order of their names.
+//
+// [RFC 9535]: https://www.rfc-editor.org/rfc/rfc9535
+package jsonpath
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Path is a compiled JSONPath expression.
+type Path struct {
+	expr     string
+	segments []segment
+}
+
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+type selectorKind uint8
+
+const (
+	nameSelector selectorKind = iota
+	wildcardSelector
+	indexSelector
+	sliceSelector
+	filterSelector
+)
+
+type selector struct {
+	kind   selectorKind
+	name   string
+	index  int
+	slice  [3]*int // start, end, step
+	filter expr
+}
+
+// Error is a JSONPath syntax error.
+type Error struct {
+	Pos int // byte offset in the expression
+	Msg string
+}
+
+// Error implements error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// Parse parses the JSONPath expression s.
+func Parse(s string) (*Path, error) {
+	p := parser{s: s}
+	p.skipSpaces()
+	if !p.eat("$") {
+		return nil, p.errorf("JSONPath must start with $")
+	}
+	segments, err := p.segments()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return &Path{expr: s, segments: segments}, nil
+}
+
+// String returns the original expression of p.
+func (p *Path) String() string {
+	return p.expr
+}
+
+// Select returns the nodes of root selected by p, in document
+// order. It never returns nil: if no node is selected, an empty
+// slice is returned.
+func (p *Path) Select(root any) []any {
+	return selectNodes(p.segments, root, root)
+}
+
+func selectNodes(segments []segment, root, cur any) []any {
+	nodes := []any{cur}
+	for _, seg := range segments {
+		var next []any
+		for _, node := range nodes {
+			if seg.descendant {
+				visit(node, func(n any) {
+					next = seg.apply(root, n, next)
+				})
+			} else {
+				next = seg.apply(root, node, next)
+			}
+		}
+		nodes = next
+	}
+	if nodes == nil {
+		return []any{}
+	}
+	return nodes
+}
+
+// visit calls fn for node, then for all its descendants.
+func visit(node any, fn func(any)) {
+	fn(node)
+	switch node := node.(type) {
+	case []any:
+		for _, v := range node {
+			visit(v, fn)
+		}
+	case map[string]any:
+		for _, k := range sortedKeys(node) {
+			visit(node[k], fn)
+		}
+	}
+}
+
+func (seg segment) apply(root, node any, out []any) []any {
+	for _, sel := range seg.selectors {
+		out = sel.apply(root, node, out)
+	}
+	return out
+}
+
+func (sel *selector) apply(root, node any, out []any) []any {
+	switch sel.kind {
+	case nameSelector:
+		if m, ok := node.(map[string]any); ok {
+			if v, ok := m[sel.name]; ok {
+				out = append(out, v)
+			}
+		}
+
+	case wildcardSelector:
+		switch node := node.(type) {
+		case []any:
+			out = append(out, node...)
+		case map[string]any:
+			for _, k := range sortedKeys(node) {
+				out = append(out, node[k])
+			}
+		}
+
+	case indexSelector:
+		if a, ok := node.([]any); ok {
+			i := sel.index
+			if i < 0 {
+				i += len(a)
+			}
+			if i >= 0 && i < len(a) {
+				out = append(out, a[i])
+			}
+		}
+
+	case sliceSelector:
+		if a, ok := node.([]any); ok {
+			for _, i := range sliceIndexes(sel.slice, len(a)) {
+				out = append(out, a[i])
+			}
+		}
+
+	case filterSelector:
+		switch node := node.(type) {
+		case []any:
+			for _, v := range node {
+				if sel.filter.test(root, v) {
+					out = append(out, v)
+				}
+			}
+		case map[string]any:
+			for _, k := range sortedKeys(node) {
+				if sel.filter.test(root, node[k]) {
+					out = append(out, node[k])
+				}
+			}
+		}
+	}
+	return out
+}
+
+// sliceIndexes returns the indexes selected by the slice
+// start:end:step on an array of length n, see RFC 9535 § 2.3.4.2.
+func sliceIndexes(slice [3]*int, n int) []int {
+	step := 1
+	if slice[2] != nil {
+		step = *slice[2]
+	}
+	if step == 0 {
+		return nil
+	}
+
+	normalize := func(i int) int {
+		if i < 0 {
+			return n + i
+		}
+		return i
+	}
+	clamp := func(i, lo, hi int) int {
+		return int(math.Min(math.Max(float64(i), float64(lo)), float64(hi)))
+	}
+
+	var start, end int
+	if step > 0 {
+		start, end = 0, n
+		if slice[0] != nil {
+			start = clamp(normalize(*slice[0]), 0, n)
+		}
+		if slice[1] != nil {
+			end = clamp(normalize(*slice[1]), 0, n)
+		}
+	} else {
+		start, end = n-1, -1
+		if slice[0] != nil {
+			start = clamp(normalize(*slice[0]), -1, n-1)
+		}
+		if slice[1] != nil {
+			end = clamp(normalize(*slice[1]), -1, n-1)
+		}
+	}
+
+	var idx []int
+	if step > 0 {
+		for i := start; i < end; i += step {
+			idx = append(idx, i)
+		}
+	} else {
+		for i := start; i > end; i += step {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+//
+// Filter expressions
+//
+
+// expr is a logical expression of a filter selector.
+type expr interface {
+	test(root, cur any) bool
+}
+
+// operand is a comparable of a filter expression.
+type operand interface {
+	eval(root, cur any) (any, bool) // value, false if Nothing
+}
+
+type orExpr []expr
+
+func (e orExpr) test(root, cur any) bool {
+	for _, sub := range e {
+		if sub.test(root, cur) {
+			return true
+		}
+	}
+	return false
+}
+
+type andExpr []expr
+
+func (e andExpr) test(root, cur any) bool {
+	for _, sub := range e {
+		if !sub.test(root, cur) {
+			return false
+		}
+	}
+	return true
+}
+
+type notExpr struct{ expr }
+
+func (e notExpr) test(root, cur any) bool {
+	return !e.expr.test(root, cur)
+}
+
+type existExpr struct{ query *query }
+
+func (e existExpr) test(root, cur any) bool {
+	return len(e.query.nodes(root, cur)) > 0
+}
+
+type cmpExpr struct {
+	op          string
+	left, right operand
+}
+
+func (e cmpExpr) test(root, cur any) bool {
+	l, lok := e.left.eval(root, cur)
+	r, rok := e.right.eval(root, cur)
+
+	switch e.op {
+	case "==":
+		return equal(l, lok, r, rok)
+	case "!=":
+		return !equal(l, lok, r, rok)
+	case "<":
+		return less(l, lok, r, rok)
+	case ">":
+		return less(r, rok, l, lok)
+	case "<=":
+		return less(l, lok, r, rok) || equal(l, lok, r, rok)
+	default: // ">="
+		return less(r, rok, l, lok) || equal(l, lok, r, rok)
+	}
+}
+
+func equal(l any, lok bool, r any, rok bool) bool {
+	if !lok || !rok {
+		return lok == rok
+	}
+	return reflect.DeepEqual(l, r)
+}
+
+func less(l any, lok bool, r any, rok bool) bool {
+	if !lok || !rok {
+		return false
+	}
+	switch l := l.(type) {
+	case float64:
+		if r, ok := r.(float64); ok {
+			return l < r
+		}
+	case string:
+		if r, ok := r.(string); ok {
+			return l < r
+		}
+	}
+	return false
+}
+
+type literal struct{ value any }
+
+func (l literal) eval(root, cur any) (any, bool) {
+	return l.value, true
+}
+
+// query is a relative (@) or absolute ($) query used in a filter
+// expression.
+type query struct {
+	absolute bool
+	segments []segment
+}
+
+func (q *query) nodes(root, cur any) []any {
+	if q.absolute {
+		cur = root
+	}
+	return selectNodes(q.segments, root, cur)
+}
+
+// eval returns the value of the node selected by q, only if exactly
+// one node is selected.
+func (q *query) eval(root, cur any) (any, bool) {
+	nodes := q.nodes(root, cur)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0], true
+}
+
+// lengthFunc is the length() function extension.
+type lengthFunc struct{ arg operand }
+
+func (f lengthFunc) eval(root, cur any) (any, bool) {
+	v, ok := f.arg.eval(root, cur)
+	if !ok {
+		return nil, false
+	}
+	switch v := v.(type) {
+	case string:
+		return float64(utf8.RuneCountInString(v)), true
+	case []any:
+		return float64(len(v)), true
+	case map[string]any:
+		return float64(len(v)), true
+	}
+	return nil, false
+}
+
+// countFunc is the count() function extension.
+type countFunc struct{ arg *query }
+
+func (f countFunc) eval(root, cur any) (any, bool) {
+	return float64(len(f.arg.nodes(root, cur))), true
+}
+
+// matchFunc is the match() and search() functions extension.
+type matchFunc struct {
+	arg operand
+	re  *regexp.Regexp
+}
+
+func (f matchFunc) test(root, cur any) bool {
+	v, ok := f.arg.eval(root, cur)
+	if !ok {
+		return false
+	}
+	s, ok := v.(string)
+	return ok && f.re.MatchString(s)
+}
+
+//
+// Parser
+//
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...any) *Error {
+	return &Error{Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\n\r", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *parser) eat(tok string) bool {
+	if strings.HasPrefix(p.s[p.pos:], tok) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+// segments parses the segments following $ or @.
+func (p *parser) segments() ([]segment, error) {
+	var segments []segment
+	for {
+		// Spaces are allowed before segments, but not before "." in
+		// filters as "@.a .b" is ambiguous, so only before "[" and ".."
+		save := p.pos
+		p.skipSpaces()
+
+		switch {
+		case p.eat(".."):
+			seg := segment{descendant: true}
+			if p.peek() == '[' {
+				sels, err := p.bracket()
+				if err != nil {
+					return nil, err
+				}
+				seg.selectors = sels
+			} else {
+				sel, err := p.dotSelector()
+				if err != nil {
+					return nil, err
+				}
+				seg.selectors = []selector{sel}
+			}
+			segments = append(segments, seg)
+
+		case p.peek() == '.' && p.pos == save:
+			p.pos++
+			sel, err := p.dotSelector()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment{selectors: []selector{sel}})
+
+		case p.peek() == '[':
+			sels, err := p.bracket()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment{selectors: sels})
+
+		default:
+			p.pos = save
+			return segments, nil
+		}
+	}
+}
+
+// dotSelector parses the selector following "." or "..".
+func (p *parser) dotSelector() (selector, error) {
+	if p.eat("*") {
+		return selector{kind: wildcardSelector}, nil
+	}
+	name := p.name()
+	if name == "" {
+		return selector{}, p.errorf("member name or * expected")
+	}
+	return selector{kind: nameSelector, name: name}, nil
+}
+
+// name parses a member name shorthand.
+func (p *parser) name() string {
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if c == '_' || c >= 0x80 || (c|0x20 >= 'a' && c|0x20 <= 'z') ||
+			(p.pos > start && (c >= '0' && c <= '9' || c == '-')) {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.s[start:p.pos]
+}
+
+// bracket parses a bracketed selection.
+func (p *parser) bracket() ([]selector, error) {
+	p.pos++ // [
+	var sels []selector
+	for {
+		p.skipSpaces()
+		sel, err := p.selector()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+
+		p.skipSpaces()
+		if p.eat("]") {
+			return sels, nil
+		}
+		if !p.eat(",") {
+			return nil, p.errorf("] or , expected")
+		}
+	}
+}
+
+func (p *parser) selector() (selector, error) {
+	switch c := p.peek(); {
+	case c == '*':
+		p.pos++
+		return selector{kind: wildcardSelector}, nil
+
+	case c == '\'' || c == '"':
+		s, err := p.stringLiteral()
+		if err != nil {
+			return selector{}, err
+		}
+		return selector{kind: nameSelector, name: s}, nil
+
+	case c == '?':
+		p.pos++
+		f, err := p.logicalOr()
+		if err != nil {
+			return selector{}, err
+		}
+		return selector{kind: filterSelector, filter: f}, nil
+
+	case c == ':' || c == '-' || (c >= '0' && c <= '9'):
+		return p.indexOrSlice()
+	}
+	return selector{}, p.errorf("selector expected")
+}
+
+func (p *parser) indexOrSlice() (selector, error) {
+	var (
+		bounds [3]*int
+		part   int
+	)
+	for {
+		p.skipSpaces()
+		if c := p.peek(); c == '-' || (c >= '0' && c <= '9') {
+			i, err := p.integer()
+			if err != nil {
+				return selector{}, err
+			}
+			bounds[part] = &i
+			p.skipSpaces()
+		}
+		if part == 2 || !p.eat(":") {
+			break
+		}
+		part++
+	}
+
+	if part == 0 {
+		if bounds[0] == nil {
+			return selector{}, p.errorf("index expected")
+		}
+		return selector{kind: indexSelector, index: *bounds[0]}, nil
+	}
+	return selector{kind: sliceSelector, slice: bounds}, nil
+}
+
+func (p *parser) integer() (int, error) {
+	start := p.pos
+	p.eat("-")
+	for c := p.peek(); c >= '0' && c <= '9'; c = p.peek() {
+		p.pos++
+	}
+	i, err := strconv.Atoi(p.s[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("bad integer")
+	}
+	return i, nil
+}
+
+func (p *parser) stringLiteral() (string, error) {
+	start := p.pos
+	quote := p.s[p.pos]
+	p.pos++
+
+	var buf strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch c {
+		case quote:
+			p.pos++
+			return buf.String(), nil
+
+		case '\\':
+			if p.pos+1 >= len(p.s) {
+				break
+			}
+			p.pos++
+			switch e := p.s[p.pos]; e {
+			case 'b':
+				buf.WriteByte('\b')
+			case 'f':
+				buf.WriteByte('\f')
+			case 'n':
+				buf.WriteByte('\n')
+			case 'r':
+				buf.WriteByte('\r')
+			case 't':
+				buf.WriteByte('\t')
+			case 'u':
+				if p.pos+5 > len(p.s) {
+					return "", p.errorf("bad \\u escape")
+				}
+				r, err := strconv.ParseUint(p.s[p.pos+1:p.pos+5], 16, 16)
+				if err != nil {
+					return "", p.errorf("bad \\u escape")
+				}
+				buf.WriteRune(rune(r))
+				p.pos += 4
+			default:
+				buf.WriteByte(e)
+			}
+			p.pos++
+			continue
+		}
+		buf.WriteByte(c)
+		p.pos++
+	}
+	p.pos = start
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) logicalOr() (expr, error) {
+	var or orExpr
+	for {
+		e, err := p.logicalAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, e)
+		p.skipSpaces()
+		if !p.eat("||") {
+			break
+		}
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *parser) logicalAnd() (expr, error) {
+	var and andExpr
+	for {
+		e, err := p.basicExpr()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, e)
+		p.skipSpaces()
+		if !p.eat("&&") {
+			break
+		}
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+var cmpOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func (p *parser) basicExpr() (expr, error) {
+	p.skipSpaces()
+
+	if p.eat("!") {
+		e, err := p.basicExpr()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{e}, nil
+	}
+
+	if p.eat("(") {
+		e, err := p.logicalOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.eat(")") {
+			return nil, p.errorf(") expected")
+		}
+		return e, nil
+	}
+
+	start := p.pos
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	for _, op := range cmpOps {
+		if p.eat(op) {
+			p.skipSpaces()
+			right, err := p.operand()
+			if err != nil {
+				return nil, err
+			}
+			l, lok := left.(operand)
+			r, rok := right.(operand)
+			if !lok || !rok {
+				p.pos = start
+				return nil, p.errorf("match() and search() results cannot be compared")
+			}
+			return cmpExpr{op: op, left: l, right: r}, nil
+		}
+	}
+
+	switch left := left.(type) {
+	case *query:
+		return existExpr{query: left}, nil
+	case expr:
+		return left, nil
+	}
+	p.pos = start
+	return nil, p.errorf("comparison or test expected")
+}
+
+// operand parses a literal, a query or a function call. It returns an
+// operand or an expr (for logical functions).
+func (p *parser) operand() (any, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		return p.query()
+
+	case c == '\'' || c == '"':
+		s, err := p.stringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return literal{s}, nil
+
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.number()
+	}
+
+	start := p.pos
+	name := p.name()
+	switch name {
+	case "true":
+		return literal{true}, nil
+	case "false":
+		return literal{false}, nil
+	case "null":
+		return literal{nil}, nil
+	case "length", "count", "match", "search":
+		return p.function(name, start)
+	case "":
+		return nil, p.errorf("operand expected")
+	}
+	p.pos = start
+	return nil, p.errorf("unknown %q", name)
+}
+
+func (p *parser) query() (*query, error) {
+	q := query{absolute: p.peek() == '$'}
+	p.pos++
+	segments, err := p.segments()
+	if err != nil {
+		return nil, err
+	}
+	q.segments = segments
+	return &q, nil
+}
+
+func (p *parser) number() (operand, error) {
+	start := p.pos
+	p.eat("-")
+	for p.pos < len(p.s) && strings.IndexByte("0123456789.eE+-", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("bad number")
+	}
+	return literal{f}, nil
+}
+
+func (p *parser) function(name string, start int) (any, error) {
+	p.skipSpaces()
+	if !p.eat("(") {
+		p.pos = start
+		return nil, p.errorf("%s( expected", name)
+	}
+	p.skipSpaces()
+
+	arg, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := arg.(operand); !ok {
+		return nil, p.errorf("%s() bad argument", name)
+	}
+
+	var ret any
+	switch name {
+	case "length":
+		ret = lengthFunc{arg: arg.(operand)}
+
+	case "count":
+		q, ok := arg.(*query)
+		if !ok {
+			return nil, p.errorf("count() argument must be a query")
+		}
+		ret = countFunc{arg: q}
+
+	default: // match & search
+		p.skipSpaces()
+		if !p.eat(",") {
+			return nil, p.errorf("%s() requires 2 arguments", name)
+		}
+		p.skipSpaces()
+		if c := p.peek(); c != '\'' && c != '"' {
+			return nil, p.errorf("%s() 2nd argument must be a string literal", name)
+		}
+		reStart := p.pos
+		re, err := p.stringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		if name == "match" {
+			re = `\A(?:` + re + `)\z`
+		}
+		cre, err := regexp.Compile(re)
+		if err != nil {
+			p.pos = reStart
+			return nil, p.errorf("%s() bad regexp: %s", name, err)
+		}
+		ret = matchFunc{arg: arg.(operand), re: cre}
+	}
+
+	p.skipSpaces()
+	if !p.eat(")") {
+		return nil, p.errorf(") expected")
+	}
+	return ret, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package jsonpath_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/jsonpath"
+	"github.com/maxatome/go-testdeep/internal/test"
+)
+
+const store = `{
+  "store": {
+    "book": [
+      {"category": "reference", "author": "Nigel Rees",
+       "title": "Sayings of the Century", "price": 8.95},
+      {"category": "fiction", "author": "Evelyn Waugh",
+       "title": "Sword of Honour", "price": 12.99},
+      {"category": "fiction", "author": "Herman Melville",
+       "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+      {"category": "fiction", "author": "J. R. R. Tolkien",
+       "title": "The Lord of the Rings", "isbn": "0-395-19395-8",
+       "price": 22.99}
+    ],
+    "bicycle": {"color": "red", "price": 399}
+  },
+  "expensive": 10
+}`
+
+func TestSelect(t *testing.T) {
+	var root any
+	if err := json.Unmarshal([]byte(store), &root); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		path     string
+		expected string
+	}{
+		{path: `$`, expected: `[` + store + `]`},
+		{path: `$.expensive`, expected: `[10]`},
+		{path: `$.unknown`, expected: `[]`},
+		{path: `$['expensive']`, expected: `[10]`},
+		{path: `$["store"]["bicycle"].color`, expected: `["red"]`},
+		{path: `$.store.book[*].author`,
+			expected: `["Nigel Rees","Evelyn Waugh","Herman Melville","J. R. R. Tolkien"]`},
+		{path: `$..author`,
+			expected: `["Nigel Rees","Evelyn Waugh","Herman Melville","J. R. R. Tolkien"]`},
+		{path: `$.store.*`, expected: `[{"color":"red","price":399},` +
+			`[{"category":"reference","author":"Nigel Rees","title":"Sayings of the Century","price":8.95},` +
+			`{"category":"fiction","author":"Evelyn Waugh","title":"Sword of Honour","price":12.99},` +
+			`{"category":"fiction","author":"Herman Melville","title":"Moby Dick","isbn":"0-553-21311-3","price":8.99},` +
+			`{"category":"fiction","author":"J. R. R. Tolkien","title":"The Lord of the Rings","isbn":"0-395-19395-8","price":22.99}]]`},
+		{path: `$.store..price`, expected: `[399,8.95,12.99,8.99,22.99]`},
+		{path: `$..book[2].title`, expected: `["Moby Dick"]`},
+		{path: `$..book[-1].title`, expected: `["The Lord of the Rings"]`},
+		{path: `$..book[9].title`, expected: `[]`},
+		{path: `$..book[0,1].title`, expected: `["Sayings of the Century","Sword of Honour"]`},
+		{path: `$..book[:2].title`, expected: `["Sayings of the Century","Sword of Honour"]`},
+		{path: `$..book[2:].title`, expected: `["Moby Dick","The Lord of the Rings"]`},
+		{path: `$..book[-2:].title`, expected: `["Moby Dick","The Lord of the Rings"]`},
+		{path: `$..book[::2].title`, expected: `["Sayings of the Century","Moby Dick"]`},
+		{path: `$..book[::-1].price`, expected: `[22.99,8.99,12.99,8.95]`},
+		{path: `$..book[3:1:-1].price`, expected: `[22.99,8.99]`},
+		{path: `$..book[1:2:0].price`, expected: `[]`},
+		{path: `$..book[ 0 , 'x' ].price`, expected: `[8.95]`},
+		{path: `$..book[?@.isbn].title`, expected: `["Moby Dick","The Lord of the Rings"]`},
+		{path: `$..book[?!@.isbn].title`,
+			expected: `["Sayings of the Century","Sword of Honour"]`},
+		{path: `$..book[?(@.price < 10)].title`,
+			expected: `["Sayings of the Century","Moby Dick"]`},
+		{path: `$..book[?@.price > $.expensive].price`, expected: `[12.99,22.99]`},
+		{path: `$..book[?@.price >= 12.99 && @.category == 'fiction'].price`,
+			expected: `[12.99,22.99]`},
+		{path: `$..book[?@.price <= 8.95 || @.price == 22.99].price`,
+			expected: `[8.95,22.99]`},
+		{path: `$..book[?(@.category != "fiction")].price`, expected: `[8.95]`},
+		{path: `$..book[?@.author > 'M'].author`,
+			expected: `["Nigel Rees"]`},
+		{path: `$..book[?@.unknown == @.other].price`,
+			expected: `[8.95,12.99,8.99,22.99]`}, // Nothing == Nothing
+		{path: `$..book[?@.price == 'x'].price`, expected: `[]`},
+		{path: `$..book[?match(@.author, 'H.*')].author`, expected: `["Herman Melville"]`},
+		{path: `$..book[?search(@.author, 'R\\.')].author`,
+			expected: `["J. R. R. Tolkien"]`},
+		{path: `$..book[?search(@.author, '[RW]')].author`,
+			expected: `["Nigel Rees","Evelyn Waugh","J. R. R. Tolkien"]`},
+		{path: `$..book[?length(@.title) < 10].title`, expected: `["Moby Dick"]`},
+		{path: `$.store[?count(@.*) == 2].color`, expected: `["red"]`},
+		{path: `$..[?@.color == "red"].price`, expected: `[399]`},
+		{path: `$.store.bicycle[?@ == 399]`, expected: `[399]`},
+		{path: `$.store.book[?@.price == 8.95][*]`,
+			expected: `["Nigel Rees","reference",8.95,"Sayings of the Century"]`},
+		{path: `$.store.book[?(@.price < 9 && (@.isbn || @.category == "reference"))].price`,
+			expected: `[8.95,8.99]`},
+		{path: `$..book[0]['title']`, expected: `["Sayings of the Century"]`},
+	} {
+		p, err := jsonpath.Parse(tc.path)
+		if !test.NoError(t, err, tc.path) {
+			continue
+		}
+		test.EqualStr(t, p.String(), tc.path)
+
+		got, _ := json.Marshal(p.Select(root))
+		var expected any
+		if err := json.Unmarshal([]byte(tc.expected), &expected); err != nil {
+			t.Fatalf("%s: bad expected JSON: %s", tc.path, err)
+		}
+		exp, _ := json.Marshal(expected)
+		test.EqualStr(t, string(got), string(exp), tc.path)
+	}
+
+	// Never nil
+	p, err := jsonpath.Parse(`$.x`)
+	if test.NoError(t, err) {
+		test.IsTrue(t, p.Select(nil) != nil)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		path string
+		err  string
+	}{
+		{path: ``, err: `JSONPath must start with $ at position 0`},
+		{path: `a.b`, err: `JSONPath must start with $ at position 0`},
+		{path: `$.`, err: `member name or * expected at position 2`},
+		{path: `$..`, err: `member name or * expected at position 3`},
+		{path: `$[`, err: `selector expected at position 2`},
+		{path: `$[0`, err: `] or , expected at position 3`},
+		{path: `$[:x]`, err: `] or , expected at position 3`},
+		{path: `$['a`, err: `unterminated string at position 2`},
+		{path: `$.a b`, err: `unexpected "b" at position 4`},
+		{path: `$[?@.a ==]`, err: `operand expected at position 9`},
+		{path: `$[?foo(@.a)]`, err: `unknown "foo" at position 3`},
+		{path: `$[?(@.a]`, err: `) expected at position 7`},
+		{path: `$[?1]`, err: `comparison or test expected at position 3`},
+		{path: `$[?match(@.a, 'x') == true]`,
+			err: `match() and search() results cannot be compared at position 3`},
+		{path: `$[?match(@.a, '(')]`, err: "match() bad regexp: error parsing regexp: missing closing ): `\\A(?:()\\z` at position 14"},
+		{path: `$[?match(@.a)]`, err: `match() requires 2 arguments at position 12`},
+		{path: `$[?count(1) == 1]`, err: `count() argument must be a query at position 10`},
+		{path: `$[?length(@.a]`, err: `) expected at position 13`},
+		{path: `$['\u12']`, err: `bad \u escape at position 4`},
+	} {
+		_, err := jsonpath.Parse(tc.path)
+		if test.Error(t, err, tc.path) {
+			test.EqualStr(t, err.Error(), tc.err, tc.path)
+		}
+	}
+}
@@ -0,0 +1,714 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package jsonschema validates JSON values against JSON Schema
+// documents, as used by OpenAPI 3.0 and 3.1.
+//
+// Schemas and instances are expected in the representation produced
+// by encoding/json when unmarshaling into an any: nil, bool, float64,
+// string, []any and map[string]any.
+//
+// Only local references ("#…") are supported. Formats date-time,
+// date, time, email, uuid, ipv4, ipv6, uri, int32 and int64 are
+// checked, other ones are ignored.
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxDepth is the maximum number of nested schemas, to detect
+// references loops.
+const maxDepth = 512
+
+// Error is a violation of a schema by an instance.
+type Error struct {
+	// InstancePath is the JSON pointer of the faulty value in the
+	// instance, "" for the root.
+	InstancePath string
+	// SchemaPath is the JSON pointer of the failing keyword in the
+	// schema document, as "#/properties/age/minimum".
+	SchemaPath string
+	// Message describes the violation.
+	Message string
+}
+
+// String returns e as "/instance/path: message (schema path)".
+func (e Error) String() string {
+	path := e.InstancePath
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s (%s)", path, e.Message, e.SchemaPath)
+}
+
+// Validator validates instances against a schema.
+type Validator struct {
+	root    any
+	pointer string
+	schema  any
+	regexps map[string]*regexp.Regexp
+}
+
+// New returns a [*Validator] for the schema found at pointer in
+// root, pointer being a JSON pointer fragment as "#/$defs/Person". An
+// empty pointer or "#" means root itself. Local references of the
+// schema are resolved against root.
+//
+// An error is returned if the schema cannot be found, if one of its
+// references cannot be resolved or if one of its patterns is not a
+// valid regexp.
+func New(root any, pointer string) (*Validator, error) {
+	if pointer == "" {
+		pointer = "#"
+	}
+	v := &Validator{
+		root:    root,
+		pointer: pointer,
+		regexps: map[string]*regexp.Regexp{},
+	}
+
+	var err error
+	v.schema, err = v.resolve(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = v.check(v.schema, pointer, map[string]bool{}); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Schema returns the schema v validates against.
+func (v *Validator) Schema() any {
+	return v.schema
+}
+
+// Validate returns the violations of the schema of v by instance, or
+// nil if instance is valid.
+func (v *Validator) Validate(instance any) []Error {
+	var errs []Error
+	v.validate(instance, v.schema, "", v.pointer, 0, &errs)
+	return errs
+}
+
+// EscapePointer escapes s to be used as a JSON pointer token.
+func EscapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func (v *Validator) resolve(ref string) (any, error) {
+	return Resolve(v.root, ref)
+}
+
+// Resolve returns the value at the JSON pointer fragment ref, as
+// "#/components/schemas/Person", in root.
+func Resolve(root any, ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("only local $ref are supported, not %q", ref)
+	}
+	ptr, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, fmt.Errorf("bad $ref %q: %s", ref, err)
+	}
+
+	cur := root
+	if ptr == "" {
+		return cur, nil
+	}
+	if ptr[0] != '/' {
+		return nil, fmt.Errorf("bad $ref %q: only JSON pointers are supported", ref)
+	}
+	for _, tok := range strings.Split(ptr[1:], "/") {
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		switch c := cur.(type) {
+		case map[string]any:
+			var ok bool
+			if cur, ok = c[tok]; !ok {
+				return nil, fmt.Errorf("cannot resolve %q: key %q not found", ref, tok)
+			}
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, fmt.Errorf("cannot resolve %q: bad index %q", ref, tok)
+			}
+			cur = c[i]
+		default:
+			return nil, fmt.Errorf("cannot resolve %q: %q is not a container", ref, tok)
+		}
+	}
+	return cur, nil
+}
+
+// check recursively checks schema, resolving references and
+// compiling patterns.
+func (v *Validator) check(schema any, path string, seen map[string]bool) error {
+	switch s := schema.(type) {
+	case bool:
+		return nil
+	case map[string]any:
+		if seen[path] {
+			return nil
+		}
+		seen[path] = true
+
+		if ref, ok := s["$ref"].(string); ok {
+			target, err := v.resolve(ref)
+			if err != nil {
+				return fmt.Errorf("%s/$ref: %s", path, err)
+			}
+			if err = v.check(target, ref, seen); err != nil {
+				return err
+			}
+		}
+		if pattern, ok := s["pattern"].(string); ok {
+			if _, err := v.regexp(pattern); err != nil {
+				return fmt.Errorf("%s/pattern: %s", path, err)
+			}
+		}
+
+		for _, kw := range []string{
+			"items", "additionalItems", "additionalProperties", "contains",
+			"propertyNames", "not", "if", "then", "else",
+		} {
+			if sub, ok := s[kw]; ok {
+				if _, isTuple := sub.([]any); isTuple {
+					continue // items as an array, see below
+				}
+				if err := v.check(sub, path+"/"+kw, seen); err != nil {
+					return err
+				}
+			}
+		}
+		for _, kw := range []string{"allOf", "anyOf", "oneOf", "prefixItems", "items"} {
+			if subs, ok := s[kw].([]any); ok {
+				for i, sub := range subs {
+					if err := v.check(sub, fmt.Sprintf("%s/%s/%d", path, kw, i), seen); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		for _, kw := range []string{"properties", "patternProperties", "$defs", "definitions"} {
+			if subs, ok := s[kw].(map[string]any); ok {
+				for _, name := range sortedKeys(subs) {
+					if kw == "patternProperties" {
+						if _, err := v.regexp(name); err != nil {
+							return fmt.Errorf("%s/%s: %s", path, kw, err)
+						}
+					}
+					err := v.check(subs[name], path+"/"+kw+"/"+EscapePointer(name), seen)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s: schema must be an object or a boolean, not %s", path, typeOf(schema))
+	}
+}
+
+func (v *Validator) regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := v.regexps[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	v.regexps[pattern] = re
+	return re, nil
+}
+
+func (v *Validator) validate(inst, schema any, ipath, spath string, depth int, errs *[]Error) {
+	addErr := func(kw, format string, args ...any) {
+		sp := spath
+		if kw != "" {
+			sp += "/" + kw
+		}
+		*errs = append(*errs, Error{
+			InstancePath: ipath,
+			SchemaPath:   sp,
+			Message:      fmt.Sprintf(format, args...),
+		})
+	}
+
+	if depth > maxDepth {
+		addErr("", "too many nested schemas, $ref loop?")
+		return
+	}
+
+	s, ok := schema.(map[string]any)
+	if !ok {
+		if b, ok := schema.(bool); ok && !b {
+			addErr("", "no value is allowed here")
+		}
+		return
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := v.resolve(ref)
+		if err != nil {
+			addErr("$ref", "%s", err)
+			return
+		}
+		v.validate(inst, target, ipath, ref, depth+1, errs)
+	}
+
+	// type & nullable (OpenAPI 3.0)
+	if t, ok := s["type"]; ok {
+		var types []string
+		switch t := t.(type) {
+		case string:
+			types = []string{t}
+		case []any:
+			for _, tt := range t {
+				if str, ok := tt.(string); ok {
+					types = append(types, str)
+				}
+			}
+		}
+		if nullable, _ := s["nullable"].(bool); nullable {
+			types = append(types, "null")
+		}
+		if !hasType(inst, types) {
+			expected := strings.Join(types, " or ")
+			addErr("type", "expected %s, got %s", expected, typeOf(inst))
+			return
+		}
+	}
+
+	if enum, ok := s["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			if equal(inst, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			addErr("enum", "%s is not one of the allowed values %s", toString(inst), toString(enum))
+		}
+	}
+
+	if c, ok := s["const"]; ok && !equal(inst, c) {
+		addErr("const", "%s should be %s", toString(inst), toString(c))
+	}
+
+	switch inst := inst.(type) {
+	case float64:
+		v.validateNumber(inst, s, addErr)
+	case string:
+		v.validateString(inst, s, addErr)
+	case []any:
+		v.validateArray(inst, s, ipath, spath, depth, errs, addErr)
+	case map[string]any:
+		v.validateObject(inst, s, ipath, spath, depth, errs, addErr)
+	}
+
+	// Combinations
+	if subs, ok := s["allOf"].([]any); ok {
+		for i, sub := range subs {
+			v.validate(inst, sub, ipath, fmt.Sprintf("%s/allOf/%d", spath, i), depth+1, errs)
+		}
+	}
+	if subs, ok := s["anyOf"].([]any); ok {
+		if v.countValid(inst, subs, ipath, spath+"/anyOf", depth, 1) == 0 {
+			addErr("anyOf", "does not match any of the %d schemas", len(subs))
+		}
+	}
+	if subs, ok := s["oneOf"].([]any); ok {
+		switch n := v.countValid(inst, subs, ipath, spath+"/oneOf", depth, 2); n {
+		case 0:
+			addErr("oneOf", "does not match any of the %d schemas", len(subs))
+		case 1:
+		default:
+			addErr("oneOf", "matches several of the %d schemas, only one expected", len(subs))
+		}
+	}
+	if sub, ok := s["not"]; ok {
+		if v.countValid(inst, []any{sub}, ipath, spath+"/not", depth, 1) == 1 {
+			addErr("not", "should not match the schema")
+		}
+	}
+	if sub, ok := s["if"]; ok {
+		if v.countValid(inst, []any{sub}, ipath, spath+"/if", depth, 1) == 1 {
+			if then, ok := s["then"]; ok {
+				v.validate(inst, then, ipath, spath+"/then", depth+1, errs)
+			}
+		} else if els, ok := s["else"]; ok {
+			v.validate(inst, els, ipath, spath+"/else", depth+1, errs)
+		}
+	}
+}
+
+// countValid returns the number of schemas in subs inst is valid
+// against, stopping as soon as max is reached.
+func (v *Validator) countValid(inst any, subs []any, ipath, spath string, depth, max int) int {
+	n := 0
+	for i, sub := range subs {
+		var errs []Error
+		v.validate(inst, sub, ipath, spath+"/"+strconv.Itoa(i), depth+1, &errs)
+		if len(errs) == 0 {
+			n++
+			if n == max {
+				break
+			}
+		}
+	}
+	return n
+}
+
+func (v *Validator) validateNumber(n float64, s map[string]any, addErr func(string, string, ...any)) {
+	if m, ok := s["multipleOf"].(float64); ok && m > 0 {
+		q := n / m
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			addErr("multipleOf", "%s is not a multiple of %s", toString(n), toString(m))
+		}
+	}
+
+	// OpenAPI 3.0 uses booleans for exclusiveMinimum & exclusiveMaximum
+	exclMin, _ := s["exclusiveMinimum"].(bool)
+	if min, ok := s["minimum"].(float64); ok {
+		if exclMin && n <= min {
+			addErr("minimum", "%s should be > %s", toString(n), toString(min))
+		} else if n < min {
+			addErr("minimum", "%s should be ≥ %s", toString(n), toString(min))
+		}
+	}
+	if min, ok := s["exclusiveMinimum"].(float64); ok && n <= min {
+		addErr("exclusiveMinimum", "%s should be > %s", toString(n), toString(min))
+	}
+
+	exclMax, _ := s["exclusiveMaximum"].(bool)
+	if max, ok := s["maximum"].(float64); ok {
+		if exclMax && n >= max {
+			addErr("maximum", "%s should be < %s", toString(n), toString(max))
+		} else if n > max {
+			addErr("maximum", "%s should be ≤ %s", toString(n), toString(max))
+		}
+	}
+	if max, ok := s["exclusiveMaximum"].(float64); ok && n >= max {
+		addErr("exclusiveMaximum", "%s should be < %s", toString(n), toString(max))
+	}
+
+	switch s["format"] {
+	case "int32":
+		if n != math.Trunc(n) || n < math.MinInt32 || n > math.MaxInt32 {
+			addErr("format", "%s is not a valid int32", toString(n))
+		}
+	case "int64":
+		if n != math.Trunc(n) || n < math.MinInt64 || n > math.MaxInt64 {
+			addErr("format", "%s is not a valid int64", toString(n))
+		}
+	}
+}
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\z`)
+
+func (v *Validator) validateString(str string, s map[string]any, addErr func(string, string, ...any)) {
+	length := float64(utf8.RuneCountInString(str))
+	if min, ok := s["minLength"].(float64); ok && length < min {
+		addErr("minLength", "length %s should be ≥ %s", toString(length), toString(min))
+	}
+	if max, ok := s["maxLength"].(float64); ok && length > max {
+		addErr("maxLength", "length %s should be ≤ %s", toString(length), toString(max))
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		if re, err := v.regexp(pattern); err == nil && !re.MatchString(str) {
+			addErr("pattern", "%q does not match pattern %q", str, pattern)
+		}
+	}
+
+	format, _ := s["format"].(string)
+	var ok bool
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339Nano, str)
+		ok = err == nil
+	case "date":
+		_, err := time.Parse("2006-01-02", str)
+		ok = err == nil
+	case "time":
+		_, err := time.Parse("15:04:05Z07:00", str)
+		if err != nil {
+			_, err = time.Parse("15:04:05.999999999Z07:00", str)
+		}
+		ok = err == nil
+	case "email":
+		addr, err := mail.ParseAddress(str)
+		ok = err == nil && addr.Address == str
+	case "uuid":
+		ok = uuidRe.MatchString(str)
+	case "ipv4":
+		ip := net.ParseIP(str)
+		ok = ip != nil && ip.To4() != nil && !strings.Contains(str, ":")
+	case "ipv6":
+		ip := net.ParseIP(str)
+		ok = ip != nil && strings.Contains(str, ":")
+	case "uri":
+		u, err := url.Parse(str)
+		ok = err == nil && u.Scheme != ""
+	default:
+		return
+	}
+	if !ok {
+		addErr("format", "%q is not a valid %s", str, format)
+	}
+}
+
+func (v *Validator) validateArray(
+	arr []any, s map[string]any,
+	ipath, spath string, depth int, errs *[]Error,
+	addErr func(string, string, ...any),
+) {
+	length := float64(len(arr))
+	if min, ok := s["minItems"].(float64); ok && length < min {
+		addErr("minItems", "%s items, should be ≥ %s", toString(length), toString(min))
+	}
+	if max, ok := s["maxItems"].(float64); ok && length > max {
+		addErr("maxItems", "%s items, should be ≤ %s", toString(length), toString(max))
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+	loop:
+		for i := 1; i < len(arr); i++ {
+			for j := 0; j < i; j++ {
+				if equal(arr[i], arr[j]) {
+					addErr("uniqueItems", "items #%d and #%d are equal", j, i)
+					break loop
+				}
+			}
+		}
+	}
+
+	// Tuples: prefixItems (2020-12) or items as an array (older drafts)
+	var prefix []any
+	prefixKw, restKw := "prefixItems", "items"
+	if p, ok := s["prefixItems"].([]any); ok {
+		prefix = p
+	} else if p, ok := s["items"].([]any); ok {
+		prefix = p
+		prefixKw, restKw = "items", "additionalItems"
+	}
+	for i := 0; i < len(prefix) && i < len(arr); i++ {
+		v.validate(arr[i], prefix[i], ipath+"/"+strconv.Itoa(i),
+			fmt.Sprintf("%s/%s/%d", spath, prefixKw, i), depth+1, errs)
+	}
+
+	if rest, ok := s[restKw]; ok {
+		if _, isTuple := rest.([]any); !isTuple {
+			for i := len(prefix); i < len(arr); i++ {
+				v.validate(arr[i], rest, ipath+"/"+strconv.Itoa(i), spath+"/"+restKw, depth+1, errs)
+			}
+		}
+	}
+
+	if contains, ok := s["contains"]; ok {
+		n := 0
+		for i, item := range arr {
+			var subErrs []Error
+			v.validate(item, contains, ipath+"/"+strconv.Itoa(i), spath+"/contains", depth+1, &subErrs)
+			if len(subErrs) == 0 {
+				n++
+			}
+		}
+		min := 1.
+		if m, ok := s["minContains"].(float64); ok {
+			min = m
+		}
+		if float64(n) < min {
+			addErr("contains", "%d items match the contains schema, should be ≥ %s", n, toString(min))
+		}
+		if max, ok := s["maxContains"].(float64); ok && float64(n) > max {
+			addErr("maxContains", "%d items match the contains schema, should be ≤ %s", n, toString(max))
+		}
+	}
+}
+
+func (v *Validator) validateObject(
+	obj map[string]any, s map[string]any,
+	ipath, spath string, depth int, errs *[]Error,
+	addErr func(string, string, ...any),
+) {
+	length := float64(len(obj))
+	if min, ok := s["minProperties"].(float64); ok && length < min {
+		addErr("minProperties", "%s properties, should be ≥ %s", toString(length), toString(min))
+	}
+	if max, ok := s["maxProperties"].(float64); ok && length > max {
+		addErr("maxProperties", "%s properties, should be ≤ %s", toString(length), toString(max))
+	}
+
+	if required, ok := s["required"].([]any); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, exists := obj[name]; !exists {
+					addErr("required", "missing required property %q", name)
+				}
+			}
+		}
+	}
+
+	props, _ := s["properties"].(map[string]any)
+	patternProps, _ := s["patternProperties"].(map[string]any)
+	additional, hasAdditional := s["additionalProperties"]
+	propertyNames, hasPropertyNames := s["propertyNames"]
+
+	for _, name := range sortedKeys(obj) {
+		val := obj[name]
+		ip := ipath + "/" + EscapePointer(name)
+
+		if hasPropertyNames {
+			v.validate(name, propertyNames, ip, spath+"/propertyNames", depth+1, errs)
+		}
+
+		matched := false
+		if sub, ok := props[name]; ok {
+			matched = true
+			v.validate(val, sub, ip, spath+"/properties/"+EscapePointer(name), depth+1, errs)
+		}
+		for _, pattern := range sortedKeys(patternProps) {
+			if re, err := v.regexp(pattern); err == nil && re.MatchString(name) {
+				matched = true
+				v.validate(val, patternProps[pattern], ip,
+					spath+"/patternProperties/"+EscapePointer(pattern), depth+1, errs)
+			}
+		}
+		if !matched && hasAdditional {
+			if b, ok := additional.(bool); ok {
+				if !b {
+					*errs = append(*errs, Error{
+						InstancePath: ip,
+						SchemaPath:   spath + "/additionalProperties",
+						Message:      fmt.Sprintf("unexpected property %q", name),
+					})
+				}
+				continue
+			}
+			v.validate(val, additional, ip, spath+"/additionalProperties", depth+1, errs)
+		}
+	}
+
+	if deps, ok := s["dependentRequired"].(map[string]any); ok {
+		for _, name := range sortedKeys(deps) {
+			if _, exists := obj[name]; !exists {
+				continue
+			}
+			list, _ := deps[name].([]any)
+			for _, r := range list {
+				if dep, ok := r.(string); ok {
+					if _, exists := obj[dep]; !exists {
+						addErr("dependentRequired",
+							"missing property %q, required when %q is present", dep, name)
+					}
+				}
+			}
+		}
+	}
+}
+
+func hasType(inst any, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case "null":
+			if inst == nil {
+				return true
+			}
+		case "boolean":
+			if _, ok := inst.(bool); ok {
+				return true
+			}
+		case "object":
+			if _, ok := inst.(map[string]any); ok {
+				return true
+			}
+		case "array":
+			if _, ok := inst.([]any); ok {
+				return true
+			}
+		case "number":
+			if _, ok := inst.(float64); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := inst.(float64); ok && n == math.Trunc(n) && !math.IsInf(n, 0) {
+				return true
+			}
+		case "string":
+			if _, ok := inst.(string); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeOf(inst any) string {
+	switch inst := inst.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case float64:
+		if inst == math.Trunc(inst) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	default:
+		return fmt.Sprintf("%T", inst)
+	}
+}
+
+func equal(a, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		return strconv.Quote(v)
+	case nil:
+		return "null"
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = toString(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
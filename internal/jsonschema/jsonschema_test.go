@@ -0,0 +1,389 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/jsonschema"
+	"github.com/maxatome/go-testdeep/internal/test"
+)
+
+func mustUnmarshal(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("bad JSON %s: %s", s, err)
+	}
+	return v
+}
+
+func errorsString(errs []jsonschema.Error) string {
+	strs := make([]string, len(errs))
+	for i, err := range errs {
+		strs[i] = err.String()
+	}
+	return strings.Join(strs, "\n")
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		instance string
+		errors   string
+	}{
+		// Boolean schemas
+		{name: "true", schema: `true`, instance: `12`},
+		{
+			name: "false", schema: `false`, instance: `12`,
+			errors: `/: no value is allowed here (#)`,
+		},
+
+		// type
+		{name: "type OK", schema: `{"type":"integer"}`, instance: `12`},
+		{
+			name: "type KO", schema: `{"type":"integer"}`, instance: `12.5`,
+			errors: `/: expected integer, got number (#/type)`,
+		},
+		{name: "types OK", schema: `{"type":["string","null"]}`, instance: `null`},
+		{name: "nullable OK", schema: `{"type":"string","nullable":true}`, instance: `null`},
+		{
+			name: "not nullable", schema: `{"type":"string"}`, instance: `null`,
+			errors: `/: expected string, got null (#/type)`,
+		},
+		{name: "number", schema: `{"type":"number"}`, instance: `12`},
+		{
+			name: "all types KO", schema: `{"type":["boolean","object","array"]}`, instance: `"x"`,
+			errors: `/: expected boolean or object or array, got string (#/type)`,
+		},
+
+		// enum & const
+		{name: "enum OK", schema: `{"enum":["a",1,null]}`, instance: `1`},
+		{
+			name: "enum KO", schema: `{"enum":["a",1,null]}`, instance: `"b"`,
+			errors: `/: "b" is not one of the allowed values ["a", 1, null] (#/enum)`,
+		},
+		{name: "const OK", schema: `{"const":{"a":[1]}}`, instance: `{"a":[1]}`},
+		{
+			name: "const KO", schema: `{"const":"a"}`, instance: `"b"`,
+			errors: `/: "b" should be "a" (#/const)`,
+		},
+
+		// numbers
+		{
+			name:     "number bounds",
+			schema:   `{"minimum":1,"maximum":3,"multipleOf":0.5}`,
+			instance: `2.5`,
+		},
+		{
+			name: "minimum", schema: `{"minimum":1}`, instance: `0`,
+			errors: `/: 0 should be ≥ 1 (#/minimum)`,
+		},
+		{
+			name: "maximum", schema: `{"maximum":1}`, instance: `2`,
+			errors: `/: 2 should be ≤ 1 (#/maximum)`,
+		},
+		{
+			name:     "exclusive 3.0",
+			schema:   `{"minimum":1,"exclusiveMinimum":true,"maximum":3,"exclusiveMaximum":true}`,
+			instance: `[1,3]`,
+			errors:   "",
+		},
+		{
+			name: "exclusive 3.1", schema: `{"exclusiveMinimum":1,"exclusiveMaximum":3}`, instance: `3`,
+			errors: `/: 3 should be < 3 (#/exclusiveMaximum)`,
+		},
+		{
+			name: "multipleOf", schema: `{"multipleOf":0.5}`, instance: `1.2`,
+			errors: `/: 1.2 is not a multiple of 0.5 (#/multipleOf)`,
+		},
+		{
+			name: "int32", schema: `{"format":"int32"}`, instance: `3000000000`,
+			errors: `/: 3e+09 is not a valid int32 (#/format)`,
+		},
+
+		// strings
+		{
+			name:     "string OK",
+			schema:   `{"minLength":2,"maxLength":3,"pattern":"^é"}`,
+			instance: `"été"`,
+		},
+		{
+			name: "string KO", schema: `{"minLength":2,"pattern":"^a"}`, instance: `"b"`,
+			errors: `/: length 1 should be ≥ 2 (#/minLength)
+/: "b" does not match pattern "^a" (#/pattern)`,
+		},
+		{
+			name: "maxLength", schema: `{"maxLength":2}`, instance: `"abc"`,
+			errors: `/: length 3 should be ≤ 2 (#/maxLength)`,
+		},
+		{name: "date-time", schema: `{"format":"date-time"}`, instance: `"2026-10-18T12:00:00Z"`},
+		{
+			name: "date KO", schema: `{"format":"date"}`, instance: `"2026-13-01"`,
+			errors: `/: "2026-13-01" is not a valid date (#/format)`,
+		},
+		{name: "time", schema: `{"format":"time"}`, instance: `"12:00:00.5+02:00"`},
+		{name: "email", schema: `{"format":"email"}`, instance: `"bob@example.com"`},
+		{
+			name: "email KO", schema: `{"format":"email"}`, instance: `"Bob <bob@example.com>"`,
+			errors: `/: "Bob <bob@example.com>" is not a valid email (#/format)`,
+		},
+		{name: "uuid", schema: `{"format":"uuid"}`, instance: `"123e4567-e89b-12d3-a456-426614174000"`},
+		{name: "ipv4", schema: `{"format":"ipv4"}`, instance: `"127.0.0.1"`},
+		{
+			name: "ipv6 KO", schema: `{"format":"ipv6"}`, instance: `"127.0.0.1"`,
+			errors: `/: "127.0.0.1" is not a valid ipv6 (#/format)`,
+		},
+		{name: "uri", schema: `{"format":"uri"}`, instance: `"https://example.com/"`},
+		{name: "unknown format", schema: `{"format":"pipo"}`, instance: `"x"`},
+
+		// arrays
+		{
+			name:     "items OK",
+			schema:   `{"items":{"type":"integer"},"minItems":1,"maxItems":3,"uniqueItems":true}`,
+			instance: `[1,2,3]`,
+		},
+		{
+			name:     "items KO",
+			schema:   `{"items":{"type":"integer"},"minItems":4,"uniqueItems":true}`,
+			instance: `[1,"2",1]`,
+			errors: `/: 3 items, should be ≥ 4 (#/minItems)
+/: items #0 and #2 are equal (#/uniqueItems)
+/1: expected integer, got string (#/items/type)`,
+		},
+		{
+			name: "maxItems", schema: `{"maxItems":1}`, instance: `[1,2]`,
+			errors: `/: 2 items, should be ≤ 1 (#/maxItems)`,
+		},
+		{
+			name:     "prefixItems",
+			schema:   `{"prefixItems":[{"type":"string"}],"items":false}`,
+			instance: `["a",2]`,
+			errors:   `/1: no value is allowed here (#/items)`,
+		},
+		{
+			name:     "tuple items",
+			schema:   `{"items":[{"type":"string"},{"type":"integer"}],"additionalItems":{"type":"boolean"}}`,
+			instance: `[1,2,"x"]`,
+			errors: `/0: expected string, got integer (#/items/0/type)
+/2: expected boolean, got string (#/additionalItems/type)`,
+		},
+		{
+			name:     "contains",
+			schema:   `{"contains":{"const":1},"maxContains":1}`,
+			instance: `[1,1]`,
+			errors:   `/: 2 items match the contains schema, should be ≤ 1 (#/maxContains)`,
+		},
+		{
+			name:     "contains none",
+			schema:   `{"contains":{"const":1}}`,
+			instance: `[2]`,
+			errors:   `/: 0 items match the contains schema, should be ≥ 1 (#/contains)`,
+		},
+
+		// objects
+		{
+			name: "object OK",
+			schema: `{
+  "type": "object",
+  "required": ["name"],
+  "properties": {"name": {"type":"string"}, "age": {"type":"integer"}},
+  "additionalProperties": false
+}`,
+			instance: `{"name":"Bob","age":42}`,
+		},
+		{
+			name: "object KO",
+			schema: `{
+  "type": "object",
+  "required": ["name", "age"],
+  "properties": {"name": {"type":"string"}, "age": {"type":"integer"}},
+  "additionalProperties": false,
+  "minProperties": 3
+}`,
+			instance: `{"name":12,"a/b~c":true}`,
+			errors: `/: 2 properties, should be ≥ 3 (#/minProperties)
+/: missing required property "age" (#/required)
+/a~1b~0c: unexpected property "a/b~c" (#/additionalProperties)
+/name: expected string, got integer (#/properties/name/type)`,
+		},
+		{
+			name:     "maxProperties & patternProperties",
+			schema:   `{"maxProperties":1,"patternProperties":{"^x-":{"type":"string"}},"additionalProperties":{"type":"integer"}}`,
+			instance: `{"x-a":1,"b":"c"}`,
+			errors: `/: 2 properties, should be ≤ 1 (#/maxProperties)
+/b: expected integer, got string (#/additionalProperties/type)
+/x-a: expected string, got integer (#/patternProperties/^x-/type)`,
+		},
+		{
+			name:     "propertyNames & dependentRequired",
+			schema:   `{"propertyNames":{"maxLength":3},"dependentRequired":{"foo":["bar"]}}`,
+			instance: `{"foo":1,"toolong":2}`,
+			errors: `/toolong: length 7 should be ≤ 3 (#/propertyNames/maxLength)
+/: missing property "bar", required when "foo" is present (#/dependentRequired)`,
+		},
+
+		// combinations
+		{
+			name:     "allOf",
+			schema:   `{"allOf":[{"type":"integer"},{"minimum":10}]}`,
+			instance: `5`,
+			errors:   `/: 5 should be ≥ 10 (#/allOf/1/minimum)`,
+		},
+		{name: "anyOf OK", schema: `{"anyOf":[{"type":"integer"},{"type":"string"}]}`, instance: `"x"`},
+		{
+			name: "anyOf KO", schema: `{"anyOf":[{"type":"integer"},{"type":"string"}]}`, instance: `true`,
+			errors: `/: does not match any of the 2 schemas (#/anyOf)`,
+		},
+		{name: "oneOf OK", schema: `{"oneOf":[{"type":"integer"},{"minimum":10}]}`, instance: `5`},
+		{
+			name: "oneOf several", schema: `{"oneOf":[{"type":"integer"},{"minimum":10}]}`, instance: `12`,
+			errors: `/: matches several of the 2 schemas, only one expected (#/oneOf)`,
+		},
+		{
+			name: "oneOf none", schema: `{"oneOf":[{"type":"integer"},{"minimum":10}]}`, instance: `5.5`,
+			errors: `/: does not match any of the 2 schemas (#/oneOf)`,
+		},
+		{
+			name: "not", schema: `{"not":{"type":"string"}}`, instance: `"x"`,
+			errors: `/: should not match the schema (#/not)`,
+		},
+		{
+			name:     "if then",
+			schema:   `{"if":{"type":"integer"},"then":{"minimum":0},"else":{"type":"string"}}`,
+			instance: `[1,"a"]`,
+			errors:   "",
+		},
+		{
+			name:     "then",
+			schema:   `{"if":{"type":"integer"},"then":{"minimum":0},"else":{"type":"string"}}`,
+			instance: `-1`,
+			errors:   `/: -1 should be ≥ 0 (#/then/minimum)`,
+		},
+		{
+			name:     "else",
+			schema:   `{"if":{"type":"integer"},"then":{"minimum":0},"else":{"type":"string"}}`,
+			instance: `true`,
+			errors:   `/: expected string, got boolean (#/else/type)`,
+		},
+
+		// $ref
+		{
+			name: "$ref",
+			schema: `{
+  "$defs": {
+    "Node": {
+      "type": "object",
+      "properties": {
+        "value": {"type": "integer"},
+        "children": {"type": "array", "items": {"$ref": "#/$defs/Node"}}
+      }
+    }
+  },
+  "$ref": "#/$defs/Node"
+}`,
+			instance: `{"value":1,"children":[{"value":2},{"value":"3","children":[]}]}`,
+			errors:   `/children/1/value: expected integer, got string (#/$defs/Node/properties/value/type)`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := jsonschema.New(mustUnmarshal(t, tc.schema), "")
+			if !test.NoError(t, err) {
+				return
+			}
+
+			inst := mustUnmarshal(t, tc.instance)
+			if tc.name == "exclusive 3.0" || tc.name == "if then" {
+				for _, item := range inst.([]any) {
+					errs := v.Validate(item)
+					test.IsTrue(t, len(errs) > 0 == (tc.name == "exclusive 3.0"),
+						"%v: %s", item, errorsString(errs))
+				}
+				return
+			}
+
+			errs := v.Validate(inst)
+			test.EqualStr(t, errorsString(errs), tc.errors)
+			test.IsTrue(t, (errs == nil) == (tc.errors == ""))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	root := mustUnmarshal(t, `{
+  "components": {
+    "schemas": {
+      "Person": {
+        "type": "object",
+        "properties": {"friend": {"$ref": "#/components/schemas/Person"}}
+      },
+      "Bad~Name/1": {"type": "string"}
+    }
+  }
+}`)
+
+	v, err := jsonschema.New(root, "#/components/schemas/Person")
+	if test.NoError(t, err) {
+		errs := v.Validate(mustUnmarshal(t, `{"friend":{"friend":12}}`))
+		test.EqualStr(t, errorsString(errs),
+			`/friend/friend: expected object, got integer (#/components/schemas/Person/type)`)
+		_, ok := v.Schema().(map[string]any)
+		test.IsTrue(t, ok)
+	}
+
+	v, err = jsonschema.New(root, "#/components/schemas/Bad~0Name~11")
+	if test.NoError(t, err) {
+		test.EqualInt(t, len(v.Validate("x")), 0)
+	}
+
+	v, err = jsonschema.New(root, "#/components/schemas/Bad~0Name%7E11")
+	if test.NoError(t, err) {
+		test.EqualInt(t, len(v.Validate(1)), 1)
+	}
+
+	for _, tc := range []struct{ schema, pointer, err string }{
+		{`{}`, "#/foo", `cannot resolve "#/foo": key "foo" not found`},
+		{`{}`, "other.json#/foo", `only local $ref are supported, not "other.json#/foo"`},
+		{`{}`, "#foo", `bad $ref "#foo": only JSON pointers are supported`},
+		{`{}`, "#%zz", `bad $ref "#%zz": invalid URL escape "%zz"`},
+		{`{"a":[1]}`, "#/a/2", `cannot resolve "#/a/2": bad index "2"`},
+		{`{"a":1}`, "#/a/b", `cannot resolve "#/a/b": "b" is not a container`},
+		{`12`, "", `#: schema must be an object or a boolean, not integer`},
+		{`{"$ref":"#/nope"}`, "", `#/$ref: cannot resolve "#/nope": key "nope" not found`},
+		{`{"pattern":"("}`, "", "#/pattern: error parsing regexp: missing closing ): `(`"},
+		{
+			`{"patternProperties":{"(":true}}`, "",
+			"#/patternProperties: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			`{"properties":{"a":{"items":[{"not":"x"}]}}}`, "",
+			`#/properties/a/items/0/not: schema must be an object or a boolean, not string`,
+		},
+	} {
+		_, err := jsonschema.New(mustUnmarshal(t, tc.schema), tc.pointer)
+		if test.Error(t, err, tc.schema, tc.pointer) {
+			test.EqualStr(t, err.Error(), tc.err)
+		}
+	}
+
+	// $ref loop
+	v, err = jsonschema.New(mustUnmarshal(t, `{"$defs":{"A":{"$ref":"#/$defs/A"}},"$ref":"#/$defs/A"}`), "")
+	if test.NoError(t, err) {
+		errs := v.Validate(1)
+		if test.IsTrue(t, len(errs) > 0) {
+			test.EqualStr(t, errs[0].Message, "too many nested schemas, $ref loop?")
+		}
+	}
+}
+
+func TestEscapePointer(t *testing.T) {
+	test.EqualStr(t, jsonschema.EscapePointer("a/b~c"), "a~1b~0c")
+	test.EqualStr(t, jsonschema.Error{Message: "boom", SchemaPath: "#"}.String(), "/: boom (#)")
+}
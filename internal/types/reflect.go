@@ -7,6 +7,7 @@
 package types
 
 import (
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -21,6 +22,7 @@ var (
 	FmtStringer     = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
 	Error           = reflect.TypeOf((*error)(nil)).Elem()
 	JsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem() //nolint: revive
+	TextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 	Time            = reflect.TypeOf(time.Time{})
 	Int             = reflect.TypeOf(int(0))
 	Uint8           = reflect.TypeOf(uint8(0))
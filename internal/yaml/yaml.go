@@ -0,0 +1,942 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package yaml parses YAML documents into the same representation as
+// the one produced by [json.Parse]: nil, bool, float64, string, []any
+// and map[string]any, plus placeholders and operators.
+//
+// Only a subset of YAML 1.2 is supported: block and flow collections,
+// plain, quoted and block scalars, comments, anchors and aliases. Tags,
+// complex keys and multi-documents streams are not supported.
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/maxatome/go-testdeep/internal/json"
+)
+
+type line struct {
+	num   int    // line number, starting at 1
+	start int    // offset of the line in buf
+	off   int    // offset of text in buf
+	end   int    // offset of the end of the line in buf, \r & \n excluded
+	text  string // content starting at off, until end
+}
+
+// indent returns the column of l text.
+func (l line) indent() int {
+	return l.off - l.start
+}
+
+// isEmpty returns true if l contains only spaces or a comment.
+func (l line) isEmpty() bool {
+	t := strings.TrimLeft(l.text, " \t")
+	return t == "" || t[0] == '#'
+}
+
+type parser struct {
+	buf     []byte
+	lines   []line
+	i       int
+	anchors map[string]any
+	opts    json.ParseOpts
+}
+
+// Parse parses buf as a YAML document. Placeholders & operators are
+// resolved using opts, see [json.ParseOpts]. Scalars starting with
+// "$" are parsed by [json.Parse], "$$" escaping "$".
+func Parse(buf []byte, opts ...json.ParseOpts) (any, error) {
+	p := parser{buf: buf}
+	if len(opts) > 0 {
+		p.opts = opts[0]
+	}
+
+	if err := p.splitLines(); err != nil {
+		return nil, err
+	}
+
+	p.skipEmpty()
+	if p.i == len(p.lines) {
+		return nil, nil
+	}
+
+	v, err := p.parseBlock(0)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipEmpty()
+	if p.i < len(p.lines) {
+		return nil, p.errorf(p.lines[p.i].off, "unexpected content")
+	}
+	return v, nil
+}
+
+// splitLines fills p.lines, handling directives and document markers.
+func (p *parser) splitLines() error {
+	inDoc, ended := false, false
+	for num, start := 1, 0; start < len(p.buf); num++ {
+		end := start
+		for end < len(p.buf) && p.buf[end] != '\n' {
+			end++
+		}
+		next := end + 1
+		if end > start && p.buf[end-1] == '\r' {
+			end--
+		}
+
+		l := line{num: num, start: start, off: start, end: end}
+		for l.off < end && p.buf[l.off] == ' ' {
+			l.off++
+		}
+		l.text = string(p.buf[l.off:end])
+		start = next
+
+		switch {
+		case l.isEmpty():
+			// keep empty lines for block scalars
+
+		case l.indent() == 0 && (l.text == "---" || strings.HasPrefix(l.text, "--- ")):
+			if inDoc || ended {
+				return p.errorf(l.off, "multiple documents are not supported")
+			}
+			inDoc = true
+			l.text = strings.TrimLeft(l.text[3:], " ")
+			l.off = l.end - len(l.text)
+
+		case l.indent() == 0 && (l.text == "..." || strings.HasPrefix(l.text, "... ")):
+			ended = true
+			continue
+
+		case ended:
+			return p.errorf(l.off, "multiple documents are not supported")
+
+		case l.indent() == 0 && l.text[0] == '%' && !inDoc:
+			continue // directive
+
+		default:
+			inDoc = true
+		}
+		p.lines = append(p.lines, l)
+	}
+	return nil
+}
+
+// position returns the position of buf[off].
+func (p *parser) position(off int) json.Position {
+	start := off
+	for start > 0 && p.buf[start-1] != '\n' {
+		start--
+	}
+	return json.Position{
+		Pos:  utf8.RuneCount(p.buf[:off]),
+		Line: 1 + strings.Count(string(p.buf[:off]), "\n"),
+		Col:  utf8.RuneCount(p.buf[start:off]),
+	}
+}
+
+func (p *parser) errorf(off int, format string, args ...any) error {
+	return errors.New(fmt.Sprintf(format, args...) + " " + p.position(off).String())
+}
+
+func (p *parser) skipEmpty() {
+	for p.i < len(p.lines) && p.lines[p.i].isEmpty() {
+		p.i++
+	}
+}
+
+// replaceText replaces the current line by its remaining text
+// starting at off.
+func (p *parser) replaceText(off int) {
+	l := &p.lines[p.i]
+	l.off = off
+	l.text = string(p.buf[off:l.end])
+}
+
+// nextLineAfter makes the line containing buf[off] the current line
+// and checks nothing but a comment follows off in it, then skips it.
+func (p *parser) nextLineAfter(off int) error {
+	for p.i < len(p.lines) && p.lines[p.i].end < off {
+		p.i++
+	}
+	if p.i < len(p.lines) {
+		rest := strings.TrimLeft(string(p.buf[off:p.lines[p.i].end]), " \t")
+		if rest != "" && rest[0] != '#' {
+			return p.errorf(p.lines[p.i].end-len(rest), "unexpected content")
+		}
+		p.i++
+	}
+	return nil
+}
+
+func isSeqEntry(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// parseBlock parses the node starting at the current non-empty line,
+// whose indentation is at least minIndent.
+func (p *parser) parseBlock(minIndent int) (any, error) {
+	l := p.lines[p.i]
+	if l.text[0] == '\t' {
+		return nil, p.errorf(l.off, "tab character used for indentation")
+	}
+
+	if isSeqEntry(l.text) {
+		return p.parseSeq(l.indent())
+	}
+
+	if _, _, ok := p.splitKey(l); ok {
+		return p.parseMap(l.indent())
+	}
+	return p.parseValue(minIndent - 1)
+}
+
+// parseNested parses the block node following a "key:" or a "-"
+// without value, or returns nil if there is none.
+func (p *parser) parseNested(parentIndent int, seqAtSameIndent bool) (any, error) {
+	p.skipEmpty()
+	if p.i == len(p.lines) {
+		return nil, nil
+	}
+	l := p.lines[p.i]
+	if l.indent() > parentIndent {
+		return p.parseBlock(parentIndent + 1)
+	}
+	if seqAtSameIndent && l.indent() == parentIndent && isSeqEntry(l.text) {
+		return p.parseSeq(parentIndent)
+	}
+	return nil, nil
+}
+
+func (p *parser) parseSeq(indent int) (any, error) {
+	seq := []any{}
+	for p.i < len(p.lines) {
+		l := p.lines[p.i]
+		if l.indent() != indent || !isSeqEntry(l.text) {
+			break
+		}
+
+		off := l.off + 1
+		for off < l.end && p.buf[off] == ' ' {
+			off++
+		}
+
+		var (
+			item any
+			err  error
+		)
+		if off == l.end || p.buf[off] == '#' {
+			p.i++
+			item, err = p.parseNested(indent, false)
+		} else {
+			p.replaceText(off)
+			item, err = p.parseBlock(indent + 1)
+		}
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, item)
+		p.skipEmpty()
+	}
+
+	if p.i < len(p.lines) && p.lines[p.i].indent() > indent {
+		return nil, p.errorf(p.lines[p.i].off, "bad indentation of a sequence entry")
+	}
+	return seq, nil
+}
+
+// splitKey returns the key of the mapping entry in l and the offset
+// of its value, or false if l does not contain a mapping entry.
+func (p *parser) splitKey(l line) (string, int, bool) {
+	text := l.text
+	if text == "" {
+		return "", 0, false
+	}
+
+	var key string
+	var colon int
+	switch text[0] {
+	case '"', '\'':
+		s, end, err := p.scanQuoted(l.off, true)
+		if err != nil || end > l.end {
+			return "", 0, false // perhaps a multi-lines quoted scalar
+		}
+		rest := strings.TrimLeft(string(p.buf[end:l.end]), " ")
+		if !strings.HasPrefix(rest, ":") || (len(rest) > 1 && rest[1] != ' ') {
+			return "", 0, false
+		}
+		key, colon = s, l.end-len(rest)
+
+	case '[', '{', '&', '*', '!', '|', '>', '#', '?', '%', '@', '`':
+		return "", 0, false
+
+	default:
+		if strings.HasPrefix(text, "$^") {
+			return "", 0, false
+		}
+		colon = -1
+		for i := 0; i < len(text); i++ {
+			if text[i] == '#' && i > 0 && text[i-1] == ' ' {
+				break
+			}
+			if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+				colon = i
+				break
+			}
+		}
+		if colon < 0 {
+			return "", 0, false
+		}
+		key = strings.TrimRight(text[:colon], " ")
+		colon += l.off
+	}
+
+	off := colon + 1
+	for off < l.end && p.buf[off] == ' ' {
+		off++
+	}
+	return key, off, true
+}
+
+func (p *parser) parseMap(indent int) (any, error) {
+	m := map[string]any{}
+	for p.i < len(p.lines) {
+		l := p.lines[p.i]
+		if l.indent() != indent {
+			break
+		}
+
+		key, off, ok := p.splitKey(l)
+		if !ok {
+			if isSeqEntry(l.text) {
+				break // sequence at the same indentation of the parent key
+			}
+			if l.text[0] == '\t' {
+				return nil, p.errorf(l.off, "tab character used for indentation")
+			}
+			return nil, p.errorf(l.off, "mapping entry expected")
+		}
+		if _, exists := m[key]; exists {
+			return nil, p.errorf(l.off, "duplicated key %q", key)
+		}
+
+		var (
+			v   any
+			err error
+		)
+		if off == l.end || p.buf[off] == '#' {
+			p.i++
+			v, err = p.parseNested(indent, true)
+		} else {
+			p.replaceText(off)
+			v, err = p.parseValue(indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+		p.skipEmpty()
+	}
+
+	if p.i < len(p.lines) && p.lines[p.i].indent() > indent {
+		return nil, p.errorf(p.lines[p.i].off, "bad indentation of a mapping entry")
+	}
+	return m, nil
+}
+
+// parseValue parses the value starting at the current line text. Its
+// continuation lines, if any, are more indented than parentIndent.
+func (p *parser) parseValue(parentIndent int) (any, error) {
+	l := p.lines[p.i]
+
+	var anchor string
+	switch l.text[0] {
+	case '&':
+		end := strings.IndexAny(l.text, " \t")
+		if end < 0 {
+			end = len(l.text)
+		}
+		anchor = l.text[1:end]
+		if anchor == "" {
+			return nil, p.errorf(l.off, "anchor name expected")
+		}
+		off := l.off + end
+		for off < l.end && p.buf[off] == ' ' {
+			off++
+		}
+		if off == l.end || p.buf[off] == '#' {
+			p.i++
+			v, err := p.parseNested(parentIndent, false)
+			if err != nil {
+				return nil, err
+			}
+			p.setAnchor(anchor, v)
+			return v, nil
+		}
+		p.replaceText(off)
+		l = p.lines[p.i]
+
+	case '*':
+		end := strings.IndexAny(l.text, " \t")
+		if end < 0 {
+			end = len(l.text)
+		}
+		v, ok := p.anchors[l.text[1:end]]
+		if !ok {
+			return nil, p.errorf(l.off, "unknown anchor %q", l.text[1:end])
+		}
+		return v, p.nextLineAfter(l.off + end)
+
+	case '!':
+		return nil, p.errorf(l.off, "tags are not supported")
+	}
+
+	var (
+		v   any
+		err error
+	)
+	switch l.text[0] {
+	case '|', '>':
+		v, err = p.parseBlockScalar(parentIndent)
+	case '[', '{':
+		var end int
+		v, end, err = p.parseFlow(l.off)
+		if err == nil {
+			err = p.nextLineAfter(end)
+		}
+	case '"', '\'':
+		var (
+			s   string
+			end int
+		)
+		s, end, err = p.scanQuoted(l.off, false)
+		if err == nil {
+			v, err = p.dollar(s, l.off+1)
+			if err == nil {
+				err = p.nextLineAfter(end)
+			}
+		}
+	default:
+		v, err = p.parsePlain(parentIndent)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if anchor != "" {
+		p.setAnchor(anchor, v)
+	}
+	return v, nil
+}
+
+func (p *parser) setAnchor(name string, v any) {
+	if p.anchors == nil {
+		p.anchors = map[string]any{}
+	}
+	p.anchors[name] = v
+}
+
+// parsePlain parses a plain scalar in block context.
+func (p *parser) parsePlain(parentIndent int) (any, error) {
+	l := p.lines[p.i]
+
+	if strings.HasPrefix(l.text, "$^") {
+		end, err := p.scanOperator(l.off)
+		if err != nil {
+			return nil, err
+		}
+		v, err := p.dollar(string(p.buf[l.off:end]), l.off)
+		if err != nil {
+			return nil, err
+		}
+		return v, p.nextLineAfter(end)
+	}
+
+	s := stripComment(l.text)
+	p.i++
+
+	// Multi-lines plain scalar
+	newLines := 0
+	for j := p.i; j < len(p.lines); j++ {
+		next := p.lines[j]
+		if strings.TrimSpace(next.text) == "" {
+			newLines++
+			continue
+		}
+		if next.indent() <= parentIndent || next.isEmpty() {
+			break
+		}
+		if _, _, ok := p.splitKey(next); ok {
+			return nil, p.errorf(next.off, "bad indentation of a mapping entry")
+		}
+		if newLines > 0 {
+			s += strings.Repeat("\n", newLines)
+		} else {
+			s += " "
+		}
+		s += stripComment(next.text)
+		newLines = 0
+		p.i = j + 1
+	}
+
+	return p.resolvePlain(s, l.off)
+}
+
+// stripComment removes the comment and trailing spaces of a plain
+// scalar.
+func stripComment(s string) string {
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimRight(s, " \t")
+}
+
+var (
+	intRe   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	floatRe = regexp.MustCompile(`^[-+]?(?:\.[0-9]+|[0-9]+(?:\.[0-9]*)?)(?:[eE][-+]?[0-9]+)?$`)
+)
+
+// resolvePlain returns the value of the plain scalar s starting at
+// buf[off].
+func (p *parser) resolvePlain(s string, off int) (any, error) {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1), nil
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1), nil
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN(), nil
+	}
+
+	switch {
+	case s[0] == '$':
+		return p.dollar(s, off)
+
+	case intRe.MatchString(s), floatRe.MatchString(s):
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, nil
+		}
+
+	case len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'o'):
+		base := 16
+		if s[1] == 'o' {
+			base = 8
+		}
+		if u, err := strconv.ParseUint(s[2:], base, 64); err == nil {
+			return float64(u), nil
+		}
+	}
+	return s, nil
+}
+
+// dollar handles placeholders and operators in the scalar s starting
+// at buf[off].
+func (p *parser) dollar(s string, off int) (any, error) {
+	if len(s) <= 1 || s[0] != '$' {
+		return s, nil
+	}
+	// Double $$ at start of strings escape a $
+	if s[1] == '$' {
+		return s[1:], nil
+	}
+
+	opts := p.opts
+	opts.StartPos = p.position(off)
+	return json.Parse([]byte(s), opts)
+}
+
+// scanOperator returns the offset following the operator call
+// starting at buf[off] with "$^".
+func (p *parser) scanOperator(off int) (int, error) {
+	i := off + 2
+	for i < len(p.buf) && (p.buf[i] == '_' ||
+		'a' <= p.buf[i] && p.buf[i] <= 'z' ||
+		'A' <= p.buf[i] && p.buf[i] <= 'Z' ||
+		'0' <= p.buf[i] && p.buf[i] <= '9') {
+		i++
+	}
+	if i == len(p.buf) || p.buf[i] != '(' {
+		return i, nil
+	}
+
+	depth := 0
+	for ; i < len(p.buf); i++ {
+		switch p.buf[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				return i + 1, nil
+			}
+		case '"':
+			for i++; i < len(p.buf) && p.buf[i] != '"'; i++ {
+				if p.buf[i] == '\\' {
+					i++
+				}
+			}
+		}
+	}
+	return 0, p.errorf(off, "unterminated operator call")
+}
+
+// scanQuoted scans the quoted scalar starting at buf[off] and returns
+// its value and the offset following the closing quote.
+func (p *parser) scanQuoted(off int, singleLine bool) (string, int, error) {
+	quote := p.buf[off]
+	var b strings.Builder
+	for i := off + 1; i < len(p.buf); i++ {
+		c := p.buf[i]
+		switch {
+		case c == quote:
+			if quote == '\'' && i+1 < len(p.buf) && p.buf[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			return b.String(), i + 1, nil
+
+		case c == '\n' || c == '\r':
+			if singleLine {
+				return "", 0, p.errorf(off, "unterminated quoted scalar")
+			}
+			// Line folding: trailing & leading white spaces are
+			// removed, a line break becomes a space, empty lines
+			// become line breaks
+			s := strings.TrimRight(b.String(), " \t")
+			b.Reset()
+			b.WriteString(s)
+			newLines := 0
+			for i < len(p.buf) && strings.IndexByte(" \t\r\n", p.buf[i]) >= 0 {
+				if p.buf[i] == '\n' {
+					newLines++
+				}
+				i++
+			}
+			if newLines > 1 {
+				b.WriteString(strings.Repeat("\n", newLines-1))
+			} else {
+				b.WriteByte(' ')
+			}
+			i--
+
+		case c == '\\' && quote == '"':
+			n, err := p.unescape(&b, i)
+			if err != nil {
+				return "", 0, err
+			}
+			i += n
+
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, p.errorf(off, "unterminated quoted scalar")
+}
+
+var escapes = map[byte]string{
+	'0': "\x00", 'a': "\a", 'b': "\b", 't': "\t", '\t': "\t", 'n': "\n",
+	'v': "\v", 'f': "\f", 'r': "\r", 'e': "\x1b", ' ': " ", '"': `"`,
+	'/': "/", '\\': `\`, 'N': "\u0085", '_': "\u00a0", 'L': "\u2028",
+	'P': "\u2029",
+}
+
+// unescape writes in b the escape sequence starting at buf[off] with
+// a backslash and returns the number of bytes consumed after the
+// backslash.
+func (p *parser) unescape(b *strings.Builder, off int) (int, error) {
+	if off+1 == len(p.buf) {
+		return 0, p.errorf(off, "unterminated quoted scalar")
+	}
+	c := p.buf[off+1]
+	if s, ok := escapes[c]; ok {
+		b.WriteString(s)
+		return 1, nil
+	}
+
+	var size int
+	switch c {
+	case '\n', '\r':
+		// Escaped line break: the line break and the following
+		// leading white spaces are removed
+		i := off + 1
+		for i < len(p.buf) && strings.IndexByte(" \t\r\n", p.buf[i]) >= 0 {
+			i++
+		}
+		return i - off - 1, nil
+	case 'x':
+		size = 2
+	case 'u':
+		size = 4
+	case 'U':
+		size = 8
+	default:
+		return 0, p.errorf(off, "invalid escape sequence \\%c", c)
+	}
+
+	if off+2+size > len(p.buf) {
+		return 0, p.errorf(off, "invalid escape sequence")
+	}
+	r, err := strconv.ParseUint(string(p.buf[off+2:off+2+size]), 16, 32)
+	if err != nil {
+		return 0, p.errorf(off, "invalid escape sequence")
+	}
+	b.WriteRune(rune(r))
+	return 1 + size, nil
+}
+
+// parseBlockScalar parses the literal (|) or folded (>) block scalar
+// whose header is the current line text.
+func (p *parser) parseBlockScalar(parentIndent int) (any, error) {
+	l := p.lines[p.i]
+	folded := l.text[0] == '>'
+
+	chomp, indent := byte(0), 0
+	header := stripComment(l.text[1:])
+	for i := 0; i < len(header); i++ {
+		switch c := header[i]; {
+		case (c == '-' || c == '+') && chomp == 0:
+			chomp = c
+		case '1' <= c && c <= '9' && indent == 0:
+			indent = int(c - '0')
+			if parentIndent > 0 {
+				indent += parentIndent
+			}
+		default:
+			return nil, p.errorf(l.off+1+i, "invalid block scalar header")
+		}
+	}
+	p.i++
+
+	var lines []string
+	for ; p.i < len(p.lines); p.i++ {
+		next := p.lines[p.i]
+		if strings.TrimLeft(next.text, " ") == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if indent == 0 {
+			if next.indent() <= parentIndent {
+				break
+			}
+			indent = next.indent()
+		}
+		if next.indent() < indent {
+			break
+		}
+		lines = append(lines, string(p.buf[next.start+indent:next.end]))
+	}
+
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+
+	var b strings.Builder
+	for i, ln := range lines {
+		if i > 0 {
+			prev := lines[i-1]
+			switch {
+			case !folded:
+				b.WriteByte('\n')
+			case ln == "":
+				b.WriteByte('\n')
+			case prev == "":
+			case prev[0] == ' ' || prev[0] == '\t' || ln[0] == ' ' || ln[0] == '\t':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(ln)
+	}
+
+	switch chomp {
+	case '-':
+	case '+':
+		if len(lines) > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(strings.Repeat("\n", trailing))
+	default:
+		if len(lines) > 0 {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String(), nil
+}
+
+// parseFlow parses the flow node starting at buf[off] and returns it
+// with the offset following it.
+func (p *parser) parseFlow(off int) (any, int, error) {
+	off = p.skipFlowSpaces(off)
+	if off == len(p.buf) {
+		return nil, off, p.errorf(off, "unexpected end of flow collection")
+	}
+
+	switch c := p.buf[off]; c {
+	case '[', '{':
+		isMap := c == '{'
+		closing := byte(']')
+		var (
+			seq []any
+			m   map[string]any
+		)
+		if isMap {
+			closing = '}'
+			m = map[string]any{}
+		} else {
+			seq = []any{}
+		}
+
+		off++
+		for {
+			off = p.skipFlowSpaces(off)
+			if off == len(p.buf) {
+				return nil, off, p.errorf(off, "unterminated flow collection")
+			}
+			if p.buf[off] == closing {
+				break
+			}
+
+			if isMap {
+				keyOff := off
+				key, end, err := p.parseFlowScalar(off, true)
+				if err != nil {
+					return nil, end, err
+				}
+				ks, ok := key.(string)
+				if !ok {
+					ks = fmt.Sprint(key)
+				}
+				if _, exists := m[ks]; exists {
+					return nil, end, p.errorf(keyOff, "duplicated key %q", ks)
+				}
+
+				var v any
+				off = p.skipFlowSpaces(end)
+				if off < len(p.buf) && p.buf[off] == ':' {
+					v, off, err = p.parseFlow(off + 1)
+					if err != nil {
+						return nil, off, err
+					}
+				}
+				m[ks] = v
+			} else {
+				v, end, err := p.parseFlow(off)
+				if err != nil {
+					return nil, end, err
+				}
+				seq = append(seq, v)
+				off = end
+			}
+
+			off = p.skipFlowSpaces(off)
+			if off == len(p.buf) {
+				return nil, off, p.errorf(off, "unterminated flow collection")
+			}
+			if p.buf[off] == ',' {
+				off++
+				continue
+			}
+			if p.buf[off] == closing {
+				break
+			}
+			return nil, off, p.errorf(off, "',' or '%c' expected", closing)
+		}
+		if isMap {
+			return m, off + 1, nil
+		}
+		return seq, off + 1, nil
+	}
+
+	return p.parseFlowScalar(off, false)
+}
+
+// parseFlowScalar parses the scalar starting at buf[off] in a flow
+// collection. If isKey is true, it stops at ':'.
+func (p *parser) parseFlowScalar(off int, isKey bool) (any, int, error) {
+	switch c := p.buf[off]; {
+	case c == '"' || c == '\'':
+		s, end, err := p.scanQuoted(off, false)
+		if err != nil {
+			return nil, end, err
+		}
+		if isKey {
+			return s, end, nil
+		}
+		v, err := p.dollar(s, off+1)
+		return v, end, err
+
+	case !isKey && strings.HasPrefix(string(p.buf[off:]), "$^"):
+		end, err := p.scanOperator(off)
+		if err != nil {
+			return nil, end, err
+		}
+		v, err := p.dollar(string(p.buf[off:end]), off)
+		return v, end, err
+
+	case c == '[' || c == '{' || c == '&' || c == '*' || c == '!':
+		if isKey {
+			return nil, off, p.errorf(off, "unsupported key")
+		}
+		return nil, off, p.errorf(off, "anchors, aliases and tags are not supported in flow collections")
+	}
+
+	end := off
+	for ; end < len(p.buf); end++ {
+		c := p.buf[end]
+		if c == ',' || c == ']' || c == '}' || c == '[' || c == '{' || c == '\n' || c == '\r' {
+			break
+		}
+		if c == ':' && (isKey || end+1 == len(p.buf) ||
+			strings.IndexByte(" \t\r\n,[]{}", p.buf[end+1]) >= 0) {
+			break
+		}
+		if c == '#' && end > off && p.buf[end-1] == ' ' {
+			break
+		}
+	}
+
+	s := strings.TrimRight(string(p.buf[off:end]), " \t")
+	if isKey {
+		return s, end, nil
+	}
+	v, err := p.resolvePlain(s, off)
+	return v, end, err
+}
+
+// skipFlowSpaces skips spaces, line breaks and comments starting at
+// buf[off].
+func (p *parser) skipFlowSpaces(off int) int {
+	for off < len(p.buf) {
+		switch p.buf[off] {
+		case ' ', '\t', '\r', '\n':
+			off++
+		case '#':
+			for off < len(p.buf) && p.buf[off] != '\n' {
+				off++
+			}
+		default:
+			return off
+		}
+	}
+	return off
+}
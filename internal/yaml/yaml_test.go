@@ -0,0 +1,224 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package yaml_test
+
+import (
+	ejson "encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/json"
+	"github.com/maxatome/go-testdeep/internal/spew"
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/internal/yaml"
+)
+
+func checkYAML(t *testing.T, gotYAML, expectedJSON string) {
+	t.Helper()
+
+	var expected any
+	err := ejson.Unmarshal([]byte(expectedJSON), &expected)
+	if err != nil {
+		t.Fatalf("bad JSON: %s", err)
+	}
+
+	got, err := yaml.Parse([]byte(gotYAML))
+	if !test.NoError(t, err, "yaml.Parse succeeds") {
+		return
+	}
+	if !reflect.DeepEqual(got, expected) {
+		test.EqualErrorMessage(t,
+			spew.Sdump(got),
+			spew.Sdump(expected),
+			"got matches expected",
+		)
+	}
+}
+
+func TestYAML(t *testing.T) {
+	t.Run("Scalars", func(t *testing.T) {
+		for yml, js := range map[string]string{
+			``:                           `null`,
+			`~`:                          `null`,
+			`null`:                       `null`,
+			`true`:                       `true`,
+			`False`:                      `false`,
+			`42`:                         `42`,
+			`-12.5e1`:                    `-125`,
+			`0x1F`:                       `31`,
+			`0o17`:                       `15`,
+			`foo bar`:                    `"foo bar"`,
+			`foo # comment`:              `"foo"`,
+			`foo#bar`:                    `"foo#bar"`,
+			`"42"`:                       `"42"`,
+			`'it''s'`:                    `"it's"`,
+			`"a\tb\u20ac\\"`:             `"a\tb€\\"`,
+			`$`:                          `"$"`,
+			`$$foo`:                      `"$foo"`,
+			`"$$foo"`:                    `"$foo"`,
+			"foo\n  bar\n\n  baz":        `"foo bar\nbaz"`,
+			"\"foo\n  bar\"":             `"foo bar"`,
+			"\"foo\\\n  bar\"":           `"foobar"`,
+			"--- 12":                     `12`,
+			"%YAML 1.2\n---\nfoo\n...\n": `"foo"`,
+		} {
+			t.Run(yml, func(t *testing.T) { checkYAML(t, yml, js) })
+		}
+
+		got, err := yaml.Parse([]byte(`.inf`))
+		test.NoError(t, err)
+		test.IsTrue(t, got == math.Inf(1))
+
+		got, err = yaml.Parse([]byte(`.nan`))
+		test.NoError(t, err)
+		f, _ := got.(float64)
+		test.IsTrue(t, math.IsNaN(f))
+	})
+
+	t.Run("Block collections", func(t *testing.T) {
+		checkYAML(t, `
+# A comment
+apiVersion: v1
+kind: Pod
+metadata:
+  name: my-pod   # the name
+  labels:
+    app: web
+    "quoted key": 'single'
+spec:
+  containers:
+  - name: web
+    image: nginx:1.25
+    ports:
+      - containerPort: 80
+        protocol: TCP
+  - name: sidecar
+    args: [--verbose, "--port=8080"]
+    env: {}
+  empty:
+  list:
+    -
+    - - nested
+      - seq
+`, `{
+  "apiVersion": "v1",
+  "kind": "Pod",
+  "metadata": {
+    "name": "my-pod",
+    "labels": {"app": "web", "quoted key": "single"}
+  },
+  "spec": {
+    "containers": [
+      {
+        "name": "web",
+        "image": "nginx:1.25",
+        "ports": [{"containerPort": 80, "protocol": "TCP"}]
+      },
+      {
+        "name": "sidecar",
+        "args": ["--verbose", "--port=8080"],
+        "env": {}
+      }
+    ],
+    "empty": null,
+    "list": [null, ["nested", "seq"]]
+  }
+}`)
+
+		checkYAML(t, "- a\n- b: 1\n  c: 2\n-   - x\n", `["a", {"b": 1, "c": 2}, ["x"]]`)
+	})
+
+	t.Run("Flow collections", func(t *testing.T) {
+		checkYAML(t, `{a: 1, "b": [x, 'y', {c: null}], d, e: http://x.y/z, }`,
+			`{"a": 1, "b": ["x", "y", {"c": null}], "d": null, "e": "http://x.y/z"}`)
+		checkYAML(t, "key: [\n  1, # one\n  2,\n]\nnext: true",
+			`{"key": [1, 2], "next": true}`)
+	})
+
+	t.Run("Block scalars", func(t *testing.T) {
+		checkYAML(t, "a: |\n  line1\n   line2\n\n  line3\nb: 1",
+			`{"a": "line1\n line2\n\nline3\n", "b": 1}`)
+		checkYAML(t, "a: |-\n  text\n\n", `{"a": "text"}`)
+		checkYAML(t, "a: |+\n  text\n\n", `{"a": "text\n\n"}`)
+		checkYAML(t, "a: >\n  folded\n  text\n\n  para\n", `{"a": "folded text\npara\n"}`)
+		checkYAML(t, "a: |2\n    indented\n", `{"a": "  indented\n"}`)
+		checkYAML(t, "- |\n  in seq\n- 2", `["in seq\n", 2]`)
+	})
+
+	t.Run("Anchors", func(t *testing.T) {
+		checkYAML(t, "base: &b\n  x: 1\ncopy: *b\nv: &v 12\nw: *v",
+			`{"base": {"x": 1}, "copy": {"x": 1}, "v": 12, "w": 12}`)
+	})
+
+	t.Run("Placeholders & operators", func(t *testing.T) {
+		var opPos []json.Position
+		opts := json.ParseOpts{
+			Placeholders:       []any{"first", "second"},
+			PlaceholdersByName: map[string]any{"name": "named"},
+			OpFn: func(op json.Operator, pos json.Position) (any, error) {
+				opPos = append(opPos, pos)
+				return fmt.Sprintf("%s%v", op.Name, op.Params), nil
+			},
+		}
+
+		got, err := yaml.Parse([]byte(`
+a: $1
+b: "$2"
+c: [$name, '$^NotZero']
+d: $^Between(1, 3)
+e: $^SuperMapOf({"x": 1, "y": [2]}) # comment
+f: $^Re("^a(b)")
+`), opts)
+		if test.NoError(t, err) {
+			test.EqualStr(t, spew.Sdump(got), spew.Sdump(map[string]any{
+				"a": "first",
+				"b": "second",
+				"c": []any{"named", "NotZero[]"},
+				"d": "Between[1 3]",
+				"e": "SuperMapOf[map[x:1 y:[2]]]",
+				"f": "Re[^a(b)]",
+			}))
+		}
+		test.EqualStr(t, fmt.Sprint(opPos[0]), "at line 4:14 (pos 29)")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		for yml, expected := range map[string]string{
+			"a: 1\n  b: 2":            "bad indentation of a mapping entry at line 2:2 (pos 7)",
+			"- [a]\n  - b":            "bad indentation of a sequence entry at line 2:2 (pos 8)",
+			"a: 1\nb":                 "mapping entry expected at line 2:0 (pos 5)",
+			"a: 1\na: 2":              `duplicated key "a" at line 2:0 (pos 5)`,
+			"a: [1, 2":                "unterminated flow collection at line 1:8 (pos 8)",
+			"a: [[1] 2]":              "',' or ']' expected at line 1:8 (pos 8)",
+			"a: 'foo":                 "unterminated quoted scalar at line 1:3 (pos 3)",
+			`a: "\q"`:                 `invalid escape sequence \q at line 1:4 (pos 4)`,
+			"a: \"x\" y":              "unexpected content at line 1:7 (pos 7)",
+			"a: *unknown":             `unknown anchor "unknown" at line 1:3 (pos 3)`,
+			"a: !!str 12":             "tags are not supported at line 1:3 (pos 3)",
+			"a: |x\n  foo":            "invalid block scalar header at line 1:4 (pos 4)",
+			"a: $^Len(":               "unterminated operator call at line 1:3 (pos 3)",
+			"---\na: 1\n---\nb: 2":    "multiple documents are not supported at line 3:0 (pos 9)",
+			"a:\n\t- 1":               "tab character used for indentation at line 2:0 (pos 3)",
+			"a: $1":                   `numeric placeholder "$1", but no params given at line 1:3 (pos 3)`,
+			"a:\n  b: [1, $^Unknown]": `unknown operator "Unknown" at line 2:11 (pos 14)`,
+		} {
+			t.Run(yml, func(t *testing.T) {
+				_, err := yaml.Parse([]byte(yml))
+				if test.Error(t, err) {
+					test.EqualStr(t, err.Error(), expected)
+				}
+
+				// Same with \r\n line endings
+				_, err = yaml.Parse([]byte(strings.ReplaceAll(yml, "\n", "\r\n")))
+				test.Error(t, err)
+			})
+		}
+	})
+}
@@ -293,6 +293,11 @@ func ifaceExpectedError(t *testing.T, expectedError expectedError) expectedError
 		newExpectedError.Origin = &newOrigin
 	}
 
+	if newExpectedError.Next != nil {
+		newNext := ifaceExpectedError(t, *newExpectedError.Next)
+		newExpectedError.Next = &newNext
+	}
+
 	return newExpectedError
 }
 
@@ -472,3 +477,20 @@ func equalTypes(t *testing.T, got td.TestDeep, expected any, args ...any) bool {
 		tdutil.BuildTestName(args...), gotStr, expectedStr)
 	return false
 }
+
+// setenv sets the environment variable key to value, and restores
+// its previous state when t and all its subtests complete. Contrary
+// to testing.T.Setenv, it is available before go1.17.
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+
+	oldValue, set := os.LookupEnv(key)
+	os.Setenv(key, value) //nolint: errcheck
+	t.Cleanup(func() {
+		if set {
+			os.Setenv(key, oldValue) //nolint: errcheck
+		} else {
+			os.Unsetenv(key) //nolint: errcheck
+		}
+	})
+}
@@ -11,9 +11,7 @@ import (
 	"strings"
 
 	"github.com/maxatome/go-testdeep/helpers/tdutil"
-	"github.com/maxatome/go-testdeep/internal/color"
 	"github.com/maxatome/go-testdeep/internal/ctxerr"
-	"github.com/maxatome/go-testdeep/internal/flat"
 	"github.com/maxatome/go-testdeep/internal/trace"
 )
 
@@ -114,35 +112,17 @@ func stripTrace(s trace.Stack) trace.Stack {
 func formatError(t TestingT, isFatal bool, err *ctxerr.Error, args ...any) {
 	t.Helper()
 
-	const failedTest = "Failed test"
+	failure := newFailure(isFatal, err, args)
 
-	args = flat.Interfaces(args...)
-
-	var buf strings.Builder
-	color.AppendTestNameOn(&buf)
-	if len(args) == 0 {
-		buf.WriteString(failedTest)
-	} else {
-		buf.WriteString(failedTest + " '")
-		tdutil.FbuildTestName(&buf, args...)
-		buf.WriteString("'")
-	}
-	color.AppendTestNameOff(&buf)
-	buf.WriteString("\n")
-
-	err.Append(&buf, "", true)
-
-	// Stask trace
-	if s := stripTrace(trace.Retrieve(0, "testing.tRunner")); s.IsRelevant() {
-		buf.WriteString("\nThis is how we got here:\n")
-		s.Dump(&buf)
+	if rerr := reportJSON(t, failure); rerr != nil {
+		failure.Notes = append(failure.Notes, rerr.Error())
 	}
 
-	if isFatal {
-		t.Fatal(buf.String())
-	} else {
-		t.Error(buf.String())
+	reporter, _ := err.Context.Reporter.(Reporter)
+	if reporter == nil {
+		reporter = DefaultReporter
 	}
+	reporter.Report(t, failure)
 }
 
 func cmpDeeply(ctx ctxerr.Context, t TestingT, got, expected any,
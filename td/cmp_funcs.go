@@ -12,7 +12,7 @@ import (
 	"time"
 )
 
-// allOperators lists the 70 operators.
+// allOperators lists the 82 operators.
 // nil means not usable in JSON().
 var allOperators = map[string]any{
 	"All":          All,
@@ -24,11 +24,13 @@ var allOperators = map[string]any{
 	"Cap":          nil,
 	"Catch":        nil,
 	"Code":         nil,
+	"Consistently": nil,
 	"Contains":     Contains,
 	"ContainsKey":  ContainsKey,
 	"Delay":        nil,
 	"Empty":        Empty,
 	"ErrorIs":      nil,
+	"Eventually":   nil,
 	"First":        First,
 	"Grep":         Grep,
 	"Gt":           Gt,
@@ -38,7 +40,9 @@ var allOperators = map[string]any{
 	"Ignore":       Ignore,
 	"Isa":          nil,
 	"JSON":         nil,
+	"JSONPath":     JSONPath,
 	"JSONPointer":  JSONPointer,
+	"JSONSchema":   JSONSchema,
 	"Keys":         Keys,
 	"Last":         Last,
 	"Lax":          nil,
@@ -59,6 +63,7 @@ var allOperators = map[string]any{
 	"NotNil":       NotNil,
 	"NotZero":      NotZero,
 	"PPtr":         nil,
+	"Partial":      nil,
 	"Ptr":          nil,
 	"Re":           Re,
 	"ReAll":        ReAll,
@@ -68,6 +73,7 @@ var allOperators = map[string]any{
 	"Shallow":      nil,
 	"Slice":        nil,
 	"Smuggle":      nil,
+	"Snapshot":     nil,
 	"Sort":         Sort,
 	"Sorted":       Sorted,
 	"String":       nil,
@@ -76,14 +82,20 @@ var allOperators = map[string]any{
 	"SubJSONOf":    nil,
 	"SubMapOf":     SubMapOf,
 	"SubSetOf":     SubSetOf,
+	"SubXMLOf":     nil,
+	"SubYAMLOf":    nil,
 	"SuperBagOf":   SuperBagOf,
 	"SuperJSONOf":  nil,
 	"SuperMapOf":   SuperMapOf,
 	"SuperSetOf":   SuperSetOf,
 	"SuperSliceOf": nil,
+	"SuperXMLOf":   nil,
+	"SuperYAMLOf":  nil,
 	"Tag":          nil,
 	"TruncTime":    nil,
 	"Values":       Values,
+	"XML":          nil,
+	"YAML":         nil,
 	"Zero":         Zero,
 }
 
@@ -263,6 +275,27 @@ func CmpCode(t TestingT, got, fn any, args ...any) bool {
 	return Cmp(t, got, Code(fn), args...)
 }
 
+// CmpConsistently is a shortcut for:
+//
+//	td.Cmp(t, got, td.Consistently(expectedValue, duration, interval), args...)
+//
+// See [Consistently] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpConsistently(t TestingT, got, expectedValue any, duration, interval time.Duration, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Consistently(expectedValue, duration, interval), args...)
+}
+
 // CmpContains is a shortcut for:
 //
 //	td.Cmp(t, got, td.Contains(expectedValue), args...)
@@ -347,6 +380,27 @@ func CmpErrorIs(t TestingT, got, expectedError any, args ...any) bool {
 	return Cmp(t, got, ErrorIs(expectedError), args...)
 }
 
+// CmpEventually is a shortcut for:
+//
+//	td.Cmp(t, got, td.Eventually(expectedValue, timeout, interval), args...)
+//
+// See [Eventually] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpEventually(t TestingT, got, expectedValue any, timeout, interval time.Duration, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Eventually(expectedValue, timeout, interval), args...)
+}
+
 // CmpFirst is a shortcut for:
 //
 //	td.Cmp(t, got, td.First(filter, expectedValue), args...)
@@ -515,6 +569,27 @@ func CmpJSON(t TestingT, got, expectedJSON any, params []any, args ...any) bool
 	return Cmp(t, got, JSON(expectedJSON, params...), args...)
 }
 
+// CmpJSONPath is a shortcut for:
+//
+//	td.Cmp(t, got, td.JSONPath(expr, expectedValue), args...)
+//
+// See [JSONPath] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpJSONPath(t TestingT, got any, expr string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, JSONPath(expr, expectedValue), args...)
+}
+
 // CmpJSONPointer is a shortcut for:
 //
 //	td.Cmp(t, got, td.JSONPointer(ptr, expectedValue), args...)
@@ -536,6 +611,27 @@ func CmpJSONPointer(t TestingT, got any, ptr string, expectedValue any, args ...
 	return Cmp(t, got, JSONPointer(ptr, expectedValue), args...)
 }
 
+// CmpJSONSchema is a shortcut for:
+//
+//	td.Cmp(t, got, td.JSONSchema(schema), args...)
+//
+// See [JSONSchema] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpJSONSchema(t TestingT, got, schema any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, JSONSchema(schema), args...)
+}
+
 // CmpKeys is a shortcut for:
 //
 //	td.Cmp(t, got, td.Keys(val), args...)
@@ -943,6 +1039,31 @@ func CmpNotZero(t TestingT, got any, args ...any) bool {
 	return Cmp(t, got, NotZero(), args...)
 }
 
+// CmpPartial is a shortcut for:
+//
+//	td.Cmp(t, got, td.Partial(model, expectedFields), args...)
+//
+// See [Partial] for details.
+//
+// [Partial] optional parameter expectedFields is here mandatory.
+// nil value should be passed to mimic its absence in
+// original [Partial] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpPartial(t TestingT, got, model any, expectedFields StructFields, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Partial(model, expectedFields), args...)
+}
+
 // CmpPPtr is a shortcut for:
 //
 //	td.Cmp(t, got, td.PPtr(val), args...)
@@ -1144,6 +1265,27 @@ func CmpSmuggle(t TestingT, got, fn, expectedValue any, args ...any) bool {
 	return Cmp(t, got, Smuggle(fn, expectedValue), args...)
 }
 
+// CmpSnapshot is a shortcut for:
+//
+//	td.Cmp(t, got, td.Snapshot(name, params...), args...)
+//
+// See [Snapshot] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpSnapshot(t TestingT, got any, name string, params []any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Snapshot(name, params...), args...)
+}
+
 // CmpSort is a shortcut for:
 //
 //	td.Cmp(t, got, td.Sort(how, expectedValue), args...)
@@ -1349,6 +1491,48 @@ func CmpSubSetOf(t TestingT, got any, expectedItems []any, args ...any) bool {
 	return Cmp(t, got, SubSetOf(expectedItems...), args...)
 }
 
+// CmpSubXMLOf is a shortcut for:
+//
+//	td.Cmp(t, got, td.SubXMLOf(expectedXML, params...), args...)
+//
+// See [SubXMLOf] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpSubXMLOf(t TestingT, got, expectedXML any, params []any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, SubXMLOf(expectedXML, params...), args...)
+}
+
+// CmpSubYAMLOf is a shortcut for:
+//
+//	td.Cmp(t, got, td.SubYAMLOf(expectedYAML, params...), args...)
+//
+// See [SubYAMLOf] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpSubYAMLOf(t TestingT, got, expectedYAML any, params []any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, SubYAMLOf(expectedYAML, params...), args...)
+}
+
 // CmpSuperBagOf is a shortcut for:
 //
 //	td.Cmp(t, got, td.SuperBagOf(expectedItems...), args...)
@@ -1462,6 +1646,48 @@ func CmpSuperSliceOf(t TestingT, got, model any, expectedEntries ArrayEntries, a
 	return Cmp(t, got, SuperSliceOf(model, expectedEntries), args...)
 }
 
+// CmpSuperXMLOf is a shortcut for:
+//
+//	td.Cmp(t, got, td.SuperXMLOf(expectedXML, params...), args...)
+//
+// See [SuperXMLOf] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpSuperXMLOf(t TestingT, got, expectedXML any, params []any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, SuperXMLOf(expectedXML, params...), args...)
+}
+
+// CmpSuperYAMLOf is a shortcut for:
+//
+//	td.Cmp(t, got, td.SuperYAMLOf(expectedYAML, params...), args...)
+//
+// See [SuperYAMLOf] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpSuperYAMLOf(t TestingT, got, expectedYAML any, params []any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, SuperYAMLOf(expectedYAML, params...), args...)
+}
+
 // CmpTruncTime is a shortcut for:
 //
 //	td.Cmp(t, got, td.TruncTime(expectedTime, trunc), args...)
@@ -1508,6 +1734,48 @@ func CmpValues(t TestingT, got, val any, args ...any) bool {
 	return Cmp(t, got, Values(val), args...)
 }
 
+// CmpXML is a shortcut for:
+//
+//	td.Cmp(t, got, td.XML(expectedXML, params...), args...)
+//
+// See [XML] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpXML(t TestingT, got, expectedXML any, params []any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, XML(expectedXML, params...), args...)
+}
+
+// CmpYAML is a shortcut for:
+//
+//	td.Cmp(t, got, td.YAML(expectedYAML, params...), args...)
+//
+// See [YAML] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpYAML(t TestingT, got, expectedYAML any, params []any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, YAML(expectedYAML, params...), args...)
+}
+
 // CmpZero is a shortcut for:
 //
 //	td.Cmp(t, got, td.Zero(), args...)
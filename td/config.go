@@ -80,10 +80,28 @@ type ContextConfig struct {
 	// most of the time it is a mistake to compare (expected, got)
 	// instead of official (got, expected).
 	TestDeepInGotOK bool
+	// UseDiff allows to render failures as a unified diff between got
+	// and expected values, instead of dumping them one after the
+	// other. It only applies when at least one of got or expected
+	// dump spans several lines. When both got and expected are
+	// strings, their raw contents are compared instead of their
+	// quoted representations. If colors are enabled, the changed
+	// characters of modified lines are highlighted.
+	//
+	// It defaults to false except if the environment variable
+	// TESTDEEP_DIFF is set to a true value as "1" or "true" (see
+	// strconv.ParseBool).
+	UseDiff bool
+	// Reporter is used to report failures. If nil, DefaultReporter is
+	// used. It allows to customize the failure layout, to suppress the
+	// stack trace, to add links to the sources or to forward failures
+	// to a custom collector. See Reporter and Failure types for
+	// details.
+	Reporter Reporter
 }
 
-// Equal returns true if both c and o are equal. Only public fields
-// are taken into account to check equality.
+// Equal returns true if both c and o are equal. Only public fields,
+// except Reporter, are taken into account to check equality.
 func (c ContextConfig) Equal(o ContextConfig) bool {
 	return c.RootName == o.RootName &&
 		c.MaxErrors == o.MaxErrors &&
@@ -91,7 +109,8 @@ func (c ContextConfig) Equal(o ContextConfig) bool {
 		c.UseEqual == o.UseEqual &&
 		c.BeLax == o.BeLax &&
 		c.IgnoreUnexported == o.IgnoreUnexported &&
-		c.TestDeepInGotOK == o.TestDeepInGotOK
+		c.TestDeepInGotOK == o.TestDeepInGotOK &&
+		c.UseDiff == o.UseDiff
 }
 
 // OriginalPath returns the current path when the [ContextConfig] has
@@ -109,6 +128,7 @@ const (
 	contextDefaultRootName = "DATA"
 	contextPanicRootName   = "FUNCTION"
 	envMaxErrors           = "TESTDEEP_MAX_ERRORS"
+	envDiff                = "TESTDEEP_DIFF"
 )
 
 func getMaxErrorsFromEnv() int {
@@ -122,6 +142,11 @@ func getMaxErrorsFromEnv() int {
 	return 10
 }
 
+func getUseDiffFromEnv() bool {
+	useDiff, _ := strconv.ParseBool(os.Getenv(envDiff))
+	return useDiff
+}
+
 // DefaultContextConfig is the default configuration used to render
 // tests failures. If overridden, new settings will impact all Cmp*
 // functions and [*T] methods (if not specifically configured.)
@@ -133,6 +158,7 @@ var DefaultContextConfig = ContextConfig{
 	BeLax:            false,
 	IgnoreUnexported: false,
 	TestDeepInGotOK:  false,
+	UseDiff:          getUseDiffFromEnv(),
 }
 
 func (c *ContextConfig) sanitize() {
@@ -171,6 +197,8 @@ func newContextWithConfig(tb testing.TB, config ContextConfig) (ctx ctxerr.Conte
 		BeLax:            config.BeLax,
 		IgnoreUnexported: config.IgnoreUnexported,
 		TestDeepInGotOK:  config.TestDeepInGotOK,
+		UseDiff:          config.UseDiff,
+		Reporter:         config.Reporter,
 	}
 
 	ctx.InitErrors()
@@ -29,12 +29,13 @@ func TestContext(t *testing.T) {
 		}
 	}
 
-	nctx = newContext(Require(t).UseEqual().TestDeepInGotOK())
+	nctx = newContext(Require(t).UseEqual().TestDeepInGotOK().UseDiff())
 	_, ok := nctx.OriginalTB.(*T)
 	test.IsTrue(t, ok)
 	test.IsTrue(t, nctx.FailureIsFatal)
 	test.IsTrue(t, nctx.UseEqual)
 	test.IsTrue(t, nctx.TestDeepInGotOK)
+	test.IsTrue(t, nctx.UseDiff)
 	test.EqualStr(t, nctx.Path.String(), "DATA")
 
 	nctx = newBooleanContext()
@@ -84,3 +85,26 @@ func TestGetMaxErrorsFromEnv(t *testing.T) {
 	os.Setenv(envMaxErrors, "-8") //nolint: errcheck
 	test.EqualInt(t, getMaxErrorsFromEnv(), -8)
 }
+
+func TestGetUseDiffFromEnv(t *testing.T) {
+	oldEnv, set := os.LookupEnv(envDiff)
+	defer func() {
+		if set {
+			os.Setenv(envDiff, oldEnv) //nolint: errcheck
+		} else {
+			os.Unsetenv(envDiff) //nolint: errcheck
+		}
+	}()
+
+	os.Setenv(envDiff, "") //nolint: errcheck
+	test.IsFalse(t, getUseDiffFromEnv())
+
+	os.Setenv(envDiff, "aaa") //nolint: errcheck
+	test.IsFalse(t, getUseDiffFromEnv())
+
+	os.Setenv(envDiff, "1") //nolint: errcheck
+	test.IsTrue(t, getUseDiffFromEnv())
+
+	os.Setenv(envDiff, "true") //nolint: errcheck
+	test.IsTrue(t, getUseDiffFromEnv())
+}
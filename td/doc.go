@@ -284,9 +284,54 @@
 // documentation for details (if go<1.27, see [A] & [Anchor] functions
 // instead.)
 //
+// # Machine-readable failure report
+//
+// In addition to the usual text output, each failing Cmp* function
+// or [*T] method can be reported as a JSON line appended to a file,
+// so CI tooling can extract failures without parsing the text
+// output. It is enabled by setting the TESTDEEP_REPORT environment
+// variable to json:FILE:
+//
+//	TESTDEEP_REPORT=json:/tmp/td-report.jsonl go test ./...
+//
+// Each line is a JSON object as (indented here for readability):
+//
+//	{
+//	  "Time": "2026-10-18T10:20:30.123456789+02:00",
+//	  "Package": "github.com/me/project/pkg",
+//	  "Test": "TestCreateRecord",
+//	  "Name": "Newly created record",
+//	  "Caller": "pkg/record_test.go:25",
+//	  "Fatal": false,
+//	  "Errors": [
+//	    {
+//	      "Path": "DATA.Age",
+//	      "Message": "values differ",
+//	      "Got": "12",
+//	      "Expected": "between 18 and 30",
+//	      "Location": {
+//	        "Operator": "Between",
+//	        "File": "record_test.go",
+//	        "Line": 29
+//	      }
+//	    }
+//	  ]
+//	}
+//
+// Package and Test fields are the same as the ones of [go test -json]
+// events, so both streams can be joined. Errors items are
+// [FailureError] objects, whose Got, Expected, Summary, Location and
+// Origin fields are omitted when empty. As the file
+// is opened in append mode and each line is written at once, several
+// test binaries can share the same report file.
+//
+// To go further, failures reporting can be fully customized using
+// [ContextConfig.Reporter].
+//
 // [go-testdeep]: https://go-testdeep.zetta.rocks/
 // [Test::Deep]: https://metacpan.org/pod/Test::Deep
 // ["operators"]: https://go-testdeep.zetta.rocks/operators/
 // [tdhttp]: https://pkg.go.dev/github.com/maxatome/go-testdeep/helpers/tdhttp
 // [tdsuite]: https://pkg.go.dev/github.com/maxatome/go-testdeep/helpers/tdsuite
+// [go test -json]: https://pkg.go.dev/cmd/test2json
 package td // import "github.com/maxatome/go-testdeep/td"
@@ -43,6 +43,7 @@ func deepValueEqualFinal(ctx ctxerr.Context, got, expected reflect.Value) (err *
 func deepValueEqualFinalOK(ctx ctxerr.Context, got, expected reflect.Value) (bool, *ctxerr.Error) {
 	ctx = ctx.ResetErrors()
 	ctx.BooleanError = true
+	ctx.Probing = true
 
 	if err := deepValueEqualFinal(ctx, got, expected); err != nil {
 		if err == ctxerr.BooleanError {
@@ -72,3 +72,27 @@ func ExampleEqDeeplyError() {
 	// 	expected: 3 ≤ got ≤ 8
 	// [under operator Between at example.go:18]
 }
+
+func ExampleMatcher() {
+	m := td.Matcher(td.Between(40, 45))
+
+	fmt.Println("want:", m.String())
+	fmt.Println("42 matches:", m.Matches(42))
+	fmt.Println("46 matches:", m.Matches(46))
+
+	// Output:
+	// want: 40 ≤ got ≤ 45
+	// 42 matches: true
+	// 46 matches: false
+}
+
+func ExampleMatchFunc() {
+	isBob := td.MatchFunc(td.HasPrefix("Bob"))
+
+	fmt.Println("Bobby:", isBob("Bobby"))
+	fmt.Println("Alice:", isBob("Alice"))
+
+	// Output:
+	// Bobby: true
+	// Alice: false
+}
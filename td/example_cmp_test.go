@@ -458,6 +458,27 @@ func ExampleCmpCode_custom() {
 	// with assert & require *td.T: true
 }
 
+func ExampleCmpConsistently() {
+	t := &testing.T{}
+
+	calls := 0
+	getter := func() int {
+		calls++
+		return calls
+	}
+
+	ok := td.CmpConsistently(t, getter, td.Lt(100), 20*time.Millisecond, 5*time.Millisecond)
+	fmt.Println("always < 100 during 20ms:", ok)
+
+	calls = 0
+	ok = td.CmpConsistently(t, getter, td.Lt(3), 20*time.Millisecond, 5*time.Millisecond)
+	fmt.Println("always < 3 during 20ms:", ok, "- stopped after", calls, "calls")
+
+	// Output:
+	// always < 100 during 20ms: true
+	// always < 3 during 20ms: false - stopped after 3 calls
+}
+
 func ExampleCmpContains_arraySlice() {
 	t := &testing.T{}
 
@@ -749,6 +770,29 @@ func ExampleCmpErrorIs() {
 	// err1 is err: false
 }
 
+func ExampleCmpEventually() {
+	t := &testing.T{}
+
+	calls := 0
+	getter := func() string {
+		calls++
+		if calls < 3 {
+			return "running"
+		}
+		return "done"
+	}
+
+	ok := td.CmpEventually(t, getter, "done", time.Second, 5*time.Millisecond)
+	fmt.Println("done eventually:", ok, "after", calls, "calls")
+
+	ok = td.CmpEventually(t, getter, td.HasPrefix("fail"), 20*time.Millisecond, 5*time.Millisecond)
+	fmt.Println("failed eventually:", ok)
+
+	// Output:
+	// done eventually: true after 3 calls
+	// failed eventually: false
+}
+
 func ExampleCmpFirst_classic() {
 	t := &testing.T{}
 
@@ -1401,6 +1445,49 @@ func ExampleCmpJSON_file() {
 	// Full match from io.Reader: true
 }
 
+func ExampleCmpJSONPath() {
+	t := &testing.T{}
+
+	got := json.RawMessage(`
+{
+  "store": {
+    "books": [
+      {"title": "Sayings of the Century", "price": 8.95},
+      {"title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+      {"title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+    ],
+    "bicycle": {"color": "red", "price": 399}
+  }
+}`)
+
+	ok := td.CmpJSONPath(t, got, "$.store.books[*].title", []string{
+		"Sayings of the Century",
+		"Moby Dick",
+		"The Lord of the Rings",
+	})
+	fmt.Println("all titles:", ok)
+
+	ok = td.CmpJSONPath(t, got, "$..price", td.Len(4))
+	fmt.Println("4 prices in the store:", ok)
+
+	ok = td.CmpJSONPath(t, got, "$.store.books[?@.isbn && @.price < 10].title", []string{"Moby Dick"})
+	fmt.Println("books with an ISBN and cheaper than 10:", ok)
+
+	ok = td.CmpJSONPath(t, got, "$.store.books[-1:]", td.ArrayEach(
+		td.SuperMapOf(map[string]any{"price": td.Gt(20)}, nil)))
+	fmt.Println("last book is expensive:", ok)
+
+	ok = td.CmpJSONPath(t, got, "$.store.books[?@.price > 100]", td.Empty())
+	fmt.Println("no book costs more than 100:", ok)
+
+	// Output:
+	// all titles: true
+	// 4 prices in the store: true
+	// books with an ISBN and cheaper than 10: true
+	// last book is expensive: true
+	// no book costs more than 100: true
+}
+
 func ExampleCmpJSONPointer_rfc6901() {
 	t := &testing.T{}
 
@@ -1583,6 +1670,59 @@ func ExampleCmpJSONPointer_has_hasnt() {
 	// Britt hasn't children: false
 }
 
+func ExampleCmpJSONSchema() {
+	t := &testing.T{}
+
+	type Person struct {
+		Name     string    `json:"name"`
+		Age      int       `json:"age"`
+		Children []*Person `json:"children,omitempty"`
+	}
+
+	schema := `
+{
+  "$defs": {
+    "person": {
+      "type": "object",
+      "required": ["name", "age"],
+      "properties": {
+        "name":     {"type": "string", "minLength": 1},
+        "age":      {"type": "integer", "minimum": 0, "maximum": 150},
+        "children": {"type": "array", "items": {"$ref": "#/$defs/person"}}
+      }
+    }
+  },
+  "$ref": "#/$defs/person"
+}`
+
+	got := Person{
+		Name:     "Bob",
+		Age:      42,
+		Children: []*Person{{Name: "Alice", Age: 16}},
+	}
+	ok := td.CmpJSONSchema(t, got, schema)
+	fmt.Println("Bob fits the schema:", ok)
+
+	got.Children[0].Age = 160
+	ok = td.CmpJSONSchema(t, got, schema)
+	fmt.Println("Bob with a 160 years old child fits the schema:", ok)
+
+	// JSONSchema can also be embedded in JSON
+	got.Children[0].Age = 16
+	ok = td.Cmp(t, got, td.JSON(`
+{
+  "name":     "Bob",
+  "age":      42,
+  "children": JSONSchema({"type": "array", "minItems": 1})
+}`))
+	fmt.Println("Bob has children:", ok)
+
+	// Output:
+	// Bob fits the schema: true
+	// Bob with a 160 years old child fits the schema: false
+	// Bob has children: true
+}
+
 func ExampleCmpKeys() {
 	t := &testing.T{}
 
@@ -2352,6 +2492,57 @@ func ExampleCmpNotZero() {
 	// false
 }
 
+func ExampleCmpPartial() {
+	t := &testing.T{}
+
+	type Address struct {
+		City    string
+		Country string
+	}
+	type Person struct {
+		Name      string
+		Age       int
+		Addresses []Address
+		Tags      map[string]string
+	}
+
+	got := Person{
+		Name: "Foobar",
+		Age:  42,
+		Addresses: []Address{
+			{City: "Paris", Country: "France"},
+			{City: "London", Country: "UK"},
+		},
+		Tags: map[string]string{"team": "blue", "role": "dev"},
+	}
+
+	// Zero fields are ignored, at any depth
+	ok := td.CmpPartial(t, got, Person{
+		Name:      "Foobar",
+		Addresses: []Address{{}, {Country: "UK"}},
+		Tags:      map[string]string{"team": "blue"},
+	}, nil,
+		"checks %v is the right Person")
+	fmt.Println("Foobar lives in UK and is in blue team:", ok)
+
+	// StructFields can still be used
+	ok = td.CmpPartial(t, got, Person{Addresses: []Address{{City: "Paris"}, {}}}, td.StructFields{
+		"Age": td.Between(40, 50),
+	},
+		"checks %v is the right Person")
+	fmt.Println("Foobar is between 40 & 50 and lives in Paris:", ok)
+
+	// Slices lengths are still checked
+	ok = td.CmpPartial(t, got, Person{Addresses: []Address{{City: "Paris"}}}, nil,
+		"checks %v is the right Person")
+	fmt.Println("Foobar has only one address:", ok)
+
+	// Output:
+	// Foobar lives in UK and is in blue team: true
+	// Foobar is between 40 & 50 and lives in Paris: true
+	// Foobar has only one address: false
+}
+
 func ExampleCmpPPtr() {
 	t := &testing.T{}
 
@@ -3946,6 +4137,51 @@ func ExampleCmpSubSetOf() {
 	// true
 }
 
+func ExampleCmpSubXMLOf() {
+	t := &testing.T{}
+
+	got := `<user id="42"><name>Bob</name></user>`
+
+	ok := td.CmpSubXMLOf(t, got, `<user id="$1" admin="true"><name>Bob</name><age>42</age></user>`, []any{td.NotZero()})
+	fmt.Println("check got is a subset of expected:", ok)
+
+	ok = td.CmpSubXMLOf(t, got, `<user><name>Bob</name><age>42</age></user>`, nil)
+	fmt.Println("check got without id attribute expected:", ok)
+
+	// Output:
+	// check got is a subset of expected: true
+	// check got without id attribute expected: false
+}
+
+func ExampleCmpSubYAMLOf() {
+	t := &testing.T{}
+
+	got := &struct {
+		Fullname string `json:"fullname"`
+		Age      int    `json:"age"`
+	}{
+		Fullname: "Bob",
+		Age:      42,
+	}
+
+	ok := td.CmpSubYAMLOf(t, got, `
+fullname: Bob
+age: $^Between(40, 45)
+gender: male # missing from got, but allowed
+`, nil)
+	fmt.Println("check got with gender field:", ok)
+
+	ok = td.CmpSubYAMLOf(t, got, `
+fullname: Bob
+gender: male
+`, nil)
+	fmt.Println("check got without age field:", ok)
+
+	// Output:
+	// check got with gender field: true
+	// check got without age field: false
+}
+
 func ExampleCmpSuperBagOf() {
 	t := &testing.T{}
 
@@ -4285,6 +4521,64 @@ func ExampleCmpSuperSliceOf_typedSlice() {
 	// Only check items #0 & #3 of a slice pointer, using nil model: true
 }
 
+func ExampleCmpSuperXMLOf() {
+	t := &testing.T{}
+
+	got := `<users>
+  <user id="1" admin="true"><name>Bob</name><age>42</age></user>
+  <user id="2"><name>Alice</name><age>37</age></user>
+</users>`
+
+	ok := td.CmpSuperXMLOf(t, got, `<users><user id="2"/></users>`, nil)
+	fmt.Println("check user #2 is present:", ok)
+
+	ok = td.CmpSuperXMLOf(t, got, `<users><user id="2"/><user admin="$1"/></users>`, []any{true})
+	fmt.Println("check an admin follows user #2:", ok)
+
+	ok = td.CmpSuperXMLOf(t, got, `<users><user id="2"/><user admin="$1"/></users>`, []any{td.XMLUnordered, true})
+	fmt.Println("check user #2 and an admin are present:", ok)
+
+	// Output:
+	// check user #2 is present: true
+	// check an admin follows user #2: false
+	// check user #2 and an admin are present: true
+}
+
+func ExampleCmpSuperYAMLOf() {
+	t := &testing.T{}
+
+	got := &struct {
+		Fullname string   `json:"fullname"`
+		Age      int      `json:"age"`
+		Gender   string   `json:"gender"`
+		Children []string `json:"children"`
+	}{
+		Fullname: "Bob",
+		Age:      42,
+		Gender:   "male",
+		Children: []string{"Alice", "Brian"},
+	}
+
+	ok := td.CmpSuperYAMLOf(t, got, `
+fullname: $name
+age: $2
+children:
+  - Alice
+  - $^HasPrefix("Br")
+`, []any{td.Tag("name", td.HasPrefix("Bo")), td.Between(40, 45)})
+	fmt.Println("check got with placeholders:", ok)
+
+	ok = td.CmpSuperYAMLOf(t, got, `
+fullname: Bob
+details: {}
+`, nil)
+	fmt.Println("check got with details field:", ok)
+
+	// Output:
+	// check got with placeholders: true
+	// check got with details field: false
+}
+
 func ExampleCmpTruncTime() {
 	t := &testing.T{}
 
@@ -4350,6 +4644,96 @@ func ExampleCmpValues() {
 	// Each value is between 1 and 3: true
 }
 
+func ExampleCmpXML() {
+	t := &testing.T{}
+
+	got := `<?xml version="1.0" encoding="UTF-8"?>
+<user xmlns="urn:example:users" id="42">
+  <name>Bob</name>
+  <age>42</age>
+</user>`
+
+	ok := td.CmpXML(t, got, `
+<u:user xmlns:u="urn:example:users" id="42">
+  <u:name>Bob</u:name>
+  <u:age>42</u:age>
+</u:user>`, nil)
+	fmt.Println("check got, namespace prefix does not matter:", ok)
+
+	ok = td.CmpXML(t, got, `
+<user xmlns="urn:example:users" id="$1">
+  <name>$name</name>
+  <age>$^Between(40, 45)</age>
+</user>`, []any{td.NotZero(), td.Tag("name", td.HasPrefix("Bo"))})
+	fmt.Println("check got with placeholders and operators:", ok)
+
+	ok = td.CmpXML(t, got, `
+<user xmlns="urn:example:users" id="42">
+  <age>42</age>
+  <name>Bob</name>
+</user>`, nil)
+	fmt.Println("check got with children in another order:", ok)
+
+	ok = td.CmpXML(t, got, `
+<user xmlns="urn:example:users" id="42">
+  <age>42</age>
+  <name>Bob</name>
+</user>`, []any{td.XMLUnordered})
+	fmt.Println("check got with children in any order:", ok)
+
+	type user struct { // element name is the type name
+		ID   int    `xml:"id,attr"`
+		Name string `xml:"name"`
+	}
+	ok = td.CmpXML(t, user{ID: 42, Name: "Bob"}, `<user id="42"><name>Bob</name></user>`, nil)
+	fmt.Println("check XML representation of a struct:", ok)
+
+	// Output:
+	// check got, namespace prefix does not matter: true
+	// check got with placeholders and operators: true
+	// check got with children in another order: false
+	// check got with children in any order: true
+	// check XML representation of a struct: true
+}
+
+func ExampleCmpYAML() {
+	t := &testing.T{}
+
+	got := &struct {
+		Fullname string   `json:"fullname"`
+		Age      int      `json:"age"`
+		Details  string   `json:"details"`
+		Tags     []string `json:"tags"`
+	}{
+		Fullname: "Bob Foobar",
+		Age:      42,
+		Details:  "$info",
+		Tags:     []string{"admin", "staff"},
+	}
+
+	ok := td.CmpYAML(t, got, `
+# A person
+fullname: $^HasPrefix("Bob")
+age: $1
+details: $$info # literally "$info", thanks to "$" escape
+tags: [admin, staff]
+`, []any{td.Between(40, 45)})
+	fmt.Println("check got with YAML:", ok)
+
+	ok = td.CmpYAML(t, got, `
+fullname: Bob Foobar
+age: 42
+details: $$info
+tags:
+  - $^Re("^a")
+`, nil)
+	fmt.Println("check got with only one tag:", ok)
+
+	// Output:
+	// check got with YAML: true
+	// check got with only one tag: false
+}
+
 func ExampleCmpZero() {
 	t := &testing.T{}
 
@@ -614,6 +614,29 @@ func ExampleCode_custom() {
 	// with assert & require *td.T: true
 }
 
+func ExampleConsistently() {
+	t := &testing.T{}
+
+	calls := 0
+	getter := func() int {
+		calls++
+		return calls
+	}
+
+	ok := td.Cmp(t, getter,
+		td.Consistently(td.Lt(100), 20*time.Millisecond, 5*time.Millisecond))
+	fmt.Println("always < 100 during 20ms:", ok)
+
+	calls = 0
+	ok = td.Cmp(t, getter,
+		td.Consistently(td.Lt(3), 20*time.Millisecond, 5*time.Millisecond))
+	fmt.Println("always < 3 during 20ms:", ok, "- stopped after", calls, "calls")
+
+	// Output:
+	// always < 100 during 20ms: true
+	// always < 3 during 20ms: false - stopped after 3 calls
+}
+
 func ExampleContains_arraySlice() {
 	t := &testing.T{}
 
@@ -936,6 +959,31 @@ func ExampleErrorIs() {
 	// err1 is err: false
 }
 
+func ExampleEventually() {
+	t := &testing.T{}
+
+	calls := 0
+	getter := func() string {
+		calls++
+		if calls < 3 {
+			return "running"
+		}
+		return "done"
+	}
+
+	ok := td.Cmp(t, getter,
+		td.Eventually("done", time.Second, 5*time.Millisecond))
+	fmt.Println("done eventually:", ok, "after", calls, "calls")
+
+	ok = td.Cmp(t, getter,
+		td.Eventually(td.HasPrefix("fail"), 20*time.Millisecond, 5*time.Millisecond))
+	fmt.Println("failed eventually:", ok)
+
+	// Output:
+	// done eventually: true after 3 calls
+	// failed eventually: false
+}
+
 func ExampleFirst_classic() {
 	t := &testing.T{}
 
@@ -1614,6 +1662,50 @@ func ExampleJSON_file() {
 	// Full match from io.Reader: true
 }
 
+func ExampleJSONPath() {
+	t := &testing.T{}
+
+	got := json.RawMessage(`
+{
+  "store": {
+    "books": [
+      {"title": "Sayings of the Century", "price": 8.95},
+      {"title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+      {"title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+    ],
+    "bicycle": {"color": "red", "price": 399}
+  }
+}`)
+
+	ok := td.Cmp(t, got, td.JSONPath("$.store.books[*].title", []string{
+		"Sayings of the Century",
+		"Moby Dick",
+		"The Lord of the Rings",
+	}))
+	fmt.Println("all titles:", ok)
+
+	ok = td.Cmp(t, got, td.JSONPath("$..price", td.Len(4)))
+	fmt.Println("4 prices in the store:", ok)
+
+	ok = td.Cmp(t, got, td.JSONPath("$.store.books[?@.isbn && @.price < 10].title",
+		[]string{"Moby Dick"}))
+	fmt.Println("books with an ISBN and cheaper than 10:", ok)
+
+	ok = td.Cmp(t, got, td.JSONPath("$.store.books[-1:]", td.ArrayEach(
+		td.SuperMapOf(map[string]any{"price": td.Gt(20)}, nil))))
+	fmt.Println("last book is expensive:", ok)
+
+	ok = td.Cmp(t, got, td.JSONPath("$.store.books[?@.price > 100]", td.Empty()))
+	fmt.Println("no book costs more than 100:", ok)
+
+	// Output:
+	// all titles: true
+	// 4 prices in the store: true
+	// books with an ISBN and cheaper than 10: true
+	// last book is expensive: true
+	// no book costs more than 100: true
+}
+
 func ExampleJSONPointer_rfc6901() {
 	t := &testing.T{}
 
@@ -1798,6 +1890,59 @@ func ExampleJSONPointer_has_hasnt() {
 	// Britt hasn't children: false
 }
 
+func ExampleJSONSchema() {
+	t := &testing.T{}
+
+	type Person struct {
+		Name     string    `json:"name"`
+		Age      int       `json:"age"`
+		Children []*Person `json:"children,omitempty"`
+	}
+
+	schema := `
+{
+  "$defs": {
+    "person": {
+      "type": "object",
+      "required": ["name", "age"],
+      "properties": {
+        "name":     {"type": "string", "minLength": 1},
+        "age":      {"type": "integer", "minimum": 0, "maximum": 150},
+        "children": {"type": "array", "items": {"$ref": "#/$defs/person"}}
+      }
+    }
+  },
+  "$ref": "#/$defs/person"
+}`
+
+	got := Person{
+		Name:     "Bob",
+		Age:      42,
+		Children: []*Person{{Name: "Alice", Age: 16}},
+	}
+	ok := td.Cmp(t, got, td.JSONSchema(schema))
+	fmt.Println("Bob fits the schema:", ok)
+
+	got.Children[0].Age = 160
+	ok = td.Cmp(t, got, td.JSONSchema(schema))
+	fmt.Println("Bob with a 160 years old child fits the schema:", ok)
+
+	// JSONSchema can also be embedded in JSON
+	got.Children[0].Age = 16
+	ok = td.Cmp(t, got, td.JSON(`
+{
+  "name":     "Bob",
+  "age":      42,
+  "children": JSONSchema({"type": "array", "minItems": 1})
+}`))
+	fmt.Println("Bob has children:", ok)
+
+	// Output:
+	// Bob fits the schema: true
+	// Bob with a 160 years old child fits the schema: false
+	// Bob has children: true
+}
+
 func ExampleList() {
 	t := &testing.T{}
 
@@ -2619,6 +2764,61 @@ func ExampleNotZero() {
 	// false
 }
 
+func ExamplePartial() {
+	t := &testing.T{}
+
+	type Address struct {
+		City    string
+		Country string
+	}
+	type Person struct {
+		Name      string
+		Age       int
+		Addresses []Address
+		Tags      map[string]string
+	}
+
+	got := Person{
+		Name: "Foobar",
+		Age:  42,
+		Addresses: []Address{
+			{City: "Paris", Country: "France"},
+			{City: "London", Country: "UK"},
+		},
+		Tags: map[string]string{"team": "blue", "role": "dev"},
+	}
+
+	// Zero fields are ignored, at any depth
+	ok := td.Cmp(t, got,
+		td.Partial(Person{
+			Name:      "Foobar",
+			Addresses: []Address{{}, {Country: "UK"}},
+			Tags:      map[string]string{"team": "blue"},
+		}),
+		"checks %v is the right Person")
+	fmt.Println("Foobar lives in UK and is in blue team:", ok)
+
+	// StructFields can still be used
+	ok = td.Cmp(t, got,
+		td.Partial(Person{Addresses: []Address{{City: "Paris"}, {}}},
+			td.StructFields{
+				"Age": td.Between(40, 50),
+			}),
+		"checks %v is the right Person")
+	fmt.Println("Foobar is between 40 & 50 and lives in Paris:", ok)
+
+	// Slices lengths are still checked
+	ok = td.Cmp(t, got,
+		td.Partial(Person{Addresses: []Address{{City: "Paris"}}}),
+		"checks %v is the right Person")
+	fmt.Println("Foobar has only one address:", ok)
+
+	// Output:
+	// Foobar lives in UK and is in blue team: true
+	// Foobar is between 40 & 50 and lives in Paris: true
+	// Foobar has only one address: false
+}
+
 func ExamplePPtr() {
 	t := &testing.T{}
 
@@ -4611,6 +4811,53 @@ func ExampleSubSetOf() {
 	// true
 }
 
+func ExampleSubXMLOf() {
+	t := &testing.T{}
+
+	got := `<user id="42"><name>Bob</name></user>`
+
+	ok := td.Cmp(t, got,
+		td.SubXMLOf(`<user id="$1" admin="true"><name>Bob</name><age>42</age></user>`,
+			td.NotZero()))
+	fmt.Println("check got is a subset of expected:", ok)
+
+	ok = td.Cmp(t, got, td.SubXMLOf(`<user><name>Bob</name><age>42</age></user>`))
+	fmt.Println("check got without id attribute expected:", ok)
+
+	// Output:
+	// check got is a subset of expected: true
+	// check got without id attribute expected: false
+}
+
+func ExampleSubYAMLOf() {
+	t := &testing.T{}
+
+	got := &struct {
+		Fullname string `json:"fullname"`
+		Age      int    `json:"age"`
+	}{
+		Fullname: "Bob",
+		Age:      42,
+	}
+
+	ok := td.Cmp(t, got, td.SubYAMLOf(`
+fullname: Bob
+age: $^Between(40, 45)
+gender: male # missing from got, but allowed
+`))
+	fmt.Println("check got with gender field:", ok)
+
+	ok = td.Cmp(t, got, td.SubYAMLOf(`
+fullname: Bob
+gender: male
+`))
+	fmt.Println("check got without age field:", ok)
+
+	// Output:
+	// check got with gender field: true
+	// check got without age field: false
+}
+
 func ExampleSuperBagOf() {
 	t := &testing.T{}
 
@@ -4861,6 +5108,69 @@ func ExampleSuperSetOf() {
 	// true
 }
 
+func ExampleSuperXMLOf() {
+	t := &testing.T{}
+
+	got := `<users>
+  <user id="1" admin="true"><name>Bob</name><age>42</age></user>
+  <user id="2"><name>Alice</name><age>37</age></user>
+</users>`
+
+	ok := td.Cmp(t, got, td.SuperXMLOf(`<users><user id="2"/></users>`))
+	fmt.Println("check user #2 is present:", ok)
+
+	ok = td.Cmp(t, got,
+		td.SuperXMLOf(`<users><user id="2"/><user admin="$1"/></users>`,
+			true))
+	fmt.Println("check an admin follows user #2:", ok)
+
+	ok = td.Cmp(t, got,
+		td.SuperXMLOf(`<users><user id="2"/><user admin="$1"/></users>`,
+			td.XMLUnordered,
+			true))
+	fmt.Println("check user #2 and an admin are present:", ok)
+
+	// Output:
+	// check user #2 is present: true
+	// check an admin follows user #2: false
+	// check user #2 and an admin are present: true
+}
+
+func ExampleSuperYAMLOf() {
+	t := &testing.T{}
+
+	got := &struct {
+		Fullname string   `json:"fullname"`
+		Age      int      `json:"age"`
+		Gender   string   `json:"gender"`
+		Children []string `json:"children"`
+	}{
+		Fullname: "Bob",
+		Age:      42,
+		Gender:   "male",
+		Children: []string{"Alice", "Brian"},
+	}
+
+	ok := td.Cmp(t, got, td.SuperYAMLOf(`
+fullname: $name
+age: $2
+children:
+  - Alice
+  - $^HasPrefix("Br")
+`, td.Tag("name", td.HasPrefix("Bo")), td.Between(40, 45)))
+	fmt.Println("check got with placeholders:", ok)
+
+	ok = td.Cmp(t, got, td.SuperYAMLOf(`
+fullname: Bob
+details: {}
+`))
+	fmt.Println("check got with details field:", ok)
+
+	// Output:
+	// check got with placeholders: true
+	// check got with details field: false
+}
+
 func ExampleTruncTime() {
 	t := &testing.T{}
 
@@ -4926,6 +5236,100 @@ func ExampleValues() {
 	// Each value is between 1 and 3: true
 }
 
+func ExampleXML() {
+	t := &testing.T{}
+
+	got := `<?xml version="1.0" encoding="UTF-8"?>
+<user xmlns="urn:example:users" id="42">
+  <name>Bob</name>
+  <age>42</age>
+</user>`
+
+	ok := td.Cmp(t, got, td.XML(`
+<u:user xmlns:u="urn:example:users" id="42">
+  <u:name>Bob</u:name>
+  <u:age>42</u:age>
+</u:user>`))
+	fmt.Println("check got, namespace prefix does not matter:", ok)
+
+	ok = td.Cmp(t, got, td.XML(`
+<user xmlns="urn:example:users" id="$1">
+  <name>$name</name>
+  <age>$^Between(40, 45)</age>
+</user>`,
+		td.NotZero(),
+		td.Tag("name", td.HasPrefix("Bo"))))
+	fmt.Println("check got with placeholders and operators:", ok)
+
+	ok = td.Cmp(t, got, td.XML(`
+<user xmlns="urn:example:users" id="42">
+  <age>42</age>
+  <name>Bob</name>
+</user>`))
+	fmt.Println("check got with children in another order:", ok)
+
+	ok = td.Cmp(t, got, td.XML(`
+<user xmlns="urn:example:users" id="42">
+  <age>42</age>
+  <name>Bob</name>
+</user>`,
+		td.XMLUnordered))
+	fmt.Println("check got with children in any order:", ok)
+
+	type user struct { // element name is the type name
+		ID   int    `xml:"id,attr"`
+		Name string `xml:"name"`
+	}
+	ok = td.Cmp(t, user{ID: 42, Name: "Bob"},
+		td.XML(`<user id="42"><name>Bob</name></user>`))
+	fmt.Println("check XML representation of a struct:", ok)
+
+	// Output:
+	// check got, namespace prefix does not matter: true
+	// check got with placeholders and operators: true
+	// check got with children in another order: false
+	// check got with children in any order: true
+	// check XML representation of a struct: true
+}
+
+func ExampleYAML() {
+	t := &testing.T{}
+
+	got := &struct {
+		Fullname string   `json:"fullname"`
+		Age      int      `json:"age"`
+		Details  string   `json:"details"`
+		Tags     []string `json:"tags"`
+	}{
+		Fullname: "Bob Foobar",
+		Age:      42,
+		Details:  "$info",
+		Tags:     []string{"admin", "staff"},
+	}
+
+	ok := td.Cmp(t, got, td.YAML(`
+# A person
+fullname: $^HasPrefix("Bob")
+age: $1
+details: $$info # literally "$info", thanks to "$" escape
+tags: [admin, staff]
+`, td.Between(40, 45)))
+	fmt.Println("check got with YAML:", ok)
+
+	ok = td.Cmp(t, got, td.YAML(`
+fullname: Bob Foobar
+age: 42
+details: $$info
+tags:
+  - $^Re("^a")
+`))
+	fmt.Println("check got with only one tag:", ok)
+
+	// Output:
+	// check got with YAML: true
+	// check got with only one tag: false
+}
+
 func ExampleZero() {
 	t := &testing.T{}
 
@@ -0,0 +1,158 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"reflect"
+
+	"github.com/maxatome/go-testdeep/internal/types"
+)
+
+// Range-over-func iterators (iter.Seq & iter.Seq2 appeared in
+// go1.23) are detected using their signatures only, so this file
+// does not need any build tag and any func type with the same
+// signature is handled the same way.
+
+type seqKind uint8
+
+const (
+	noSeq seqKind = iota
+	seq1          // func(yield func(V) bool)
+	seq2          // func(yield func(K, V) bool)
+)
+
+var trueValue = reflect.ValueOf(true)
+
+// getSeqKind returns the kind of iterator typ is, or noSeq if typ
+// is not an iterator.
+func getSeqKind(typ reflect.Type) seqKind {
+	if typ.Kind() != reflect.Func ||
+		typ.NumIn() != 1 || typ.NumOut() != 0 || typ.IsVariadic() {
+		return noSeq
+	}
+
+	yield := typ.In(0)
+	if yield.Kind() != reflect.Func ||
+		yield.NumOut() != 1 || yield.Out(0) != types.Bool || yield.IsVariadic() {
+		return noSeq
+	}
+
+	switch yield.NumIn() {
+	case 1:
+		return seq1
+	case 2:
+		return seq2
+	}
+	return noSeq
+}
+
+// isSeq returns the kind of iterator got is, or noSeq if got is not
+// an iterator.
+func isSeq(got reflect.Value) seqKind {
+	if got.Kind() != reflect.Func {
+		return noSeq
+	}
+	return getSeqKind(got.Type())
+}
+
+// seqEach calls fn for each value yielded by the iterator seq,
+// until fn returns false. For a [seq1] iterator, k is always
+// invalid. It is the caller responsibility to check that seq is an
+// iterator. A nil iterator is handled as an empty one.
+func seqEach(seq reflect.Value, fn func(k, v reflect.Value) bool) {
+	if seq.IsNil() {
+		return
+	}
+
+	yieldType := seq.Type().In(0)
+	ret := []reflect.Value{trueValue}
+	retStop := []reflect.Value{reflect.ValueOf(false)}
+
+	var yield func([]reflect.Value) []reflect.Value
+	if yieldType.NumIn() == 1 {
+		yield = func(args []reflect.Value) []reflect.Value {
+			if fn(reflect.Value{}, args[0]) {
+				return ret
+			}
+			return retStop
+		}
+	} else {
+		yield = func(args []reflect.Value) []reflect.Value {
+			if fn(args[0], args[1]) {
+				return ret
+			}
+			return retStop
+		}
+	}
+
+	seq.Call([]reflect.Value{reflect.MakeFunc(yieldType, yield)})
+}
+
+// seqLen returns the number of items yielded by the iterator seq.
+func seqLen(seq reflect.Value) int {
+	n := 0
+	seqEach(seq, func(_, _ reflect.Value) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// seqIsEmpty returns true if the iterator seq does not yield any item.
+func seqIsEmpty(seq reflect.Value) bool {
+	empty := true
+	seqEach(seq, func(_, _ reflect.Value) bool {
+		empty = false
+		return false
+	})
+	return empty
+}
+
+// seqValues returns a new slice containing all the values yielded by
+// the iterator seq, in iteration order. For a [seq2] iterator, the
+// keys are ignored. A nil iterator returns a nil slice.
+func seqValues(seq reflect.Value) reflect.Value {
+	yieldType := seq.Type().In(0)
+	sliceType := reflect.SliceOf(yieldType.In(yieldType.NumIn() - 1))
+	if seq.IsNil() {
+		return reflect.Zero(sliceType)
+	}
+
+	out := reflect.MakeSlice(sliceType, 0, 0)
+	seqEach(seq, func(_, v reflect.Value) bool {
+		out = reflect.Append(out, v)
+		return true
+	})
+	return out
+}
+
+// seqKeys returns a new slice containing all the keys yielded by the
+// [seq2] iterator seq, in iteration order, duplicates included. A nil
+// iterator returns a nil slice.
+func seqKeys(seq reflect.Value) reflect.Value {
+	sliceType := reflect.SliceOf(seq.Type().In(0).In(0))
+	if seq.IsNil() {
+		return reflect.Zero(sliceType)
+	}
+
+	out := reflect.MakeSlice(sliceType, 0, 0)
+	seqEach(seq, func(k, _ reflect.Value) bool {
+		out = reflect.Append(out, k)
+		return true
+	})
+	return out
+}
+
+// resolveSeq replaces got by the slice of values it yields, if got is
+// a [seq1] iterator. So operators handling slices also handle
+// iterators, and the index of an item in the slice is its iteration
+// position.
+func resolveSeq(got *reflect.Value) {
+	if isSeq(*got) == seq1 {
+		*got = seqValues(*got)
+	}
+}
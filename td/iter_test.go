@@ -0,0 +1,249 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// Same signatures as iter.Seq[int] & iter.Seq2[string, int], without
+// requiring go1.23.
+type (
+	intSeq     func(yield func(int) bool)
+	strIntSeq2 func(yield func(string, int) bool)
+	strIntPair struct {
+		k string
+		v int
+	}
+)
+
+func seqOf(items ...int) intSeq {
+	return func(yield func(int) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func seq2Of(pairs ...strIntPair) strIntSeq2 {
+	return func(yield func(string, int) bool) {
+		for _, p := range pairs {
+			if !yield(p.k, p.v) {
+				return
+			}
+		}
+	}
+}
+
+func TestIterSeq(t *testing.T) {
+	got := seqOf(3, 1, 2)
+
+	checkOK(t, got, td.Len(3))
+	checkOK(t, intSeq(nil), td.Len(0))
+	checkOK(t, got, td.NotEmpty())
+	checkOK(t, seqOf(), td.Empty())
+	checkOK(t, intSeq(nil), td.Empty())
+	checkOK(t, got, td.Bag(1, 2, 3))
+	checkOK(t, got, td.SubBagOf(1, 2, 3, 4))
+	checkOK(t, got, td.SuperBagOf(2))
+	checkOK(t, got, td.Set(1, 2, 3))
+	checkOK(t, got, td.SubSetOf(1, 2, 3, 4))
+	checkOK(t, got, td.SuperSetOf(1, 3))
+	checkOK(t, got, td.NotAny(4, 5))
+	checkOK(t, got, td.List(3, 1, 2))
+	checkOK(t, got, td.ArrayEach(td.Between(1, 3)))
+	checkOK(t, got, td.Grep(td.Gt(1), []int{3, 2}))
+	checkOK(t, got, td.First(td.Lt(3), 1))
+	checkOK(t, got, td.Last(td.Gt(1), 2))
+	checkOK(t, got, td.Sort(1, []int{1, 2, 3}))
+	checkOK(t, seqOf(1, 2, 3), td.Sorted())
+	checkOK(t, got, td.Contains(2))
+	checkOK(t, []intSeq{got}, td.ArrayEach(td.Len(3)))
+
+	checkError(t, got, td.Len(2),
+		expectedError{
+			Message:  mustBe("bad length"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("3"),
+			Expected: mustBe("2"),
+		})
+
+	checkError(t, got, td.Empty(),
+		expectedError{
+			Message:  mustBe("not empty"),
+			Path:     mustBe("DATA"),
+			Expected: mustBe("empty"),
+		})
+
+	checkError(t, seqOf(), td.NotEmpty(),
+		expectedError{
+			Message:  mustBe("empty"),
+			Path:     mustBe("DATA"),
+			Expected: mustBe("not empty"),
+		})
+
+	checkError(t, got, td.List(3, 1, 4),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA[2]"),
+			Got:      mustBe("2"),
+			Expected: mustBe("4"),
+		})
+
+	checkError(t, got, td.ArrayEach(td.Gt(1)),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("1"),
+			Expected: mustBe("> 1"),
+		})
+
+	checkError(t, got, td.Bag(1, 2, 4),
+		expectedError{
+			Message: mustBe("comparing %% as a Bag"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Missing item: (4)\n  Extra item: (3)"),
+		})
+
+	checkError(t, got, td.Sorted(),
+		expectedError{
+			Message: mustBe("not sorted, item #1 value is before #0 one while it should not"),
+			Path:    mustBe("DATA"),
+		})
+
+	checkError(t, got, td.Contains(4),
+		expectedError{
+			Message:  mustBe("does not contain"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("([]int) (len=3) {\n (int) 3,\n (int) 1,\n (int) 2\n}"),
+			Expected: mustBe("Contains(4)"),
+		})
+
+	// Not an iterator
+	checkError(t, func(int) {}, td.Len(1),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("func (func(int) type)"),
+			Expected: mustBe("array OR chan OR map OR slice OR string OR iter.Seq OR iter.Seq2"),
+		})
+
+	checkError(t, func(yield func(int) int) {}, td.Empty(),
+		expectedError{
+			Message: mustBe("bad kind"),
+			Path:    mustBe("DATA"),
+		})
+
+	checkError(t, func(int) {}, td.Bag(1),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("func (func(int) type)"),
+			Expected: mustBe("slice OR array OR *slice OR *array"),
+		})
+
+	// A Seq2 is not a slice
+	checkError(t, seq2Of(strIntPair{"a", 1}), td.List(1),
+		expectedError{
+			Message: mustBe("bad kind"),
+			Path:    mustBe("DATA"),
+		})
+
+	// Iteration stops as soon as possible
+	calls := 0
+	stopped := intSeq(func(yield func(int) bool) {
+		for i := 0; i < 10; i++ {
+			calls++
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	test.IsTrue(t, td.EqDeeply(stopped, td.NotEmpty()))
+	test.EqualInt(t, calls, 1)
+}
+
+func TestIterSeq2(t *testing.T) {
+	got := seq2Of(
+		strIntPair{"b", 2},
+		strIntPair{"a", 1},
+		strIntPair{"b", 3}, // duplicated key
+	)
+
+	checkOK(t, got, td.Len(3))
+	checkOK(t, got, td.NotEmpty())
+	checkOK(t, strIntSeq2(nil), td.Empty())
+	checkOK(t, got, td.Keys([]string{"b", "a", "b"}))
+	checkOK(t, got, td.Keys(td.Bag("a", "b", "b")))
+	checkOK(t, got, td.Values([]int{2, 1, 3}))
+	checkOK(t, got, td.MapEach(td.Between(1, 3)))
+	checkOK(t, got, td.ContainsKey("a"))
+	checkOK(t, got, td.ContainsKey(td.HasPrefix("b")))
+	checkOK(t, got, td.Contains(3))
+	checkOK(t, strIntSeq2(nil), td.Keys(td.Empty()))
+
+	checkError(t, got, td.Keys([]string{"b", "a", "c"}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("keys(DATA)[2]"),
+			Got:      mustBe(`"b"`),
+			Expected: mustBe(`"c"`),
+		})
+
+	checkError(t, got, td.Values(td.List(2, 1, 4)),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("values(DATA)[2]"),
+			Got:      mustBe("3"),
+			Expected: mustBe("4"),
+		})
+
+	checkError(t, got, td.MapEach(td.Lt(3)),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe(`DATA["b"]`),
+			Got:      mustBe("3"),
+			Expected: mustBe("< 3"),
+		})
+
+	checkError(t, got, td.ContainsKey("c"),
+		expectedError{
+			Message: mustBe("does not contain key"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`expected key: "c"
+ not in keys: ([]string) (len=3) {
+               (string) (len=1) "b",
+               (string) (len=1) "a",
+               (string) (len=1) "b"
+              }`),
+		})
+
+	checkError(t, got, td.Contains(4),
+		expectedError{
+			Message:  mustBe("does not contain"),
+			Path:     mustBe("DATA"),
+			Expected: mustBe("Contains(4)"),
+		})
+
+	// A Seq is not a map
+	checkError(t, seqOf(1), td.Keys([]string{}),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Expected: mustBe("map"),
+		})
+	checkError(t, seqOf(1), td.ContainsKey(0),
+		expectedError{
+			Message: mustBe("cannot check contains key"),
+			Path:    mustBe("DATA"),
+		})
+}
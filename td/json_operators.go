@@ -0,0 +1,97 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/maxatome/go-testdeep/internal/color"
+)
+
+// jsonOperators contains the operators registered using
+// RegisterJSONOperator.
+var jsonOperators struct {
+	sync.RWMutex
+	m map[string]reflect.Value
+}
+
+// RegisterJSONOperator registers constructor as the operator name, so
+// it can be used in [JSON], [SubJSONOf] and [SuperJSONOf] expected
+// JSON, as any built-in operator:
+//
+//	td.RegisterJSONOperator("IsValidULID", IsValidULID)
+//	td.RegisterJSONOperator("MoneyEq", MoneyEq)
+//
+//	td.Cmp(t, got, td.JSON(`{"id": IsValidULID(), "total": $^MoneyEq(12.5)}`))
+//
+// name must start with an uppercase letter followed by letters only,
+// as built-in operator names do. It cannot be the name of a built-in
+// operator, even one not usable in JSON. Registering twice the same
+// name replaces the previous constructor.
+//
+// constructor must be a function returning a [TestDeep] operator,
+// typically built using [Base]. When used in JSON, the number of
+// parameters is checked against the constructor signature, as well
+// as the type of each non-variadic one. Keep in mind that JSON
+// numbers are float64, strings are string, arrays are []any and
+// objects are map[string]any. So prefer any or these types for
+// constructor parameters.
+//
+// Bad usage of RegisterJSONOperator panics. It is typically called in
+// an init function.
+func RegisterJSONOperator(name string, constructor any) {
+	const usage = "RegisterJSONOperator(NAME, CONSTRUCTOR)"
+
+	if !isValidJSONOperatorName(name) {
+		panic(color.Bad("usage: %s, NAME must match ^[A-Z][a-zA-Z]*$ but received %q", usage, name))
+	}
+
+	if _, exists := allOperators[name]; exists {
+		panic(color.Bad("usage: %s, %s is a built-in operator and cannot be overridden", usage, name))
+	}
+
+	vfn := reflect.ValueOf(constructor)
+	if vfn.Kind() != reflect.Func ||
+		vfn.Type().NumOut() != 1 || vfn.Type().Out(0) != testDeeper {
+		panic(color.BadUsage(usage, constructor, 2, false))
+	}
+	if vfn.IsNil() {
+		panic(color.Bad("usage: %s, CONSTRUCTOR must be a non-nil function", usage))
+	}
+
+	jsonOperators.Lock()
+	defer jsonOperators.Unlock()
+
+	if jsonOperators.m == nil {
+		jsonOperators.m = map[string]reflect.Value{}
+	}
+	jsonOperators.m[name] = vfn
+}
+
+// lookupJSONOperator returns the constructor registered using
+// RegisterJSONOperator for the operator name and true, or false if
+// no such operator has been registered.
+func lookupJSONOperator(name string) (reflect.Value, bool) {
+	jsonOperators.RLock()
+	defer jsonOperators.RUnlock()
+
+	vfn, ok := jsonOperators.m[name]
+	return vfn, ok
+}
+
+func isValidJSONOperatorName(name string) bool {
+	if name == "" || name[0] < 'A' || name[0] > 'Z' {
+		return false
+	}
+	for _, r := range name[1:] {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// tdMultipleOf is an operator implemented outside td package and
+// usable in JSON once registered.
+type tdMultipleOf struct {
+	td.Base
+	n float64
+}
+
+func MultipleOf(n float64) td.TestDeep {
+	m := tdMultipleOf{Base: td.NewBase(0), n: n}
+	if n == 0 {
+		m.SetBad("MultipleOf(0) is a non-sense")
+	}
+	return &m
+}
+
+func (m *tdMultipleOf) Match(ctx td.Context, got reflect.Value) *td.Error {
+	if err := m.UsageError(); err != nil {
+		return ctx.CollectError(err)
+	}
+	if got.Kind() != reflect.Float64 {
+		return ctx.CollectError(td.BadKindError(got, "float64"))
+	}
+	if math.Mod(got.Float(), m.n) != 0 {
+		return ctx.CollectError(&td.Error{
+			Message:  "not a multiple",
+			Got:      got,
+			Expected: m,
+		})
+	}
+	return nil
+}
+
+func (m *tdMultipleOf) String() string {
+	if m.UsageError() != nil {
+		return m.StringError()
+	}
+	return "MultipleOf(" + td.S(m.n) + ")"
+}
+
+func TestRegisterJSONOperator(t *testing.T) {
+	td.RegisterJSONOperator("MultipleOf", MultipleOf)
+	td.RegisterJSONOperator("AllItems", AllItems)
+	td.RegisterJSONOperator("NilOp", func() td.TestDeep { return nil })
+
+	type Item struct {
+		Qty   int   `json:"qty"`
+		Sizes []int `json:"sizes"`
+	}
+
+	got := Item{Qty: 12, Sizes: []int{4, 8}}
+
+	checkOK(t, got, td.JSON(`{"qty": MultipleOf(3), "sizes": AllItems($1)}`,
+		MultipleOf(4)))
+	checkOK(t, got, td.JSON(`{"qty": "$^MultipleOf(6)", "sizes": [4, 8]}`))
+	checkOK(t, got, td.SuperJSONOf(`{"qty": MultipleOf(2)}`))
+	checkOK(t, got, td.SubJSONOf(`{"qty": MultipleOf(2), "sizes": Len(2), "x": 1}`))
+
+	checkError(t, got, td.JSON(`{"qty": MultipleOf(5), "sizes": Ignore()}`),
+		expectedError{
+			Message:  mustBe("not a multiple"),
+			Path:     mustBe(`DATA["qty"]`),
+			Got:      mustBe("12.0"),
+			Expected: mustBe("MultipleOf(5)"),
+			Under:    mustContain("under operator MultipleOf at line 1:8 (pos 8) inside operator JSON at json_operators_test.go:"),
+		})
+
+	checkError(t, got, td.JSON(`{"qty": 12, "sizes": AllItems(MultipleOf(8))}`),
+		expectedError{
+			Message:  mustBe("not a multiple"),
+			Path:     mustBe(`DATA["sizes"][0]`),
+			Got:      mustBe("4.0"),
+			Expected: mustBe("MultipleOf(8)"),
+			Under:    mustContain("under operator MultipleOf at line 1:30 (pos 30) inside operator JSON at json_operators_test.go:"),
+		})
+
+	// Erroneous operator
+	_checkError(t, got, td.JSON(`{"qty": MultipleOf(0), "sizes": Ignore()}`),
+		expectedError{
+			Message: mustBe("bad usage of MultipleOf operator"),
+			Path:    mustBe(`DATA["qty"]`),
+			Summary: mustBe("MultipleOf(0) is a non-sense"),
+			Under:   mustContain("under operator MultipleOf at line 1:8 (pos 8) inside operator JSON at json_operators_test.go:"),
+		})
+
+	//
+	// Errors
+	checkError(t, "never tested",
+		td.JSON(`[ MultipleOf() ]`),
+		expectedError{
+			Message: mustBe("bad usage of JSON operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`JSON unmarshal error: MultipleOf() requires only one parameter at line 1:2 (pos 2)`),
+			Under:   mustContain("under operator JSON at json_operators_test.go:"),
+		})
+
+	checkError(t, "never tested",
+		td.JSON(`[ MultipleOf("3") ]`),
+		expectedError{
+			Message: mustBe("bad usage of JSON operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`JSON unmarshal error: MultipleOf() bad #1 parameter type: float64 required but string received at line 1:2 (pos 2)`),
+			Under:   mustContain("under operator JSON at json_operators_test.go:"),
+		})
+
+	checkError(t, "never tested",
+		td.JSON(`[ NilOp() ]`),
+		expectedError{
+			Message: mustBe("bad usage of JSON operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`JSON unmarshal error: NilOp() returned a nil operator at line 1:2 (pos 2)`),
+			Under:   mustContain("under operator JSON at json_operators_test.go:"),
+		})
+
+	checkError(t, "never tested",
+		td.JSON(`[ NotRegistered() ]`),
+		expectedError{
+			Message: mustBe("bad usage of JSON operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`JSON unmarshal error: unknown operator NotRegistered() at line 1:2 (pos 2)`),
+			Under:   mustContain("under operator JSON at json_operators_test.go:"),
+		})
+
+	// Registering again replaces the previous constructor
+	td.RegisterJSONOperator("NilOp", func() td.TestDeep { return td.Empty() })
+	checkOK(t, []int{}, td.JSON(`NilOp()`))
+
+	//
+	// Bad usages
+	test.CheckPanic(t, func() { td.RegisterJSONOperator("", MultipleOf) },
+		`usage: RegisterJSONOperator(NAME, CONSTRUCTOR), NAME must match ^[A-Z][a-zA-Z]*$ but received ""`)
+	test.CheckPanic(t, func() { td.RegisterJSONOperator("multipleOf", MultipleOf) },
+		`NAME must match ^[A-Z][a-zA-Z]*$ but received "multipleOf"`)
+	test.CheckPanic(t, func() { td.RegisterJSONOperator("Multiple_Of", MultipleOf) },
+		`NAME must match ^[A-Z][a-zA-Z]*$ but received "Multiple_Of"`)
+	test.CheckPanic(t, func() { td.RegisterJSONOperator("Len", MultipleOf) },
+		"usage: RegisterJSONOperator(NAME, CONSTRUCTOR), Len is a built-in operator and cannot be overridden")
+	test.CheckPanic(t, func() { td.RegisterJSONOperator("Struct", MultipleOf) },
+		"Struct is a built-in operator and cannot be overridden")
+	test.CheckPanic(t, func() { td.RegisterJSONOperator("Foo", 42) },
+		"usage: RegisterJSONOperator(NAME, CONSTRUCTOR), but received int as 2nd parameter")
+	test.CheckPanic(t, func() { td.RegisterJSONOperator("Foo", func() int { return 0 }) },
+		"usage: RegisterJSONOperator(NAME, CONSTRUCTOR), but received func() int as 2nd parameter")
+	test.CheckPanic(t, func() { td.RegisterJSONOperator("Foo", (func() td.TestDeep)(nil)) },
+		"usage: RegisterJSONOperator(NAME, CONSTRUCTOR), CONSTRUCTOR must be a non-nil function")
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+// MatcherAdapter allows to use any expected value, and so any
+// [TestDeep] operator, where a matcher is expected, as in mocking
+// libraries. It is returned by [Matcher].
+//
+// It structurally implements gomock.Matcher (Matches and String
+// methods) and gomock.GotFormatter (Got method) interfaces, without
+// depending on gomock.
+type MatcherAdapter struct {
+	expected any
+}
+
+// Matcher returns a [*MatcherAdapter] matching expected. expected
+// can be any value, including a [TestDeep] operator or a value
+// containing some.
+//
+// As [*MatcherAdapter] implements gomock.Matcher interface, it can
+// be used with gomock as in:
+//
+//	mock.EXPECT().
+//	  Create(td.Matcher(td.Struct(Person{}, td.StructFields{
+//	    "Name": td.HasPrefix("Bob"),
+//	    "Age":  td.Between(40, 45),
+//	  }))).
+//	  Return(nil)
+//
+// With testify mock package, use [MatchFunc] instead:
+//
+//	m.On("Create", mock.MatchedBy(td.MatchFunc(td.HasPrefix("Bob"))))
+//
+// See also [MatchFunc], [MatchErrorFunc] and [EqDeeply].
+func Matcher(expected any) *MatcherAdapter {
+	return &MatcherAdapter{expected: expected}
+}
+
+// Matches returns true if x matches the expected value of m. It uses
+// [EqDeeply] under the hood.
+func (m *MatcherAdapter) Matches(x any) bool {
+	return EqDeeply(x, m.expected)
+}
+
+// Check returns nil if x matches the expected value of m, the
+// [*Error] describing the first mismatch otherwise. It uses
+// [EqDeeplyError] under the hood.
+func (m *MatcherAdapter) Check(x any) error {
+	return EqDeeplyError(x, m.expected)
+}
+
+// String returns the expected value of m stringified, as gomock
+// displays it in its "Want:" section.
+func (m *MatcherAdapter) String() string {
+	return util.ToString(m.expected)
+}
+
+// Got returns x stringified followed by the reason why it does not
+// match the expected value of m, if any. gomock calls it to fill its
+// "Got:" section.
+func (m *MatcherAdapter) Got(x any) string {
+	s := util.ToString(x)
+	if err := m.Check(x); err != nil {
+		s += "\n" + err.Error()
+	}
+	return s
+}
+
+// MatchFunc returns a function returning true if its parameter
+// matches expected, false otherwise. expected can be any value,
+// including a [TestDeep] operator or a value containing some. It is
+// typically useful with testify mock.MatchedBy:
+//
+//	m.On("SetAge", mock.MatchedBy(td.MatchFunc(td.Between(40, 45))))
+//
+// See also [Matcher] and [MatchErrorFunc].
+func MatchFunc(expected any) func(any) bool {
+	return Matcher(expected).Matches
+}
+
+// MatchErrorFunc returns a function returning nil if its parameter
+// matches expected, the [*Error] describing the first mismatch
+// otherwise. expected can be any value, including a [TestDeep]
+// operator or a value containing some.
+//
+//	check := td.MatchErrorFunc(td.Between(40, 45))
+//	if err := check(age); err != nil {
+//	  log.Print(err)
+//	}
+//
+// See also [Matcher] and [MatchFunc].
+func MatchErrorFunc(expected any) func(any) error {
+	return Matcher(expected).Check
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// gomockMatcher mimics gomock.Matcher interface.
+type gomockMatcher interface {
+	Matches(x any) bool
+	String() string
+}
+
+// gomockGotFormatter mimics gomock.GotFormatter interface.
+type gomockGotFormatter interface {
+	Got(got any) string
+}
+
+func TestMatcher(t *testing.T) {
+	var m gomockMatcher = td.Matcher(td.Between(40, 45))
+	_, ok := m.(gomockGotFormatter)
+	test.IsTrue(t, ok, "implements gomock.GotFormatter")
+
+	test.IsTrue(t, m.Matches(42))
+	test.IsFalse(t, m.Matches(46))
+	test.IsFalse(t, m.Matches("42"))
+	test.EqualStr(t, m.String(), "40 ≤ got ≤ 45")
+
+	mm := td.Matcher(td.Between(40, 45))
+	test.NoError(t, mm.Check(42))
+	err := mm.Check(46)
+	if test.Error(t, err) {
+		test.IsTrue(t, strings.HasPrefix(err.Error(), "DATA: values differ\n"),
+			"got: %s", err)
+	}
+	test.EqualStr(t, mm.Got(42), "42")
+	got := mm.Got(46)
+	test.IsTrue(t, strings.HasPrefix(got, "46\nDATA: values differ\n"),
+		"got: %s", got)
+	test.IsTrue(t, strings.Contains(got, "expected: 40 ≤ got ≤ 45"),
+		"got: %s", got)
+
+	// Plain values
+	type Person struct {
+		Name string
+		Age  int
+	}
+	m = td.Matcher(Person{Name: "Bob", Age: 42})
+	test.IsTrue(t, m.Matches(Person{Name: "Bob", Age: 42}))
+	test.IsFalse(t, m.Matches(Person{Name: "Bob", Age: 43}))
+	test.IsFalse(t, m.Matches(nil))
+
+	m = td.Matcher(td.Struct(Person{}, td.StructFields{"Name": td.HasPrefix("Bo")}))
+	test.IsTrue(t, m.Matches(Person{Name: "Bob", Age: 42}))
+	test.IsFalse(t, m.Matches(&Person{Name: "Bob", Age: 42}))
+
+	test.EqualStr(t, td.Matcher(nil).String(), "nil")
+	test.EqualStr(t, td.Matcher("foo").String(), `"foo"`)
+}
+
+func TestMatchFunc(t *testing.T) {
+	fn := td.MatchFunc(td.HasPrefix("Bob"))
+	test.IsTrue(t, fn("Bobby"))
+	test.IsFalse(t, fn("Alice"))
+	test.IsFalse(t, fn(12))
+
+	efn := td.MatchErrorFunc(td.Smuggle("Age", td.Gt(40)))
+	test.NoError(t, efn(struct{ Age int }{Age: 42}))
+	err := efn(struct{ Age int }{Age: 12})
+	if test.Error(t, err) {
+		test.IsTrue(t, strings.HasPrefix(err.Error(), "DATA.Age: values differ\n"),
+			"got: %s", err)
+	}
+}
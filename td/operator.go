@@ -0,0 +1,253 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"reflect"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+)
+
+// Context is the comparison context received by the Match method of
+// any [TestDeep] operator. It keeps track of the current path in got
+// data and of the errors already encountered.
+//
+// An operator implemented outside td package uses its methods to
+// add a level to the path before comparing a sub-value:
+//
+//   - AddField(field string) for a struct field, as in DATA.Field;
+//   - AddArrayIndex(index int) for an array or slice item, as in DATA[1];
+//   - AddMapKey(key any) for a map entry, as in DATA["key"];
+//   - AddPtr(num int) for num pointer dereferences, as in *DATA;
+//   - AddFunctionCall(fn string) for a function call, as in len(DATA);
+//   - AddCustomLevel(custom string) for anything else, as in DATA<custom>.
+//
+// and its CollectError method to return any [*Error]:
+//
+//	return ctx.CollectError(&td.Error{
+//	  Message:  "values differ",
+//	  Got:      got,
+//	  Expected: expected,
+//	})
+//
+// CollectError takes care of the boolean context (see [EqDeeply]) as
+// well as of the MaxErrors setting of [ContextConfig].
+//
+// See [Base] for a complete example.
+type Context = ctxerr.Context
+
+// Error is the error returned by the Match method of any [TestDeep]
+// operator. An operator implemented outside td package fills its
+// Message field and either Got and Expected fields or Summary one,
+// then passes it to [Context] CollectError method.
+//
+// See [Base] for a complete example.
+type Error = ctxerr.Error
+
+// ErrorSummary is the interface used to render the Summary field of
+// [Error]. See [NewSummary], [NewSummaryReason], [ErrorSummaryItem]
+// and [ErrorSummaryItems].
+type ErrorSummary = ctxerr.ErrorSummary
+
+// ErrorSummaryItem implements [ErrorSummary] and allows to render a
+// labeled value, with an optional explanation:
+//
+//	Label: value
+//	Explanation
+type ErrorSummaryItem = ctxerr.ErrorSummaryItem
+
+// ErrorSummaryItems implements [ErrorSummary] and allows to render
+// several labeled values, aligned on their labels:
+//
+//	Missing 6 items: the 6 items...
+//	  Extra 2 items: the 2 items...
+type ErrorSummaryItems = ctxerr.ErrorSummaryItems
+
+// NewSummary returns an [ErrorSummary] composed by the simple string s.
+func NewSummary(s string) ErrorSummary {
+	return ctxerr.NewSummary(s)
+}
+
+// NewSummaryReason returns an [ErrorSummary] meaning that the value
+// got failed for an (optional) reason.
+//
+// With a given reason "it is not nil", the generated summary is:
+//
+//	        value: the_got_value
+//	it failed coz: it is not nil
+//
+// If reason is empty, the generated summary is:
+//
+//	  value: the_got_value
+//	it failed but didn't say why
+func NewSummaryReason(got any, reason string) ErrorSummary {
+	return ctxerr.NewSummaryReason(got, reason)
+}
+
+// BadKindError returns a “bad kind” [*Error], saying got kind does
+// not match kind(s) listed in okKinds. It is the caller
+// responsibility to check the kinds compatibility. got can be
+// invalid, in this case it is displayed as nil.
+//
+//	return ctx.CollectError(td.BadKindError(got, "slice OR array"))
+func BadKindError(got reflect.Value, okKinds string) *Error {
+	return ctxerr.BadKind(got, okKinds)
+}
+
+// TypeMismatchError returns a “type mismatch” [*Error]. It is the
+// caller responsibility to check that both types differ.
+//
+//	return ctx.CollectError(td.TypeMismatchError(got.Type(), expectedType))
+func TypeMismatchError(got, expected reflect.Type) *Error {
+	return ctxerr.TypeMismatch(got, expected)
+}
+
+// MatchDeeply compares got against expected using ctx, and returns
+// nil if it matches. expected can be the same type as got is, or
+// contains some [TestDeep] operators.
+//
+// It is the building block for operators implemented outside td
+// package needing to compare a sub-value. As ctx is kept, the path
+// and the errors already collected are kept too:
+//
+//	for i := 0; i < got.Len(); i++ {
+//	  err := td.MatchDeeply(ctx.AddArrayIndex(i), got.Index(i), o.expected)
+//	  if err != nil {
+//	    return err
+//	  }
+//	}
+func MatchDeeply(ctx Context, got reflect.Value, expected any) *Error {
+	return deepValueEqual(ctx, got, reflect.ValueOf(expected))
+}
+
+// Base is the base type to embed in operators implemented outside td
+// package. It provides all the methods required by [TestDeep]
+// interface, except Match and String ones. As a [TestDeep] operator
+// always has to be a pointer, Base must be embedded by value and
+// initialized using [NewBase].
+//
+// HandleInvalid and TypeBehind methods can be overridden if needed.
+//
+//	type tdULID struct {
+//	  td.Base
+//	}
+//
+//	func IsValidULID() td.TestDeep {
+//	  return &tdULID{Base: td.NewBase(0)}
+//	}
+//
+//	func (u *tdULID) Match(ctx td.Context, got reflect.Value) *td.Error {
+//	  if got.Kind() != reflect.String {
+//	    return ctx.CollectError(td.BadKindError(got, "string"))
+//	  }
+//	  if !ulidRe.MatchString(got.String()) {
+//	    return ctx.CollectError(&td.Error{
+//	      Message:  "invalid ULID",
+//	      Got:      got,
+//	      Expected: u,
+//	    })
+//	  }
+//	  return nil
+//	}
+//
+//	func (u *tdULID) String() string {
+//	  return "IsValidULID()"
+//	}
+//
+// See also [BaseOKNil].
+type Base struct {
+	base
+}
+
+// NewBase returns a new [Base] recording the location of the operator
+// creation. It has to be called by the operator constructor itself,
+// in this case callDepth is 0. If it is called from a function
+// itself called by the constructor, callDepth has to be 1, and so
+// on.
+func NewBase(callDepth int) (b Base) {
+	b.initLocation(3 + callDepth)
+	return
+}
+
+// SetBadUsage marks the operator as not operational as the user
+// passed a bad parameter to its constructor. usage describes the
+// constructor parameters, param is the bad parameter and pos its
+// position (starting at 1). If kind is true, the kind of param is
+// also displayed if it differs from its type name.
+//
+//	func MoneyEq(amount any) td.TestDeep {
+//	  m := tdMoney{Base: td.NewBase(0)}
+//	  if _, ok := amount.(Money); !ok {
+//	    m.SetBadUsage("(Money)", amount, 1, false)
+//	  }
+//	  return &m
+//	}
+//
+// produces:
+//
+//	usage: MoneyEq(Money), but received int as 1st parameter
+//
+// The Match method should then return [Base.UsageError] result.
+func (t *Base) SetBadUsage(usage string, param any, pos int, kind bool) {
+	t.err = ctxerr.OpBadUsage(t.location.Func, usage, param, pos, kind)
+}
+
+// SetBad marks the operator as not operational as the user
+// misused its constructor. If len(args) > 0, s and args are given
+// to [fmt.Sprintf] to describe the problem.
+//
+// The Match method should then return [Base.UsageError] result.
+func (t *Base) SetBad(s string, args ...any) {
+	t.err = ctxerr.OpBad(t.location.Func, s, args...)
+}
+
+// UsageError returns the [*Error] set by [Base.SetBadUsage] or
+// [Base.SetBad], nil if the operator is operational. It is typically
+// used at the beginning of the Match method:
+//
+//	func (m *tdMoney) Match(ctx td.Context, got reflect.Value) *td.Error {
+//	  if err := m.UsageError(); err != nil {
+//	    return ctx.CollectError(err)
+//	  }
+//	  …
+//	}
+func (t *Base) UsageError() *Error {
+	return t.err
+}
+
+// StringError returns the string to use in String method when the
+// operator is not operational.
+//
+//	func (m *tdMoney) String() string {
+//	  if m.UsageError() != nil {
+//	    return m.StringError()
+//	  }
+//	  …
+//	}
+func (t *Base) StringError() string {
+	return t.stringError()
+}
+
+// BaseOKNil is the same as [Base] except that the operator handles
+// untyped nil values directly, so its Match method can receive an
+// invalid [reflect.Value]. Use [NewBaseOKNil] to initialize it.
+type BaseOKNil struct {
+	Base
+}
+
+// NewBaseOKNil returns a new [BaseOKNil]. See [NewBase] for callDepth
+// meaning.
+func NewBaseOKNil(callDepth int) (b BaseOKNil) {
+	b.initLocation(3 + callDepth)
+	return
+}
+
+// HandleInvalid tells go-testdeep internals that this operator
+// handles nil values directly.
+func (t BaseOKNil) HandleInvalid() bool {
+	return true
+}
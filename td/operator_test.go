@@ -0,0 +1,188 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// tdEven is an operator implemented outside td package.
+type tdEven struct {
+	td.Base
+}
+
+func Even() td.TestDeep {
+	return &tdEven{Base: td.NewBase(0)}
+}
+
+func (e *tdEven) Match(ctx td.Context, got reflect.Value) *td.Error {
+	if got.Kind() != reflect.Int {
+		return ctx.CollectError(td.BadKindError(got, "int"))
+	}
+	if got.Int()%2 != 0 {
+		return ctx.CollectError(&td.Error{
+			Message:  "odd number",
+			Got:      got,
+			Expected: e,
+		})
+	}
+	return nil
+}
+
+func (e *tdEven) String() string {
+	return "Even()"
+}
+
+// tdAllItems is an operator implemented outside td package, with a
+// parameter and a nested comparison.
+type tdAllItems struct {
+	td.BaseOKNil
+	expected any
+}
+
+func AllItems(expected any) td.TestDeep {
+	return newAllItems(expected)
+}
+
+func newAllItems(expected any) td.TestDeep {
+	a := tdAllItems{
+		BaseOKNil: td.NewBaseOKNil(1),
+		expected:  expected,
+	}
+	if expected == nil {
+		a.SetBadUsage("(EXPECTED)", expected, 1, false)
+	}
+	return &a
+}
+
+func (a *tdAllItems) Match(ctx td.Context, got reflect.Value) *td.Error {
+	if err := a.UsageError(); err != nil {
+		return ctx.CollectError(err)
+	}
+	if !got.IsValid() {
+		return ctx.CollectError(&td.Error{
+			Message: "nil slice",
+			Summary: td.NewSummaryReason(nil, "a slice is expected"),
+		})
+	}
+	if got.Kind() != reflect.Slice {
+		return ctx.CollectError(td.BadKindError(got, "slice"))
+	}
+	for i := 0; i < got.Len(); i++ {
+		if err := td.MatchDeeply(ctx.AddArrayIndex(i), got.Index(i), a.expected); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *tdAllItems) String() string {
+	if a.UsageError() != nil {
+		return a.StringError()
+	}
+	return "AllItems(" + td.S(a.expected) + ")"
+}
+
+func TestExternalOperator(t *testing.T) {
+	checkOK(t, 12, Even())
+	checkOK(t, []int{2, 4}, AllItems(Even()))
+	checkOK(t, []int{3, 3}, AllItems(3))
+
+	checkError(t, 13, Even(),
+		expectedError{
+			Message:  mustBe("odd number"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("13"),
+			Expected: mustBe("Even()"),
+			Under:    mustContain("under operator Even at operator_test.go:"),
+		})
+
+	checkError(t, "foo", Even(),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("string"),
+			Expected: mustBe("int"),
+		})
+
+	checkError(t, []int{2, 5}, AllItems(Even()),
+		expectedError{
+			Message:  mustBe("odd number"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("5"),
+			Expected: mustBe("Even()"),
+			Under:    mustContain("under operator Even at operator_test.go:"),
+		})
+
+	checkError(t, []int{3, 4}, AllItems(3),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("4"),
+			Expected: mustBe("3"),
+			Under:    mustContain("under operator AllItems at operator_test.go:"),
+		})
+
+	// BaseOKNil
+	checkError(t, nil, AllItems(3),
+		expectedError{
+			Message: mustBe("nil slice"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("        value: nil\nit failed coz: a slice is expected"),
+		})
+
+	//
+	// Bad usage
+	checkError(t, "never tested",
+		AllItems(nil),
+		expectedError{
+			Message: mustBe("bad usage of AllItems operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("usage: AllItems(EXPECTED), but received nil as 1st parameter"),
+		})
+
+	//
+	// String
+	test.EqualStr(t, Even().String(), "Even()")
+	test.EqualStr(t, AllItems(3).String(), "AllItems(3)")
+	test.EqualStr(t, AllItems(nil).String(), "AllItems(<ERROR>)")
+
+	// Location is the constructor one, not the intermediate function
+	test.EqualStr(t, AllItems(3).GetLocation().Func, "AllItems")
+	test.EqualStr(t, Even().GetLocation().File, "operator_test.go")
+	test.IsFalse(t, Even().GetLocation().BehindCmp)
+
+	// Error
+	test.NoError(t, Even().Error())
+	test.Error(t, AllItems(nil).Error())
+
+	// HandleInvalid
+	test.IsFalse(t, Even().HandleInvalid())
+	test.IsTrue(t, AllItems(3).HandleInvalid())
+
+	// Can be used as a field of a Struct
+	type Person struct {
+		Age int
+	}
+	checkError(t, Person{Age: 41},
+		td.Struct(Person{}, td.StructFields{"Age": Even()}),
+		expectedError{
+			Message:  mustBe("odd number"),
+			Path:     mustBe("DATA.Age"),
+			Got:      mustBe("41"),
+			Expected: mustBe("Even()"),
+		})
+}
+
+func TestExternalOperatorTypeBehind(t *testing.T) {
+	equalTypes(t, Even(), nil)
+	equalTypes(t, AllItems(3), nil)
+}
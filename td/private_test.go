@@ -47,6 +47,10 @@ func TestPkgFunc(t *testing.T) {
 	test.EqualStr(t, pkg, "the/package")
 	test.EqualStr(t, fn, "glob..func1")
 
+	pkg, fn = pkgFunc("the/package.Foo[...]")
+	test.EqualStr(t, pkg, "the/package")
+	test.EqualStr(t, fn, "Foo")
+
 	// Theorically not possible, but...
 	pkg, fn = pkgFunc(".Foo")
 	test.EqualStr(t, pkg, "")
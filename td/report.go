@@ -0,0 +1,109 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// envReport is the environment variable name used to enable the
+// structured failure report.
+const envReport = "TESTDEEP_REPORT"
+
+// jsonReport is the JSON representation of a failing Cmp* call, one
+// per line in the report file.
+type jsonReport struct {
+	Time    time.Time
+	Package string `json:",omitempty"`
+	Test    string `json:",omitempty"`
+	Name    string `json:",omitempty"`
+	Caller  string `json:",omitempty"`
+	Fatal   bool
+	Errors  []*FailureError
+}
+
+func newJSONReport(t TestingT, failure *Failure) *jsonReport {
+	report := jsonReport{
+		Time:   time.Now(),
+		Name:   failure.Name,
+		Fatal:  failure.Fatal,
+		Errors: failure.Errors,
+	}
+	if tn, ok := t.(interface{ Name() string }); ok {
+		report.Test = tn.Name()
+	}
+	if len(failure.Trace) > 0 {
+		// go test -json reports external test packages under the
+		// name of the tested package
+		report.Package = strings.TrimSuffix(failure.Trace[0].Package, "_test")
+		report.Caller = failure.Trace[0].FileLine
+	}
+	return &report
+}
+
+// jsonReportMu serializes writes to the report file among goroutines.
+var jsonReportMu sync.Mutex
+
+// reportFileFromEnv returns the JSON report file name set in
+// TESTDEEP_REPORT environment variable, or "" if none is set.
+func reportFileFromEnv() (string, error) {
+	env := os.Getenv(envReport)
+	if env == "" {
+		return "", nil
+	}
+
+	file := strings.TrimPrefix(env, "json:")
+	if !strings.HasPrefix(env, "json:") || file == "" {
+		return "", fmt.Errorf("%s: json:FILE expected, but received %q", envReport, env)
+	}
+	return file, nil
+}
+
+// writeJSONReport appends report as a JSON line to file.
+func writeJSONReport(file string, report *jsonReport) error {
+	b, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	jsonReportMu.Lock()
+	defer jsonReportMu.Unlock()
+
+	fh, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	// Only one write per line, so several test binaries can share the
+	// same report file
+	_, err = fh.Write(b)
+	if cerr := fh.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// reportJSON writes the report of a failing Cmp* call to the file
+// set in TESTDEEP_REPORT environment variable, if any.
+func reportJSON(t TestingT, failure *Failure) error {
+	file, ferr := reportFileFromEnv()
+	if file == "" {
+		return ferr
+	}
+
+	if werr := writeJSONReport(file, newJSONReport(t, failure)); werr != nil {
+		return errors.New(envReport + ": " + werr.Error())
+	}
+	return nil
+}
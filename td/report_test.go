@@ -0,0 +1,126 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestReportJSON(tt *testing.T) {
+	file := filepath.Join(tt.TempDir(), "report.jsonl")
+	setenv(tt, "TESTDEEP_REPORT", "json:"+file)
+
+	readReport := func(t *testing.T) []map[string]any {
+		t.Helper()
+		b, err := os.ReadFile(file)
+		test.NoError(t, err)
+
+		var reports []map[string]any
+		for _, line := range strings.Split(strings.TrimSuffix(string(b), "\n"), "\n") {
+			var r map[string]any
+			test.NoError(t, json.Unmarshal([]byte(line), &r))
+			reports = append(reports, r)
+		}
+		return reports
+	}
+
+	ttt := test.NewTestingTB(tt.Name())
+	t := td.NewT(ttt, td.ContextConfig{MaxErrors: -1})
+
+	// Success: nothing reported
+	test.IsTrue(tt, t.Cmp(1, 1))
+	_, err := os.Stat(file)
+	test.IsTrue(tt, os.IsNotExist(err))
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+	test.IsFalse(tt, t.Cmp(Person{Name: "Bob", Age: 12},
+		td.Struct(Person{Name: "Alice"}, td.StructFields{
+			"Age": td.All(td.Between(18, 30)),
+		}),
+		"my %s", "person"))
+	ttt.CatchFatal(func() { t.FailureIsFatal().True(false) })
+
+	reports := readReport(tt)
+	test.EqualInt(tt, len(reports), 2)
+
+	td.Cmp(tt, reports[0], td.Map(map[string]any{
+		"Package": "github.com/maxatome/go-testdeep/td",
+		"Test":    tt.Name(),
+		"Name":    "my person",
+		"Fatal":   false,
+		"Caller":  td.Re(`^td/report_test\.go:\d+\z`),
+		"Time":    td.Re(`^\d{4}-\d\d-\d\dT`),
+		"Errors": []any{
+			map[string]any{
+				"Path":     "DATA.Age",
+				"Message":  "compared (part 1 of 1)",
+				"Got":      "12",
+				"Expected": "18 ≤ got ≤ 30",
+				"Location": map[string]any{
+					"Operator": "All",
+					"File":     "report_test.go",
+					"Line":     td.Gt(0.0),
+				},
+				"Origin": map[string]any{
+					"Path":     "DATA.Age<All#1/1>",
+					"Message":  "values differ",
+					"Got":      "12",
+					"Expected": "18 ≤ got ≤ 30",
+					"Location": map[string]any{
+						"Operator": "Between",
+						"File":     "report_test.go",
+						"Line":     td.Gt(0.0),
+					},
+				},
+			},
+			map[string]any{
+				"Path":     "DATA.Name",
+				"Message":  "values differ",
+				"Got":      `"Bob"`,
+				"Expected": `"Alice"`,
+				"Location": map[string]any{
+					"Operator": "Struct",
+					"File":     "report_test.go",
+					"Line":     td.Gt(0.0),
+				},
+			},
+		},
+	}, nil))
+
+	td.Cmp(tt, reports[1], td.SuperMapOf(map[string]any{
+		"Fatal": true,
+		"Errors": []any{
+			map[string]any{
+				"Path":     "DATA",
+				"Message":  "values differ",
+				"Got":      "false",
+				"Expected": "true",
+			},
+		},
+	}, nil))
+
+	// Bad env
+	setenv(tt, "TESTDEEP_REPORT", "xml:"+file)
+	test.IsFalse(tt, t.Cmp(1, 2))
+	test.IsTrue(tt, strings.Contains(ttt.LastMessage(),
+		`TESTDEEP_REPORT: json:FILE expected, but received "xml:`))
+
+	// Cannot open file
+	setenv(tt, "TESTDEEP_REPORT", "json:"+filepath.Join(file, "sub"))
+	test.IsFalse(tt, t.Cmp(1, 2))
+	test.IsTrue(tt, strings.Contains(ttt.LastMessage(), "TESTDEEP_REPORT: open "))
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"strings"
+
+	"github.com/maxatome/go-testdeep/helpers/tdutil"
+	"github.com/maxatome/go-testdeep/internal/color"
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/flat"
+	"github.com/maxatome/go-testdeep/internal/trace"
+)
+
+// Reporter is the interface used to report Cmp* functions and [*T]
+// methods failures. See [ContextConfig.Reporter].
+//
+// Report is called once per failing Cmp* call. It is responsible for
+// failing the test, typically by calling t.Error() or t.Fatal()
+// depending on failure.Fatal, as [DefaultReporter] does.
+//
+// Note that the JSON report enabled by TESTDEEP_REPORT environment
+// variable is written before Report is called, whatever the Reporter
+// is.
+type Reporter interface {
+	Report(t TestingT, failure *Failure)
+}
+
+// ReporterFunc is an adapter to allow the use of ordinary functions
+// as [Reporter].
+//
+//	config := td.DefaultContextConfig
+//	config.Reporter = td.ReporterFunc(func(t td.TestingT, f *td.Failure) {
+//	  t.Helper()
+//	  t.Error(f.Header() + "\n" + f.ErrorsString(false)) // no trace
+//	})
+//	t := td.NewT(tt, config)
+type ReporterFunc func(t TestingT, failure *Failure)
+
+// Report implements [Reporter] interface.
+func (f ReporterFunc) Report(t TestingT, failure *Failure) {
+	t.Helper()
+	f(t, failure)
+}
+
+// DefaultReporter is the [Reporter] used when
+// [ContextConfig.Reporter] is nil. It calls t.Fatal() if
+// failure.Fatal is true, t.Error() otherwise, passing failure
+// text representation as returned by [Failure.String].
+var DefaultReporter Reporter = ReporterFunc(func(t TestingT, failure *Failure) {
+	t.Helper()
+	if failure.Fatal {
+		t.Fatal(failure.String())
+	} else {
+		t.Error(failure.String())
+	}
+})
+
+// FailureLocation is the location of the operator responsible of a
+// [FailureError].
+type FailureLocation struct {
+	Operator string // operator name, as "Between"
+	Inside   string `json:",omitempty"` // operator using Operator, if any
+	File     string // file name, without directory
+	Line     int    // line number in File
+}
+
+// FailureError is an error of a [Failure].
+type FailureError struct {
+	Path     string           // path of the failing item, as "DATA.Age"
+	Message  string           // description of the error
+	Got      string           `json:",omitempty"` // got value representation
+	Expected string           `json:",omitempty"` // expected value representation
+	Summary  string           `json:",omitempty"` // replaces Got & Expected, if any
+	Location *FailureLocation `json:",omitempty"` // location of the responsible operator
+	Origin   *FailureError    `json:",omitempty"` // error originating this one
+}
+
+// FailureFrame is a level of the [Failure] stack trace.
+type FailureFrame struct {
+	Package  string // package import path
+	Func     string // function name
+	FileLine string // file name, relative to the module root, and line
+}
+
+// Failure describes a failing Cmp* call. It is passed to
+// [Reporter.Report].
+type Failure struct {
+	// Fatal is true if the failure is fatal, see
+	// [ContextConfig.FailureIsFatal].
+	Fatal bool
+	// Args are the args passed to the Cmp* call to name the test.
+	Args []any
+	// Name is the test name built from Args using
+	// [tdutil.BuildTestName]. It is empty if Args is empty.
+	Name string
+	// Errors are the errors found during the comparison.
+	Errors []*FailureError
+	// Trace is the stack trace of the Cmp* call, starting from the
+	// caller of the Cmp* function or [*T] method.
+	Trace []FailureFrame
+	// Notes are additional messages about the failure itself, as a
+	// TESTDEEP_REPORT write error. Reporters should display them.
+	Notes []string
+
+	err   *ctxerr.Error
+	stack trace.Stack
+}
+
+func newFailureError(err *ctxerr.Error) *FailureError {
+	path := err.Context.Path.String()
+	message := err.Message
+	if strings.Contains(message, "%%") {
+		message = strings.ReplaceAll(message, "%%", path)
+	}
+
+	fe := FailureError{
+		Path:     path,
+		Message:  message,
+		Got:      err.GotString(),
+		Expected: err.ExpectedString(),
+		Summary:  err.SummaryString(),
+	}
+	if err.Location.IsInitialized() {
+		fe.Location = &FailureLocation{
+			Operator: err.Location.Func,
+			Inside:   strings.TrimSpace(err.Location.Inside),
+			File:     err.Location.File,
+			Line:     err.Location.Line,
+		}
+	}
+	if err.Origin != nil {
+		fe.Origin = newFailureError(err.Origin)
+	}
+	return &fe
+}
+
+// newFailure builds a new [Failure]. err chain (following Next field)
+// is flattened in Errors field.
+func newFailure(isFatal bool, err *ctxerr.Error, args []any) *Failure {
+	args = flat.Interfaces(args...)
+
+	f := Failure{
+		Fatal: isFatal,
+		Args:  args,
+		Name:  tdutil.BuildTestName(args...),
+		err:   err,
+		stack: stripTrace(trace.Retrieve(0, "testing.tRunner")),
+	}
+
+	for ; err != nil; err = err.Next {
+		if err != ctxerr.ErrTooManyErrors {
+			f.Errors = append(f.Errors, newFailureError(err))
+		}
+	}
+
+	f.Trace = make([]FailureFrame, len(f.stack))
+	for i, level := range f.stack {
+		f.Trace[i] = FailureFrame{
+			Package:  level.Package,
+			Func:     level.Func,
+			FileLine: level.FileLine,
+		}
+	}
+	return &f
+}
+
+// Header returns the failure header, as "Failed test" or
+// "Failed test 'NAME'" if the test is named.
+func (f *Failure) Header() string {
+	const failedTest = "Failed test"
+	if len(f.Args) == 0 {
+		return failedTest
+	}
+	return failedTest + " '" + f.Name + "'"
+}
+
+// ErrorsString returns the text representation of the failure errors,
+// as displayed by [DefaultReporter]. If colorized is true, ANSI
+// colors are used, depending on TESTDEEP_COLOR environment variable.
+func (f *Failure) ErrorsString(colorized bool) string {
+	var buf strings.Builder
+	f.err.Append(&buf, "", colorized)
+	return buf.String()
+}
+
+// TraceString returns the text representation of the failure stack
+// trace, as displayed by [DefaultReporter], or "" if it is not
+// relevant.
+func (f *Failure) TraceString() string {
+	if !f.stack.IsRelevant() {
+		return ""
+	}
+	var buf strings.Builder
+	f.stack.Dump(&buf)
+	return buf.String()
+}
+
+// String returns the full text representation of the failure, as
+// displayed by [DefaultReporter]: the header, the errors and the stack
+// trace.
+func (f *Failure) String() string {
+	var buf strings.Builder
+	color.AppendTestNameOn(&buf)
+	buf.WriteString(f.Header())
+	color.AppendTestNameOff(&buf)
+	buf.WriteString("\n")
+
+	f.err.Append(&buf, "", true)
+
+	if s := f.TraceString(); s != "" {
+		buf.WriteString("\nThis is how we got here:\n")
+		buf.WriteString(s)
+	}
+
+	for _, note := range f.Notes {
+		buf.WriteString("\n")
+		buf.WriteString(color.Bad(note))
+	}
+	return buf.String()
+}
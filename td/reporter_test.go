@@ -0,0 +1,163 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/color"
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestReporter(tt *testing.T) {
+	defer color.SaveState()()
+
+	var (
+		failures []*td.Failure
+		reporter = td.ReporterFunc(func(t td.TestingT, f *td.Failure) {
+			t.Helper()
+			failures = append(failures, f)
+			t.Error(f.Header())
+		})
+	)
+
+	ttt := test.NewTestingTB(tt.Name())
+	t := td.NewT(ttt, td.ContextConfig{Reporter: reporter})
+
+	test.IsTrue(tt, t.Cmp(1, 1))
+	test.EqualInt(tt, len(failures), 0)
+
+	test.IsFalse(tt, t.Cmp([]int{1, 2}, []int{3, 2}, "my %s", "test"))
+	test.EqualInt(tt, len(failures), 1)
+	test.EqualStr(tt, ttt.LastMessage(), "Failed test 'my test'")
+
+	f := failures[0]
+	test.IsFalse(tt, f.Fatal)
+	test.EqualInt(tt, len(f.Args), 2)
+	test.EqualStr(tt, f.Name, "my test")
+	test.EqualStr(tt, f.Header(), "Failed test 'my test'")
+	td.Cmp(tt, f.Errors, []*td.FailureError{
+		{
+			Path:     "DATA[0]",
+			Message:  "values differ",
+			Got:      "1",
+			Expected: "3",
+		},
+	})
+	test.EqualStr(tt, f.ErrorsString(false), `DATA[0]: values differ
+	     got: 1
+	expected: 3`)
+	assert := td.Assert(tt)
+	assert.Cmp(f.Trace, []td.FailureFrame{
+		{
+			Package:  "github.com/maxatome/go-testdeep/td_test",
+			Func:     "TestReporter",
+			FileLine: assert.Anchor(td.Re(`^td/reporter_test\.go:\d+\z`), "").(string),
+		},
+	})
+	test.EqualStr(tt, f.TraceString(),
+		"\tTestReporter() "+f.Trace[0].FileLine)
+	test.EqualStr(tt, f.String(), `Failed test 'my test'
+DATA[0]: values differ
+	     got: 1
+	expected: 3
+This is how we got here:
+	TestReporter() `+f.Trace[0].FileLine)
+
+	// Not named, fatal, with operator location & origin
+	failures = nil
+	ttt.CatchFatal(func() {
+		t.FailureIsFatal().Cmp(12, td.All(td.Gt(20)))
+	})
+	test.EqualInt(tt, len(failures), 1)
+	f = failures[0]
+	test.IsTrue(tt, f.Fatal)
+	test.EqualInt(tt, len(f.Args), 0)
+	test.EqualStr(tt, f.Name, "")
+	test.EqualStr(tt, f.Header(), "Failed test")
+	assert.Cmp(f.Errors, []*td.FailureError{
+		{
+			Path:     "DATA",
+			Message:  "compared (part 1 of 1)",
+			Got:      "12",
+			Expected: "> 20",
+			Location: &td.FailureLocation{
+				Operator: "All",
+				File:     "reporter_test.go",
+				Line:     assert.Anchor(td.NotZero(), 0).(int),
+			},
+			Origin: &td.FailureError{
+				Path:     "DATA<All#1/1>",
+				Message:  "values differ",
+				Got:      "12",
+				Expected: "> 20",
+				Location: &td.FailureLocation{
+					Operator: "Gt",
+					File:     "reporter_test.go",
+					Line:     assert.Anchor(td.NotZero(), 0).(int),
+				},
+			},
+		},
+	})
+
+	// Several errors
+	failures = nil
+	t = td.NewT(ttt, td.ContextConfig{Reporter: reporter, MaxErrors: 2})
+	test.IsFalse(tt, t.Cmp(map[string]int{"a": 1, "b": 2, "c": 3},
+		map[string]int{"a": 0, "b": 0, "c": 0, "d": 0}))
+	test.EqualInt(tt, len(failures), 1)
+	td.Cmp(tt, failures[0].Errors, []*td.FailureError{
+		{
+			Path:     `DATA["a"]`,
+			Message:  "values differ",
+			Got:      "1",
+			Expected: "0",
+		},
+		{
+			Path:     `DATA["b"]`,
+			Message:  "values differ",
+			Got:      "2",
+			Expected: "0",
+		},
+	})
+	test.IsTrue(tt, strings.HasSuffix(failures[0].ErrorsString(false),
+		"\nToo many errors (use TESTDEEP_MAX_ERRORS=-1 to see all)"))
+	test.EqualInt(tt, len(failures[0].Notes), 0)
+
+	// Notes are available to custom reporters
+	failures = nil
+	setenv(tt, "TESTDEEP_REPORT", "bad")
+	test.IsFalse(tt, t.Cmp(1, 2))
+	test.EqualInt(tt, len(failures), 1)
+	td.Cmp(tt, failures[0].Notes, []string{
+		`TESTDEEP_REPORT: json:FILE expected, but received "bad"`,
+	})
+	test.IsTrue(tt, strings.Contains(failures[0].String(),
+		`TESTDEEP_REPORT: json:FILE expected, but received "bad"`))
+	setenv(tt, "TESTDEEP_REPORT", "")
+
+	// Using DefaultContextConfig
+	failures = nil
+	oldReporter := td.DefaultContextConfig.Reporter
+	defer func() { td.DefaultContextConfig.Reporter = oldReporter }()
+	td.DefaultContextConfig.Reporter = reporter
+
+	test.IsFalse(tt, td.Cmp(ttt, 1, 2))
+	test.EqualInt(tt, len(failures), 1)
+	test.EqualStr(tt, ttt.LastMessage(), "Failed test")
+
+	// nil Reporter means DefaultReporter
+	td.DefaultContextConfig.Reporter = nil
+	test.IsFalse(tt, td.Cmp(ttt, 1, 2))
+	test.EqualInt(tt, len(failures), 1)
+	test.IsTrue(tt, strings.HasPrefix(ttt.LastMessage(), `Failed test
+DATA: values differ
+	     got: 1
+	expected: 2`))
+}
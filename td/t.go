@@ -172,6 +172,25 @@ func (t *T) Code(got, fn any, args ...any) bool {
 	return t.Cmp(got, Code(fn), args...)
 }
 
+// Consistently is a shortcut for:
+//
+//	t.Cmp(got, td.Consistently(expectedValue, duration, interval), args...)
+//
+// See [Consistently] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Consistently(got, expectedValue any, duration, interval time.Duration, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Consistently(expectedValue, duration, interval), args...)
+}
+
 // Contains is a shortcut for:
 //
 //	t.Cmp(got, td.Contains(expectedValue), args...)
@@ -248,6 +267,25 @@ func (t *T) CmpErrorIs(got, expectedError any, args ...any) bool {
 	return t.Cmp(got, ErrorIs(expectedError), args...)
 }
 
+// Eventually is a shortcut for:
+//
+//	t.Cmp(got, td.Eventually(expectedValue, timeout, interval), args...)
+//
+// See [Eventually] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Eventually(got, expectedValue any, timeout, interval time.Duration, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Eventually(expectedValue, timeout, interval), args...)
+}
+
 // First is a shortcut for:
 //
 //	t.Cmp(got, td.First(filter, expectedValue), args...)
@@ -400,6 +438,25 @@ func (t *T) JSON(got, expectedJSON any, params []any, args ...any) bool {
 	return t.Cmp(got, JSON(expectedJSON, params...), args...)
 }
 
+// JSONPath is a shortcut for:
+//
+//	t.Cmp(got, td.JSONPath(expr, expectedValue), args...)
+//
+// See [JSONPath] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) JSONPath(got any, expr string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, JSONPath(expr, expectedValue), args...)
+}
+
 // JSONPointer is a shortcut for:
 //
 //	t.Cmp(got, td.JSONPointer(ptr, expectedValue), args...)
@@ -419,6 +476,25 @@ func (t *T) JSONPointer(got any, ptr string, expectedValue any, args ...any) boo
 	return t.Cmp(got, JSONPointer(ptr, expectedValue), args...)
 }
 
+// JSONSchema is a shortcut for:
+//
+//	t.Cmp(got, td.JSONSchema(schema), args...)
+//
+// See [JSONSchema] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) JSONSchema(got, schema any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, JSONSchema(schema), args...)
+}
+
 // Keys is a shortcut for:
 //
 //	t.Cmp(got, td.Keys(val), args...)
@@ -788,6 +864,29 @@ func (t *T) NotZero(got any, args ...any) bool {
 	return t.Cmp(got, NotZero(), args...)
 }
 
+// Partial is a shortcut for:
+//
+//	t.Cmp(got, td.Partial(model, expectedFields), args...)
+//
+// See [Partial] for details.
+//
+// [Partial] optional parameter expectedFields is here mandatory.
+// nil value should be passed to mimic its absence in
+// original [Partial] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Partial(got, model any, expectedFields StructFields, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Partial(model, expectedFields), args...)
+}
+
 // PPtr is a shortcut for:
 //
 //	t.Cmp(got, td.PPtr(val), args...)
@@ -971,6 +1070,25 @@ func (t *T) Smuggle(got, fn, expectedValue any, args ...any) bool {
 	return t.Cmp(got, Smuggle(fn, expectedValue), args...)
 }
 
+// CmpSnapshot is a shortcut for:
+//
+//	t.Cmp(got, td.Snapshot(name, params...), args...)
+//
+// See [Snapshot] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) CmpSnapshot(got any, name string, params []any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Snapshot(name, params...), args...)
+}
+
 // Sort is a shortcut for:
 //
 //	t.Cmp(got, td.Sort(how, expectedValue), args...)
@@ -1158,6 +1276,44 @@ func (t *T) SubSetOf(got any, expectedItems []any, args ...any) bool {
 	return t.Cmp(got, SubSetOf(expectedItems...), args...)
 }
 
+// SubXMLOf is a shortcut for:
+//
+//	t.Cmp(got, td.SubXMLOf(expectedXML, params...), args...)
+//
+// See [SubXMLOf] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) SubXMLOf(got, expectedXML any, params []any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, SubXMLOf(expectedXML, params...), args...)
+}
+
+// SubYAMLOf is a shortcut for:
+//
+//	t.Cmp(got, td.SubYAMLOf(expectedYAML, params...), args...)
+//
+// See [SubYAMLOf] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) SubYAMLOf(got, expectedYAML any, params []any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, SubYAMLOf(expectedYAML, params...), args...)
+}
+
 // SuperBagOf is a shortcut for:
 //
 //	t.Cmp(got, td.SuperBagOf(expectedItems...), args...)
@@ -1261,6 +1417,44 @@ func (t *T) SuperSliceOf(got, model any, expectedEntries ArrayEntries, args ...a
 	return t.Cmp(got, SuperSliceOf(model, expectedEntries), args...)
 }
 
+// SuperXMLOf is a shortcut for:
+//
+//	t.Cmp(got, td.SuperXMLOf(expectedXML, params...), args...)
+//
+// See [SuperXMLOf] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) SuperXMLOf(got, expectedXML any, params []any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, SuperXMLOf(expectedXML, params...), args...)
+}
+
+// SuperYAMLOf is a shortcut for:
+//
+//	t.Cmp(got, td.SuperYAMLOf(expectedYAML, params...), args...)
+//
+// See [SuperYAMLOf] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) SuperYAMLOf(got, expectedYAML any, params []any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, SuperYAMLOf(expectedYAML, params...), args...)
+}
+
 // TruncTime is a shortcut for:
 //
 //	t.Cmp(got, td.TruncTime(expectedTime, trunc), args...)
@@ -1303,6 +1497,44 @@ func (t *T) Values(got, val any, args ...any) bool {
 	return t.Cmp(got, Values(val), args...)
 }
 
+// XML is a shortcut for:
+//
+//	t.Cmp(got, td.XML(expectedXML, params...), args...)
+//
+// See [XML] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) XML(got, expectedXML any, params []any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, XML(expectedXML, params...), args...)
+}
+
+// YAML is a shortcut for:
+//
+//	t.Cmp(got, td.YAML(expectedYAML, params...), args...)
+//
+// See [YAML] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) YAML(got, expectedYAML any, params []any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, YAML(expectedYAML, params...), args...)
+}
+
 // Zero is a shortcut for:
 //
 //	t.Cmp(got, td.Zero(), args...)
@@ -137,8 +137,8 @@ var _ testing.TB = T{}
 // See also other constructors [Assert], [Require] and [AssertRequire].
 //
 // See also configurators [T.Assert], [T.Require], [T.RootName],
-// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported]
-// and [T.TestDeepInGotOK].
+// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported],
+// [T.TestDeepInGotOK] and [T.UseDiff].
 func NewT(t testing.TB, config ...ContextConfig) *T {
 	var newT T
 
@@ -188,8 +188,8 @@ func NewT(t testing.TB, config ...ContextConfig) *T {
 // See also other constructors [Require] and [AssertRequire].
 //
 // See also configurators [T.Assert], [T.Require], [T.RootName],
-// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported]
-// and [T.TestDeepInGotOK].
+// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported],
+// [T.TestDeepInGotOK] and [T.UseDiff].
 func Assert(t testing.TB, config ...ContextConfig) *T {
 	return NewT(t, config...).FailureIsFatal(false)
 }
@@ -208,8 +208,8 @@ func Assert(t testing.TB, config ...ContextConfig) *T {
 // See also other constructors [Assert] and [AssertRequire].
 //
 // See also configurators [T.Assert], [T.Require], [T.RootName],
-// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported]
-// and [T.TestDeepInGotOK].
+// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported],
+// [T.TestDeepInGotOK] and [T.UseDiff].
 func Require(t testing.TB, config ...ContextConfig) *T {
 	return NewT(t, config...).FailureIsFatal()
 }
@@ -229,8 +229,8 @@ func Require(t testing.TB, config ...ContextConfig) *T {
 // See also other constructors [Assert] and [Require].
 //
 // See also configurators [T.Assert], [T.Require], [T.RootName],
-// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported]
-// and [T.TestDeepInGotOK].
+// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported],
+// [T.TestDeepInGotOK] and [T.UseDiff].
 func AssertRequire(t testing.TB, config ...ContextConfig) (assert, require *T) {
 	assert = Assert(t, config...)
 	require = assert.FailureIsFatal()
@@ -266,8 +266,8 @@ func AssertRequire(t testing.TB, config ...ContextConfig) (assert, require *T) {
 // If "" is passed the name is set to "DATA", the default value.
 //
 // See also other configurators [T.Assert], [T.Require],
-// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported]
-// and [T.TestDeepInGotOK].
+// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported],
+// [T.TestDeepInGotOK] and [T.UseDiff].
 func (t *T) RootName(rootName string) *T {
 	nt := *t
 	if rootName == "" {
@@ -306,8 +306,8 @@ func (t *T) RootName(rootName string) *T {
 // Note that t.FailureIsFatal() acts as t.FailureIsFatal(true).
 //
 // See also other configurators [T.Assert], [T.Require], [T.RootName],
-// [T.UseEqual], [T.BeLax], [T.IgnoreUnexported] and
-// [T.TestDeepInGotOK].
+// [T.UseEqual], [T.BeLax], [T.IgnoreUnexported], [T.TestDeepInGotOK]
+// and [T.UseDiff].
 func (t *T) FailureIsFatal(enable ...bool) *T {
 	nt := *t
 	nt.Config.FailureIsFatal = len(enable) == 0 || enable[0]
@@ -324,8 +324,8 @@ func (t *T) FailureIsFatal(enable ...bool) *T {
 //	t.FailureIsFatal(false)
 //
 // See also other configurators [T.Require], [T.RootName],
-// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported]
-// and [T.TestDeepInGotOK].
+// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported],
+// [T.TestDeepInGotOK] and [T.UseDiff].
 func (t *T) Assert() *T {
 	return t.FailureIsFatal(false)
 }
@@ -340,8 +340,8 @@ func (t *T) Assert() *T {
 //	t.FailureIsFatal(true)
 //
 // See also other configurators [T.Assert], [T.RootName],
-// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported]
-// and [T.TestDeepInGotOK].
+// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported],
+// [T.TestDeepInGotOK] and [T.UseDiff].
 func (t *T) Require() *T {
 	return t.FailureIsFatal(true)
 }
@@ -375,8 +375,8 @@ func (t *T) Require() *T {
 // UseEqual call.
 //
 // See also other configurators [T.Assert], [T.Require], [T.RootName],
-// [T.FailureIsFatal], [T.BeLax], [T.IgnoreUnexported] and
-// [T.TestDeepInGotOK].
+// [T.FailureIsFatal], [T.BeLax], [T.IgnoreUnexported],
+// [T.TestDeepInGotOK] and [T.UseDiff].
 func (t *T) UseEqual(types ...any) *T {
 	// special case: UseEqual()
 	if len(types) == 0 {
@@ -418,8 +418,8 @@ func (t *T) UseEqual(types ...any) *T {
 // Note that t.BeLax() acts as t.BeLax(true).
 //
 // See also other configurators [T.Assert], [T.Require], [T.RootName],
-// [T.FailureIsFatal], [T.UseEqual], [T.IgnoreUnexported] and
-// [T.TestDeepInGotOK].
+// [T.FailureIsFatal], [T.UseEqual], [T.IgnoreUnexported],
+// [T.TestDeepInGotOK] and [T.UseDiff].
 func (t *T) BeLax(enable ...bool) *T {
 	nt := *t
 	nt.Config.BeLax = len(enable) == 0 || enable[0]
@@ -445,8 +445,8 @@ func (t *T) BeLax(enable ...bool) *T {
 // for types already recorded using a previous IgnoreUnexported call.
 //
 // See also other configurators [T.Assert], [T.Require], [T.RootName],
-// [T.FailureIsFatal], [T.UseEqual], [T.BeLax] and
-// [T.TestDeepInGotOK].
+// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.TestDeepInGotOK] and
+// [T.UseDiff].
 func (t *T) IgnoreUnexported(types ...any) *T {
 	// special case: IgnoreUnexported()
 	if len(types) == 0 {
@@ -486,14 +486,31 @@ func (t *T) IgnoreUnexported(types ...any) *T {
 // Note that t.TestDeepInGotOK() acts as t.TestDeepInGotOK(true).
 //
 // See also other configurators [T.Assert], [T.Require], [T.RootName],
-// [T.FailureIsFatal], [T.UseEqual], [T.BeLax] and
-// [T.IgnoreUnexported].
+// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported]
+// and [T.UseDiff].
 func (t *T) TestDeepInGotOK(enable ...bool) *T {
 	nt := *t
 	nt.Config.TestDeepInGotOK = len(enable) == 0 || enable[0]
 	return &nt
 }
 
+// UseDiff tells go-testdeep to render failures as a unified diff
+// between got and expected values, when at least one of them spans
+// several lines. See [ContextConfig.UseDiff] for details.
+//
+// It returns a new instance of [*T] so does not alter the original t.
+//
+// Note that t.UseDiff() acts as t.UseDiff(true).
+//
+// See also other configurators [T.Assert], [T.Require], [T.RootName],
+// [T.FailureIsFatal], [T.UseEqual], [T.BeLax], [T.IgnoreUnexported]
+// and [T.TestDeepInGotOK].
+func (t *T) UseDiff(enable ...bool) *T {
+	nt := *t
+	nt.Config.UseDiff = len(enable) == 0 || enable[0]
+	return &nt
+}
+
 // Cmp is mostly a shortcut for:
 //
 //	Cmp(t.TB, got, expected, args...)
@@ -568,6 +568,48 @@ func TestTestDeepInGotOK(tt *testing.T) {
 	test.IsTrue(tt, cmp())
 }
 
+func TestUseDiff(tt *testing.T) {
+	ttt := test.NewTestingTB(tt.Name())
+
+	got, expected := "foo\nbar\nzip", "foo\nbaz\nzip"
+	lastMessage := func() string {
+		return strings.SplitN(ttt.LastMessage(), "\nThis is how we got here:", 2)[0]
+	}
+
+	// Disabled
+	t := td.NewT(ttt).UseDiff(false)
+	test.IsFalse(tt, t.Cmp(got, expected))
+	test.EqualStr(tt, lastMessage(), `Failed test
+DATA: values differ
+	     got: `+"`"+`foo
+	          bar
+	          zip`+"`"+`
+	expected: `+"`"+`foo
+	          baz
+	          zip`+"`")
+
+	// UseDiff
+	t = td.NewT(ttt).UseDiff()
+	test.IsFalse(tt, t.Cmp(got, expected))
+	test.EqualStr(tt, lastMessage(), `Failed test
+DATA: values differ
+	--- got
+	+++ expected
+	@@ -1,3 +1,3 @@
+	 foo
+	-bar
+	+baz
+	 zip`)
+
+	t = t.UseDiff(false)
+	test.IsFalse(tt, t.Cmp(got, expected))
+	test.IsFalse(tt, strings.Contains(ttt.LastMessage(), "+++ expected"))
+
+	t = t.UseDiff(true)
+	test.IsFalse(tt, t.Cmp(got, expected))
+	test.IsTrue(tt, strings.Contains(ttt.LastMessage(), "+++ expected"))
+}
+
 func TestLogTrace(tt *testing.T) {
 	ttt := test.NewTestingTB(tt.Name())
 
@@ -23,7 +23,7 @@ type tdArrayEach struct {
 var _ TestDeep = &tdArrayEach{}
 
 // summary(ArrayEach): compares each array or slice item
-// input(ArrayEach): array,slice,ptr(ptr on array/slice)
+// input(ArrayEach): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // ArrayEach operator has to be applied on arrays or slices or on
 // pointers on array/slice. It compares each item of data array/slice
@@ -45,6 +45,9 @@ var _ TestDeep = &tdArrayEach{}
 //	    Age: td.Between(20, 45),
 //	  })),
 //	) // succeeds, each Person has Age field between 20 and 45
+//
+// An [iter.Seq] like function is also accepted, DATA[i] in error
+// paths then being the i-th yielded value.
 func ArrayEach(expectedValue any) TestDeep {
 	return &tdArrayEach{
 		baseOKNil: newBaseOKNil(3),
@@ -64,6 +67,8 @@ func (a *tdArrayEach) Match(ctx ctxerr.Context, got reflect.Value) (err *ctxerr.
 		})
 	}
 
+	resolveSeq(&got)
+
 	switch got.Kind() {
 	case reflect.Ptr:
 		gotElem := got.Elem()
@@ -8,7 +8,7 @@ package td
 
 // summary(Bag): compares the contents of an array or a slice without taking
 // care of the order of items
-// input(Bag): array,slice,ptr(ptr on array/slice)
+// input(Bag): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // Bag operator compares the contents of an array or a slice (or a
 // pointer on array/slice) without taking care of the order of items.
@@ -23,6 +23,24 @@ package td
 //	td.Cmp(t, []int{1, 1, 2}, td.Bag(1, 2))       // fails, one 1 is missing
 //	td.Cmp(t, []int{1, 1, 2}, td.Bag(1, 2, 1, 3)) // fails, 3 is missing
 //
+// When expected items are operators matching several items, the
+// best pairing between expected and got items is searched, so the
+// order of expected items does not matter:
+//
+//	td.Cmp(t, []int{1, 5}, td.Bag(td.Gt(0), 1)) // succeeds
+//
+// Note that this search is only done when the number of got items
+// multiplied by the number of expected items does not exceed 65536,
+// otherwise each expected item is simply paired with the first
+// matching got item.
+//
+// When items are structs, maps, slices… the failure report also
+// shows how expected items have been paired with got ones and, for
+// each missing expected item, the closest unpaired got item and why
+// it does not match. This explanation is deliberately omitted for
+// simple items (numbers, strings…), as the missing and extra items
+// are then enough to understand the failure.
+//
 //	// works with slices/arrays of any type
 //	td.Cmp(t, personSlice, td.Bag(
 //	  Person{Name: "Bob", Age: 32},
@@ -46,6 +64,10 @@ package td
 // known non-interface types are equal, or if only interface types
 // are found (mostly issued from Isa()) and they are equal.
 //
+// got can also be an [iter.Seq] like function, as the one returned
+// by slices.Values: the values it yields are then compared as a slice
+// would be.
+//
 // See also [SubBagOf], [SuperBagOf], [Set], [List] and [Sort].
 func Bag(expectedItems ...any) TestDeep {
 	return newSetBase(allSet, false, expectedItems)
@@ -54,7 +76,7 @@ func Bag(expectedItems ...any) TestDeep {
 // summary(SubBagOf): compares the contents of an array or a slice
 // without taking care of the order of items but with potentially some
 // exclusions
-// input(SubBagOf): array,slice,ptr(ptr on array/slice)
+// input(SubBagOf): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // SubBagOf operator compares the contents of an array or a slice (or a
 // pointer on array/slice) without taking care of the order of items.
@@ -89,6 +111,8 @@ func Bag(expectedItems ...any) TestDeep {
 // known non-interface types are equal, or if only interface types
 // are found (mostly issued from Isa()) and they are equal.
 //
+// As for [Bag], got can also be an [iter.Seq] like function.
+//
 // See also [Bag] and [SuperBagOf].
 func SubBagOf(expectedItems ...any) TestDeep {
 	return newSetBase(subSet, false, expectedItems)
@@ -97,7 +121,7 @@ func SubBagOf(expectedItems ...any) TestDeep {
 // summary(SuperBagOf): compares the contents of an array or a slice
 // without taking care of the order of items but with potentially some
 // extra items
-// input(SuperBagOf): array,slice,ptr(ptr on array/slice)
+// input(SuperBagOf): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // SuperBagOf operator compares the contents of an array or a slice (or a
 // pointer on array/slice) without taking care of the order of items.
@@ -132,6 +156,8 @@ func SubBagOf(expectedItems ...any) TestDeep {
 // known non-interface types are equal, or if only interface types
 // are found (mostly issued from Isa()) and they are equal.
 //
+// As for [Bag], got can also be an [iter.Seq] like function.
+//
 // See also [Bag] and [SubBagOf].
 func SuperBagOf(expectedItems ...any) TestDeep {
 	return newSetBase(superSet, false, expectedItems)
@@ -196,6 +196,49 @@ func TestBag(t *testing.T) {
 			testName)
 	}
 
+	//
+	// Overlapping operators: an optimal matching is needed
+	checkOK(t, []int{1, 5}, td.Bag(td.Gt(0), 1))
+	checkOK(t, []int{5, 1}, td.Bag(td.Gt(0), 1))
+	checkOK(t, []int{1, 2, 3}, td.Bag(td.Gt(0), td.Lt(3), 1))
+	checkOK(t, []int{1, 5}, td.SubBagOf(td.Gt(0), 1, 7))
+	checkOK(t, []int{1, 5, 8}, td.SuperBagOf(td.Gt(0), 1))
+
+	checkError(t, []int{1, 5, 8}, td.Bag(td.Gt(0), 1, 6),
+		expectedError{
+			Message: mustBe("comparing %% as a Bag"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Missing item: (6)\n  Extra item: (8)"),
+		})
+
+	checkError(t, []int{1, 1}, td.Bag(td.Gt(0), 1, td.Lt(2)),
+		expectedError{
+			Message: mustBe("comparing %% as a Bag"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Missing item: (< 2)"),
+		})
+
+	//
+	// Explanation
+	type Person struct {
+		Name string
+		Age  int
+	}
+	checkError(t,
+		[]Person{{"Bob", 40}, {"Alice", 31}, {"Brian", 22}},
+		td.Bag(Person{"Alice", 30}, Person{"Bob", 40}, Person{"Brian", 22}),
+		expectedError{
+			Message: mustBe("comparing %% as a Bag"),
+			Path:    mustBe("DATA"),
+			Summary: mustMatch(`
+Pairing \(expected ↔ got\):
+  \[1\]↔\[0\], \[2\]↔\[2\]
+Expected item \[0\] is closest to got item \[1\]:
+  DATA(\.Iface)?\[1\]\.Age: values differ
+  	     got: 31
+  	expected: 30`),
+		})
+
 	//
 	// String
 	test.EqualStr(t, td.Bag(1).String(), "Bag(1)")
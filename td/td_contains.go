@@ -27,7 +27,7 @@ var _ TestDeep = &tdContains{}
 // fmt.Stringer interfaces contain a rune, byte or a sub-string; or a
 // slice contains a single value or a sub-slice; or an array or map
 // contain a single value
-// input(Contains): str,array,slice,map,if(✓ + fmt.Stringer/error)
+// input(Contains): str,array,slice,map,if(✓ + fmt.Stringer/error),func(iter.Seq & iter.Seq2)
 
 // Contains is a smuggler operator to check if something is contained
 // in another thing. Contains has to be applied on arrays, slices, maps or
@@ -115,6 +115,9 @@ var _ TestDeep = &tdContains{}
 //	td.Cmp(t, hash, td.Contains((*int)(nil))) // succeeds
 //	td.Cmp(t, hash, td.Contains(td.Nil()))    // succeeds
 //
+// An [iter.Seq] like function is handled as a slice and an
+// [iter.Seq2] like one as a map: only the yielded values are checked.
+//
 // See also [ContainsKey].
 func Contains(expectedValue any) TestDeep {
 	c := tdContains{
@@ -157,6 +160,11 @@ func (c *tdContains) getExpectedValue(got reflect.Value) reflect.Value {
 }
 
 func (c *tdContains) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	// iter.Seq & iter.Seq2 like: values are checked as for slices & maps
+	if isSeq(got) != noSeq {
+		got = seqValues(got)
+	}
+
 	switch got.Kind() {
 	case reflect.Slice:
 		if !c.isTestDeeper && c.expectedValue.IsValid() {
@@ -22,10 +22,11 @@ type tdContainsKey struct {
 var _ TestDeep = &tdContainsKey{}
 
 // summary(ContainsKey): checks that a map contains a key
-// input(ContainsKey): map
+// input(ContainsKey): map,func(iter.Seq2)
 
-// ContainsKey is a smuggler operator and works on maps only. It
-// compares each key of map against expectedValue.
+// ContainsKey is a smuggler operator and works on maps and
+// [iter.Seq2] like functions only. It compares each key of map
+// against expectedValue.
 //
 //	hash := map[string]int{"foo": 12, "bar": 34, "zip": 28}
 //	td.Cmp(t, hash, td.ContainsKey("foo"))             // succeeds
@@ -49,6 +50,11 @@ var _ TestDeep = &tdContainsKey{}
 //	// But...
 //	td.Cmp(t, hnum, td.ContainsKey((*byte)(nil))) // fails: (*byte)(nil) ≠ (*int)(nil)
 //
+// For an [iter.Seq2] like function, the keys yielded by the iterator
+// are compared in iteration order, duplicates included:
+//
+//	td.Cmp(t, maps.All(hash), td.ContainsKey("foo")) // succeeds
+//
 // See also [Contains].
 func ContainsKey(expectedValue any) TestDeep {
 	c := tdContainsKey{
@@ -61,7 +67,7 @@ func ContainsKey(expectedValue any) TestDeep {
 	return &c
 }
 
-func (c *tdContainsKey) doesNotContainKey(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+func (c *tdContainsKey) doesNotContainKey(ctx ctxerr.Context, keys any) *ctxerr.Error {
 	if ctx.BooleanError {
 		return ctxerr.BooleanError
 	}
@@ -74,7 +80,7 @@ func (c *tdContainsKey) doesNotContainKey(ctx ctxerr.Context, got reflect.Value)
 			},
 			{
 				Label: "not in keys",
-				Value: util.ToString(tdutil.MapSortedKeys(got)),
+				Value: util.ToString(keys),
 			},
 		},
 	})
@@ -82,25 +88,25 @@ func (c *tdContainsKey) doesNotContainKey(ctx ctxerr.Context, got reflect.Value)
 
 // getExpectedValue returns the expected value handling the
 // Contains(nil) case: in this case it returns a typed nil (same type
-// as the keys of got).
-// got is a map (it's the caller responsibility to check).
-func (c *tdContainsKey) getExpectedValue(got reflect.Value) reflect.Value {
+// as keyType).
+func (c *tdContainsKey) getExpectedValue(keyType reflect.Type) reflect.Value {
 	// If the expectValue is non-typed nil
 	if !c.expectedValue.IsValid() {
-		// AND the kind of items in got is...
-		switch got.Type().Key().Kind() {
+		// AND the kind of keys is...
+		switch keyType.Kind() {
 		case reflect.Chan, reflect.Func, reflect.Interface,
 			reflect.Map, reflect.Ptr, reflect.Slice:
 			// returns a typed nil
-			return reflect.Zero(got.Type().Key())
+			return reflect.Zero(keyType)
 		}
 	}
 	return c.expectedValue
 }
 
 func (c *tdContainsKey) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
-	if got.Kind() == reflect.Map {
-		expectedValue := c.getExpectedValue(got)
+	switch got.Kind() {
+	case reflect.Map:
+		expectedValue := c.getExpectedValue(got.Type().Key())
 
 		// If expected value is a TestDeep operator OR BeLax, check each key
 		if c.isTestDeeper || ctx.BeLax {
@@ -115,7 +121,21 @@ func (c *tdContainsKey) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Err
 			got.MapIndex(expectedValue).IsValid() {
 			return nil
 		}
-		return c.doesNotContainKey(ctx, got)
+		return c.doesNotContainKey(ctx, tdutil.MapSortedKeys(got))
+
+	case reflect.Func:
+		// iter.Seq2 like: keys can be duplicated, check each of them
+		if isSeq(got) == seq2 {
+			keys := seqKeys(got)
+			expectedValue := c.getExpectedValue(keys.Type().Elem())
+			for i := 0; i < keys.Len(); i++ {
+				ok, err := deepValueEqualFinalOK(ctx, keys.Index(i), expectedValue)
+				if err != nil || ok {
+					return err
+				}
+			}
+			return c.doesNotContainKey(ctx, keys.Interface())
+		}
 	}
 
 	if ctx.BooleanError {
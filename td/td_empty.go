@@ -13,7 +13,7 @@ import (
 	"github.com/maxatome/go-testdeep/internal/types"
 )
 
-const emptyBadKind = "array OR chan OR map OR slice OR string OR pointer(s) on them"
+const emptyBadKind = "array OR chan OR map OR slice OR string OR iter.Seq OR iter.Seq2 OR pointer(s) on them"
 
 type tdEmpty struct {
 	baseOKNil
@@ -23,7 +23,7 @@ var _ TestDeep = &tdEmpty{}
 
 // summary(Empty): checks that an array, a channel, a map, a slice or
 // a string is empty
-// input(Empty): str,array,slice,map,ptr(ptr on array/slice/map/string),chan
+// input(Empty): str,array,slice,map,ptr(ptr on array/slice/map/string),chan,func(iter.Seq & iter.Seq2)
 
 // Empty operator checks that an array, a channel, a map, a slice or a
 // string is empty. As a special case (non-typed) nil, as well as nil
@@ -35,6 +35,9 @@ var _ TestDeep = &tdEmpty{}
 //	td.Cmp(t, "", td.Empty())                // succeeds
 //	td.Cmp(t, map[string]bool{}, td.Empty()) // succeeds
 //	td.Cmp(t, []string{"foo"}, td.Empty())   // fails
+//
+// An [iter.Seq] or [iter.Seq2] like function is empty if it does not
+// yield anything, a nil one included.
 func Empty() TestDeep {
 	return &tdEmpty{
 		baseOKNil: newBaseOKNil(3),
@@ -51,6 +54,12 @@ func isEmpty(got reflect.Value) (bool, bool) {
 	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
 		return got.Len() == 0, false
 
+	case reflect.Func:
+		if isSeq(got) != noSeq {
+			return seqIsEmpty(got), false
+		}
+		return false, true // bad kind
+
 	case reflect.Ptr:
 		switch got.Type().Elem().Kind() {
 		case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice,
@@ -107,7 +116,7 @@ var _ TestDeep = &tdNotEmpty{}
 
 // summary(NotEmpty): checks that an array, a channel, a map, a slice
 // or a string is not empty
-// input(NotEmpty): str,array,slice,map,ptr(ptr on array/slice/map/string),chan
+// input(NotEmpty): str,array,slice,map,ptr(ptr on array/slice/map/string),chan,func(iter.Seq & iter.Seq2)
 
 // NotEmpty operator checks that an array, a channel, a map, a slice
 // or a string is not empty. As a special case (non-typed) nil, as
@@ -119,6 +128,9 @@ var _ TestDeep = &tdNotEmpty{}
 //	td.Cmp(t, "", td.NotEmpty())                // fails
 //	td.Cmp(t, map[string]bool{}, td.NotEmpty()) // fails
 //	td.Cmp(t, []string{"foo"}, td.NotEmpty())   // succeeds
+//
+// An [iter.Seq] or [iter.Seq2] like function is not empty as soon as
+// it yields something.
 func NotEmpty() TestDeep {
 	return &tdNotEmpty{
 		baseOKNil: newBaseOKNil(3),
@@ -41,7 +41,7 @@ func TestEmpty(t *testing.T) {
 			Message:  mustBe("bad kind"),
 			Path:     mustBe("DATA"),
 			Got:      mustBe("int"),
-			Expected: mustBe("array OR chan OR map OR slice OR string OR pointer(s) on them"),
+			Expected: mustBe("array OR chan OR map OR slice OR string OR iter.Seq OR iter.Seq2 OR pointer(s) on them"),
 		})
 
 	num := 12
@@ -53,7 +53,7 @@ func TestEmpty(t *testing.T) {
 			Message:  mustBe("bad kind"),
 			Path:     mustBe("DATA"),
 			Got:      mustBe("****int"),
-			Expected: mustBe("array OR chan OR map OR slice OR string OR pointer(s) on them"),
+			Expected: mustBe("array OR chan OR map OR slice OR string OR iter.Seq OR iter.Seq2 OR pointer(s) on them"),
 		})
 
 	n1 = nil
@@ -62,7 +62,7 @@ func TestEmpty(t *testing.T) {
 			Message:  mustBe("bad kind"),
 			Path:     mustBe("DATA"),
 			Got:      mustBe("****int"),
-			Expected: mustBe("array OR chan OR map OR slice OR string OR pointer(s) on them"),
+			Expected: mustBe("array OR chan OR map OR slice OR string OR iter.Seq OR iter.Seq2 OR pointer(s) on them"),
 		})
 
 	checkError(t, "foobar", td.Empty(),
@@ -134,7 +134,7 @@ func TestNotEmpty(t *testing.T) {
 			Message:  mustBe("bad kind"),
 			Path:     mustBe("DATA"),
 			Got:      mustBe("int"),
-			Expected: mustBe("array OR chan OR map OR slice OR string OR pointer(s) on them"),
+			Expected: mustBe("array OR chan OR map OR slice OR string OR iter.Seq OR iter.Seq2 OR pointer(s) on them"),
 		})
 
 	checkError(t, nil, td.NotEmpty(),
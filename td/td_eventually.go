@@ -0,0 +1,263 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+type tdPolling struct {
+	tdSmugglerBase
+	timeout    time.Duration
+	interval   time.Duration
+	consistent bool
+}
+
+var _ TestDeep = &tdPolling{}
+
+// summary(Eventually): checks a function eventually returns an
+// expected value
+// input(Eventually): func(func() T)
+
+// Eventually is a smuggler operator. It calls the got function every
+// interval until its result matches expectedValue, giving up after
+// timeout. The got function must not take any parameter and must
+// return only one value.
+//
+// expectedValue can be any value including a [TestDeep] operator.
+//
+//	getStatus := func() string { return job.Status() }
+//	td.Cmp(t, getStatus, // succeeds as soon as status becomes "done"
+//	  td.Eventually("done", 2*time.Second, 50*time.Millisecond))
+//
+// The got function is called a first time immediately, then after
+// each interval, a last time when timeout is reached. So
+// Eventually(…, 0, interval) calls it only once, as when Eventually
+// is one of the candidates tried by operators like [Any], [Bag] or
+// [Contains]: waiting for each of them would be far too long.
+//
+// In case of failure, the last value returned by the got function is
+// reported, as well as the number of attempts:
+//
+//	// DATA: never matched in 41 attempts during 2s
+//	//      got: "running"
+//	// expected: "done"
+//
+// As [time.Now], [time.Sleep] and timers are the only time sources
+// used, Eventually cooperates with the fake clock of synctest
+// bubbles, see [github.com/maxatome/go-testdeep/helpers/tdsynctest].
+//
+// timeout must be ≥ 0 and interval > 0.
+//
+// TypeBehind method returns the [reflect.Type] of expectedValue,
+// except if expectedValue is a [TestDeep] operator. In this case, it
+// delegates TypeBehind() to the operator.
+//
+// See also [Consistently], [Recv] and [Smuggle].
+func Eventually(expectedValue any, timeout, interval time.Duration) TestDeep {
+	return newPolling(expectedValue, timeout, interval, false)
+}
+
+// summary(Consistently): checks a function keeps returning an
+// expected value
+// input(Consistently): func(func() T)
+
+// Consistently is a smuggler operator. It calls the got function
+// every interval during duration and checks each of its results
+// matches expectedValue. It fails as soon as a result does not
+// match. The got function must not take any parameter and must
+// return only one value.
+//
+// expectedValue can be any value including a [TestDeep] operator.
+//
+//	getConns := func() int { return pool.ActiveConns() }
+//	td.Cmp(t, getConns, // succeeds if it never exceeds 10 during 500ms
+//	  td.Consistently(td.Lte(10), 500*time.Millisecond, 50*time.Millisecond))
+//
+// The got function is called a first time immediately, then after
+// each interval, a last time when duration is reached. Note that in
+// case of success, the above [Cmp] call always lasts 500ms. As for
+// [Eventually], the got function is only called once when
+// Consistently is one of the candidates tried by operators like
+// [Any], [Bag] or [Contains].
+//
+// In case of failure, the mismatching value returned by the got
+// function is reported, as well as the number of attempts:
+//
+//	// DATA: stopped matching at attempt #4 after 150ms
+//	//      got: 11
+//	// expected: ≤ 10
+//
+// As [Eventually] does, Consistently cooperates with the fake clock
+// of synctest bubbles, see
+// [github.com/maxatome/go-testdeep/helpers/tdsynctest].
+//
+// duration must be ≥ 0 and interval > 0.
+//
+// TypeBehind method returns the [reflect.Type] of expectedValue,
+// except if expectedValue is a [TestDeep] operator. In this case, it
+// delegates TypeBehind() to the operator.
+//
+// See also [Eventually], [Recv] and [Smuggle].
+func Consistently(expectedValue any, duration, interval time.Duration) TestDeep {
+	return newPolling(expectedValue, duration, interval, true)
+}
+
+func newPolling(expectedValue any, timeout, interval time.Duration, consistent bool) *tdPolling {
+	p := tdPolling{
+		tdSmugglerBase: newSmugglerBase(expectedValue, 1),
+		timeout:        timeout,
+		interval:       interval,
+		consistent:     consistent,
+	}
+
+	if !p.isTestDeeper {
+		p.expectedValue = reflect.ValueOf(expectedValue)
+	}
+
+	timeoutName := "TIMEOUT"
+	if consistent {
+		timeoutName = "DURATION"
+	}
+	usage := "(EXPECTED, " + timeoutName + ", INTERVAL)"
+
+	switch {
+	case timeout < 0:
+		p.err = ctxerr.OpBad(p.location.Func,
+			"usage: %s%s, %s must be ≥ 0, but received %s",
+			p.location.Func, usage, timeoutName, timeout)
+	case interval <= 0:
+		p.err = ctxerr.OpBad(p.location.Func,
+			"usage: %s%s, INTERVAL must be > 0, but received %s",
+			p.location.Func, usage, interval)
+	}
+	return &p
+}
+
+func (p *tdPolling) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if p.err != nil {
+		return ctx.CollectError(p.err)
+	}
+
+	if got.Kind() != reflect.Func {
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		return ctx.CollectError(ctxerr.BadKind(got, "func"))
+	}
+
+	if got.Type().NumIn() != 0 || got.Type().NumOut() != 1 {
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		return ctx.CollectError(&ctxerr.Error{
+			Message:  "incompatible function signature",
+			Got:      types.RawString(got.Type().String()),
+			Expected: types.RawString("func() T"),
+		})
+	}
+
+	if got.IsNil() {
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		return ctx.CollectError(&ctxerr.Error{
+			Message:  "nil function",
+			Got:      got,
+			Expected: types.RawString("non-nil func() T"),
+		})
+	}
+
+	// When probed by an operator trying several candidates, do not
+	// wait: each candidate would last the whole timeout
+	timeout := p.timeout
+	if ctx.Probing {
+		timeout = 0
+	}
+
+	var (
+		start    = time.Now()
+		deadline = start.Add(timeout)
+		last     reflect.Value
+		attempts int
+	)
+	for {
+		attempts++
+		last = got.Call(nil)[0]
+
+		ok, err := deepValueEqualFinalOK(ctx, last, p.expectedValue)
+		if err != nil { // user error, stop asap
+			return ctx.CollectError(err)
+		}
+		if ok {
+			if !p.consistent {
+				return nil
+			}
+		} else if p.consistent {
+			break
+		}
+
+		now := time.Now()
+		if !now.Before(deadline) {
+			if p.consistent {
+				return nil
+			}
+			break
+		}
+
+		wait := deadline.Sub(now)
+		if wait > p.interval {
+			wait = p.interval
+		}
+		time.Sleep(wait)
+	}
+
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+
+	var message string
+	if p.consistent {
+		message = fmt.Sprintf("stopped matching at attempt #%d after %s",
+			attempts, time.Since(start).Round(time.Millisecond))
+	} else {
+		message = fmt.Sprintf("never matched in %d attempts during %s",
+			attempts, p.timeout)
+	}
+
+	err := &ctxerr.Error{
+		Message:  message,
+		Got:      last,
+		Expected: p.expectedValue,
+	}
+	if p.isTestDeeper {
+		err.Origin = deepValueEqualFinal(ctx.ResetErrors().AddCustomLevel("()"),
+			last, p.expectedValue)
+	}
+	return ctx.CollectError(err)
+}
+
+func (p *tdPolling) String() string {
+	if p.err != nil {
+		return p.stringError()
+	}
+	return fmt.Sprintf("%s(%s, %s, %s)",
+		p.location.Func, util.ToString(p.expectedValue), p.timeout, p.interval)
+}
+
+func (p *tdPolling) TypeBehind() reflect.Type {
+	if p.err != nil {
+		return nil
+	}
+	return p.internalTypeBehind()
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// counter returns a getter returning the number of times it has been
+// called since the last reset(), and reset.
+func counter() (getter func() int, reset func()) {
+	n := 0
+	return func() int { n++; return n }, func() { n = 0 }
+}
+
+func TestEventually(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		checkOK(t, func() int { return 42 }, td.Eventually(42, 0, time.Millisecond))
+		checkOK(t, func() string { return "done" },
+			td.Eventually(td.HasPrefix("do"), time.Second, time.Millisecond))
+
+		getter, reset := counter()
+		test.IsTrue(t, td.EqDeeply(getter, td.Eventually(3, time.Second, time.Millisecond)))
+		test.EqualInt(t, getter()-1, 3)
+
+		reset()
+		test.IsTrue(t, td.EqDeeply(getter, td.Eventually(td.Gte(4), time.Second, time.Millisecond)))
+		test.EqualInt(t, getter()-1, 4)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		checkError(t, func() int { return 12 }, td.Eventually(42, 0, time.Millisecond),
+			expectedError{
+				Message:  mustBe("never matched in 1 attempts during 0s"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("12"),
+				Expected: mustBe("42"),
+			})
+
+		checkError(t, func() int { return 12 },
+			td.Eventually(td.Gt(20), 5*time.Millisecond, time.Millisecond),
+			expectedError{
+				Message:  mustMatch(`^never matched in \d+ attempts during 5ms\z`),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("12"),
+				Expected: mustBe("> 20"),
+				Origin: &expectedError{
+					Message:  mustBe("values differ"),
+					Path:     mustBe("DATA()"),
+					Got:      mustBe("12"),
+					Expected: mustBe("> 20"),
+				},
+			})
+
+		// Last got value is reported
+		getter, _ := counter()
+		err := td.EqDeeplyError(getter, td.Eventually(0, 0, time.Millisecond))
+		test.EqualStr(t, err.Error(), `DATA: never matched in 1 attempts during 0s
+	     got: 1
+	expected: 0
+[under operator Eventually at td_eventually_test.go:65]`)
+	})
+
+	t.Run("candidate", func(t *testing.T) {
+		// A failing candidate does not wait for the timeout
+		getter, _ := counter()
+		start := time.Now()
+		checkOK(t, getter, td.Any(
+			td.Eventually(100, time.Hour, time.Millisecond),
+			td.Eventually(td.Gt(0), time.Hour, time.Millisecond),
+		))
+		test.IsTrue(t, time.Since(start) < time.Minute)
+
+		start = time.Now()
+		checkOK(t, []func() int{getter},
+			td.Bag(td.Not(td.Eventually(0, time.Hour, time.Millisecond))))
+		test.IsTrue(t, time.Since(start) < time.Minute)
+	})
+
+	t.Run("bad got", func(t *testing.T) {
+		checkError(t, 12, td.Eventually(12, 0, time.Millisecond),
+			expectedError{
+				Message:  mustBe("bad kind"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("int"),
+				Expected: mustBe("func"),
+			})
+
+		checkError(t, func(int) int { return 0 }, td.Eventually(12, 0, time.Millisecond),
+			expectedError{
+				Message:  mustBe("incompatible function signature"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("func(int) int"),
+				Expected: mustBe("func() T"),
+			})
+
+		checkError(t, (func() int)(nil), td.Eventually(12, 0, time.Millisecond),
+			expectedError{
+				Message:  mustBe("nil function"),
+				Path:     mustBe("DATA"),
+				Expected: mustBe("non-nil func() T"),
+			})
+	})
+
+	t.Run("bad usage", func(t *testing.T) {
+		checkError(t, "never tested",
+			td.Eventually(12, -time.Second, time.Millisecond),
+			expectedError{
+				Message: mustBe("bad usage of Eventually operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: Eventually(EXPECTED, TIMEOUT, INTERVAL), TIMEOUT must be ≥ 0, but received -1s"),
+			})
+
+		checkError(t, "never tested",
+			td.Eventually(12, time.Second, 0),
+			expectedError{
+				Message: mustBe("bad usage of Eventually operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: Eventually(EXPECTED, TIMEOUT, INTERVAL), INTERVAL must be > 0, but received 0s"),
+			})
+	})
+}
+
+func TestConsistently(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		checkOK(t, func() int { return 42 }, td.Consistently(42, 0, time.Millisecond))
+		checkOK(t, func() int { return 42 },
+			td.Consistently(td.Between(40, 45), 5*time.Millisecond, time.Millisecond))
+
+		getter, _ := counter()
+		test.IsTrue(t, td.EqDeeply(getter,
+			td.Consistently(td.Lt(1000), 5*time.Millisecond, time.Millisecond)))
+		test.IsTrue(t, getter() > 2)
+	})
+
+	t.Run("stopped matching", func(t *testing.T) {
+		getter, reset := counter()
+		test.IsFalse(t, td.EqDeeply(getter,
+			td.Consistently(td.Lt(3), time.Second, time.Millisecond)))
+		test.EqualInt(t, getter()-1, 3)
+
+		reset()
+		checkError(t, getter, td.Consistently(td.Lte(1), time.Second, time.Millisecond),
+			expectedError{
+				Message:  mustMatch(`^stopped matching at attempt #\d+ after \S+\z`),
+				Path:     mustBe("DATA"),
+				Expected: mustBe("≤ 1"),
+				Origin: &expectedError{
+					Message:  mustBe("values differ"),
+					Path:     mustBe("DATA()"),
+					Expected: mustBe("≤ 1"),
+				},
+			})
+
+		checkError(t, func() int { return 12 }, td.Consistently(42, time.Second, time.Millisecond),
+			expectedError{
+				Message:  mustMatch(`^stopped matching at attempt #1 after \S+\z`),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("12"),
+				Expected: mustBe("42"),
+			})
+	})
+
+	t.Run("bad usage", func(t *testing.T) {
+		checkError(t, "never tested",
+			td.Consistently(12, -time.Second, time.Millisecond),
+			expectedError{
+				Message: mustBe("bad usage of Consistently operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: Consistently(EXPECTED, DURATION, INTERVAL), DURATION must be ≥ 0, but received -1s"),
+			})
+
+		checkError(t, "never tested",
+			td.Consistently(12, time.Second, -time.Second),
+			expectedError{
+				Message: mustBe("bad usage of Consistently operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: Consistently(EXPECTED, DURATION, INTERVAL), INTERVAL must be > 0, but received -1s"),
+			})
+	})
+}
+
+func TestEventuallyString(t *testing.T) {
+	test.EqualStr(t, td.Eventually(3, time.Second, 50*time.Millisecond).String(),
+		"Eventually(3, 1s, 50ms)")
+	test.EqualStr(t, td.Consistently(td.Gt(8), time.Second, time.Millisecond).String(),
+		"Consistently(> 8, 1s, 1ms)")
+
+	// Erroneous op
+	test.EqualStr(t, td.Eventually(3, -1, 1).String(), "Eventually(<ERROR>)")
+	test.EqualStr(t, td.Consistently(3, 1, 0).String(), "Consistently(<ERROR>)")
+}
+
+func TestEventuallyTypeBehind(t *testing.T) {
+	equalTypes(t, td.Eventually(6, time.Second, time.Millisecond), 0)
+	equalTypes(t, td.Consistently(td.Between(1, 2), time.Second, time.Millisecond), 0)
+
+	// Erroneous op
+	equalTypes(t, td.Eventually(6, -1, 1), nil)
+}
@@ -137,6 +137,7 @@ func (g *tdGrepBase) notFound(ctx ctxerr.Context, got reflect.Value) *ctxerr.Err
 }
 
 func grepResolvePtr(ctx ctxerr.Context, got *reflect.Value) *ctxerr.Error {
+	resolveSeq(got)
 	if got.Kind() == reflect.Ptr {
 		gotElem := got.Elem()
 		if !gotElem.IsValid() {
@@ -167,7 +168,7 @@ type tdGrep struct {
 var _ TestDeep = &tdGrep{}
 
 // summary(Grep): reduces a slice or an array before comparing its content
-// input(Grep): array,slice,ptr(ptr on array/slice)
+// input(Grep): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // Grep is a smuggler operator. It takes an array, a slice or a
 // pointer on array/slice. For each item it applies filter, a
@@ -197,6 +198,9 @@ var _ TestDeep = &tdGrep{}
 //	td.Cmp(t, got, td.Grep(td.Gt(0), td.Nil()))     // succeeds
 //	td.Cmp(t, got, td.Grep(td.Gt(0), []int{}))      // fails
 //
+// got can also be an [iter.Seq] like function, in this case the
+// values it yields are collected in a slice before being filtered.
+//
 // See also [First], [Last] and [Flatten].
 func Grep(filter, expectedValue any) TestDeep {
 	g := tdGrep{}
@@ -264,7 +268,7 @@ var _ TestDeep = &tdFirst{}
 
 // summary(First): find the first matching item of a slice or an array
 // then compare its content
-// input(First): array,slice,ptr(ptr on array/slice)
+// input(First): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // First is a smuggler operator. It takes an array, a slice or a
 // pointer on array/slice. For each item it applies filter, a
@@ -289,6 +293,8 @@ var _ TestDeep = &tdFirst{}
 //	td.Cmp(t, []int{}, td.First(td.Gt(0), td.Gt(0)))  // fails
 //	td.Cmp(t, [0]int{}, td.First(td.Gt(0), td.Gt(0))) // fails
 //
+// As for [Grep], got can also be an [iter.Seq] like function.
+//
 // See also [Last] and [Grep].
 func First(filter, expectedValue any) TestDeep {
 	g := tdFirst{}
@@ -342,7 +348,7 @@ var _ TestDeep = &tdLast{}
 
 // summary(Last): find the last matching item of a slice or an array
 // then compare its content
-// input(Last): array,slice,ptr(ptr on array/slice)
+// input(Last): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // Last is a smuggler operator. It takes an array, a slice or a
 // pointer on array/slice. For each item it applies filter, a
@@ -367,6 +373,8 @@ var _ TestDeep = &tdLast{}
 //	td.Cmp(t, []int{}, td.Last(td.Gt(0), td.Gt(0)))  // fails
 //	td.Cmp(t, [0]int{}, td.Last(td.Gt(0), td.Gt(0))) // fails
 //
+// As for [Grep], got can also be an [iter.Seq] like function.
+//
 // See also [First] and [Grep].
 func Last(filter, expectedValue any) TestDeep {
 	g := tdLast{}
@@ -25,6 +25,10 @@ import (
 	"github.com/maxatome/go-testdeep/internal/util"
 )
 
+// jsonPathOperators contains operators whose first parameter is a
+// JSONPath expression, so "$." and "$[" strings are not placeholders.
+var jsonPathOperators = []string{"JSONPath"}
+
 // forbiddenOpsInJSON contains operators forbidden inside JSON,
 // SubJSONOf or SuperJSONOf, optionally with an alternative to help
 // the user.
@@ -33,43 +37,83 @@ var forbiddenOpsInJSON = map[string]string{
 	"Cap":          "",
 	"Catch":        "",
 	"Code":         "",
+	"Consistently": "",
 	"Delay":        "",
 	"ErrorIs":      "",
+	"Eventually":   "",
 	"Isa":          "",
 	"JSON":         "literal JSON",
 	"Lax":          "",
 	"List":         "literal []",
 	"Map":          "literal {}",
 	"PPtr":         "",
+	"Partial":      "",
 	"Ptr":          "",
 	"Recv":         "",
 	"SStruct":      "",
 	"Shallow":      "",
 	"Slice":        "literal []",
 	"Smuggle":      "",
+	"Snapshot":     "JSON operator",
 	"String":       `literal ""`,
 	"SubJSONOf":    "SubMapOf operator",
 	"SuperJSONOf":  "SuperMapOf operator",
 	"SuperSliceOf": "All and JSONPointer operators",
 	"Struct":       "",
+	"SubXMLOf":     "",
+	"SubYAMLOf":    "SubMapOf operator",
+	"SuperXMLOf":   "",
+	"SuperYAMLOf":  "SuperMapOf operator",
 	"Tag":          "",
 	"TruncTime":    "",
+	"XML":          "",
+	"YAML":         "literal JSON",
+}
+
+// unmarshalFormat describes a format handled by tdJSONUnmarshaler.
+type unmarshalFormat struct {
+	name  string   // "JSON" or "YAML"
+	exts  []string // file name extensions
+	usage string
+	parse func([]byte, ...json.ParseOpts) (any, error)
+}
+
+var jsonFormat = unmarshalFormat{
+	name:  "JSON",
+	exts:  []string{".json"},
+	usage: "(STRING_JSON|STRING_FILENAME|[]byte|json.RawMessage|io.Reader, ...)",
+	parse: json.Parse,
 }
 
 // tdJSONUnmarshaler handles the JSON unmarshaling of JSON, SubJSONOf
-// and SuperJSONOf first parameter.
+// and SuperJSONOf first parameter. It also handles YAML unmarshaling
+// of YAML, SubYAMLOf and SuperYAMLOf first parameter.
 type tdJSONUnmarshaler struct {
 	location.Location // position of the operator
 	options           jsonv2Options
+	embedIn           string // operator name used in errors about embedded operators
+	format            *unmarshalFormat
 }
 
 // newJSONUnmarshaler returns a new instance of tdJSONUnmarshaler.
 func newJSONUnmarshaler(pos location.Location) *tdJSONUnmarshaler {
 	return &tdJSONUnmarshaler{
 		Location: pos,
+		embedIn:  "JSON",
+		format:   &jsonFormat,
 	}
 }
 
+// isFilename returns true if s ends with one of u format extensions.
+func (u *tdJSONUnmarshaler) isFilename(s string) bool {
+	for _, ext := range u.format.exts {
+		if strings.HasSuffix(s, ext) {
+			return true
+		}
+	}
+	return false
+}
+
 // replaceLocation replaces the location of tdOp by the
 // JSON/SubJSONOf/SuperJSONOf one then add the position of the
 // operator inside the JSON string.
@@ -97,11 +141,12 @@ func (u *tdJSONUnmarshaler) unmarshal(expectedJSON any, params []any) (any, *ctx
 	case string:
 		// Try to load this file (if it seems it can be a filename and not
 		// a JSON content)
-		if strings.HasSuffix(data, ".json") {
+		if u.isFilename(data) {
 			// It could be a file name, try to read from it
 			b, err = os.ReadFile(data)
 			if err != nil {
-				return nil, ctxerr.OpBad(u.Func, "JSON file %s cannot be read: %s", data, err)
+				return nil, ctxerr.OpBad(u.Func, "%s file %s cannot be read: %s",
+					u.format.name, data, err)
 			}
 			break
 		}
@@ -116,13 +161,11 @@ func (u *tdJSONUnmarshaler) unmarshal(expectedJSON any, params []any) (any, *ctx
 	case io.Reader:
 		b, err = io.ReadAll(data)
 		if err != nil {
-			return nil, ctxerr.OpBad(u.Func, "JSON read error: %s", err)
+			return nil, ctxerr.OpBad(u.Func, "%s read error: %s", u.format.name, err)
 		}
 
 	default:
-		return nil, ctxerr.OpBadUsage(
-			u.Func, "(STRING_JSON|STRING_FILENAME|[]byte|json.RawMessage|io.Reader, ...)",
-			expectedJSON, 1, false)
+		return nil, ctxerr.OpBadUsage(u.Func, u.format.usage, expectedJSON, 1, false)
 	}
 
 	// Join all json/v2 options
@@ -132,13 +175,33 @@ func (u *tdJSONUnmarshaler) unmarshal(expectedJSON any, params []any) (any, *ctx
 		}
 	}
 
-	params = flat.Interfaces(params...)
-	var byTag map[string]any
+	params, byTag, cerr := u.placeholders(flat.Interfaces(params...))
+	if cerr != nil {
+		return nil, cerr
+	}
 
+	final, err := u.format.parse(b, json.ParseOpts{
+		Placeholders:       params,
+		PlaceholdersByName: byTag,
+		OpFn:               u.resolveOp(),
+		PathOperators:      jsonPathOperators,
+	})
+	if err != nil {
+		return nil, ctxerr.OpBad(u.Func, "%s unmarshal error: %s", u.format.name, err)
+	}
+
+	return final, nil
+}
+
+// placeholders returns the JSON placeholders corresponding to the
+// flattened params, numbered ones and named ones corresponding to
+// [Tag] operators. params is modified in place.
+func (u *tdJSONUnmarshaler) placeholders(params []any) ([]any, map[string]any, *ctxerr.Error) {
+	var byTag map[string]any
 	for i, p := range params {
 		if op, ok := p.(*tdTag); ok && op.err == nil {
 			if byTag[op.tag] != nil {
-				return nil, ctxerr.OpBad(u.Func, `2 params have the same tag "%s"`, op.tag)
+				return nil, nil, ctxerr.OpBad(u.Func, `2 params have the same tag "%s"`, op.tag)
 			}
 			if byTag == nil {
 				byTag = map[string]any{}
@@ -152,36 +215,26 @@ func (u *tdJSONUnmarshaler) unmarshal(expectedJSON any, params []any) (any, *ctx
 		}
 		params[i] = newJSONNumPlaceholder(uint64(i+1), p, u.options)
 	}
-
-	final, err := json.Parse(b, json.ParseOpts{
-		Placeholders:       params,
-		PlaceholdersByName: byTag,
-		OpFn:               u.resolveOp(),
-	})
-	if err != nil {
-		return nil, ctxerr.OpBad(u.Func, "JSON unmarshal error: %s", err)
-	}
-
-	return final, nil
+	return params, byTag, nil
 }
 
 // resolveOp returns a closure usable as json.ParseOpts.OpFn.
 func (u *tdJSONUnmarshaler) resolveOp() func(json.Operator, json.Position) (any, error) {
 	return func(jop json.Operator, posInJSON json.Position) (any, error) {
-		op, exists := allOperators[jop.Name]
-		if !exists {
-			return nil, fmt.Errorf("unknown operator %s()", jop.Name)
-		}
-
-		if hint, exists := forbiddenOpsInJSON[jop.Name]; exists {
-			if hint == "" {
-				return nil, fmt.Errorf("%s() is not usable in JSON()", jop.Name)
+		var vfn reflect.Value
+		if op, exists := allOperators[jop.Name]; exists {
+			if hint, exists := forbiddenOpsInJSON[jop.Name]; exists {
+				if hint == "" {
+					return nil, fmt.Errorf("%s() is not usable in %s()", jop.Name, u.embedIn)
+				}
+				return nil, fmt.Errorf("%s() is not usable in %s(), use %s instead",
+					jop.Name, u.embedIn, hint)
 			}
-			return nil, fmt.Errorf("%s() is not usable in JSON(), use %s instead",
-				jop.Name, hint)
+			vfn = reflect.ValueOf(op)
+		} else if vfn, exists = lookupJSONOperator(jop.Name); !exists {
+			return nil, fmt.Errorf("unknown operator %s()", jop.Name)
 		}
 
-		vfn := reflect.ValueOf(op)
 		tfn := vfn.Type()
 
 		// If some parameters contain a placeholder, dereference it
@@ -193,7 +246,7 @@ func (u *tdJSONUnmarshaler) resolveOp() func(json.Operator, json.Position) (any,
 
 		// Special cases
 		var min, max int
-		jsonPointer := false
+		jsonOptions := false
 		switch jop.Name {
 		case "Between":
 			min, max = 2, 3
@@ -224,8 +277,8 @@ func (u *tdJSONUnmarshaler) resolveOp() func(json.Operator, json.Position) (any,
 					return nil, errors.New(`Between() bad 3rd parameter, use "[]", "[[", "]]" or "]["`)
 				}
 			}
-		case "JSONPointer":
-			jsonPointer, min, max = true, 2, 2
+		case "JSONPath", "JSONPointer":
+			jsonOptions, min, max = true, 2, 2
 		case "N", "Re":
 			min, max = 1, 2
 		case "Sorted":
@@ -263,8 +316,9 @@ func (u *tdJSONUnmarshaler) resolveOp() func(json.Operator, json.Position) (any,
 			for i, p := range jop.Params {
 				in[i] = reflect.ValueOf(p)
 			}
-			// For JSONPointer use the same json/v2 options as the JSON operator one
-			if jsonPointer && u.options != nil {
+			// For JSONPath & JSONPointer use the same json/v2 options as
+			// the JSON operator one
+			if jsonOptions && u.options != nil {
 				in = append(in, reflect.ValueOf(u.options))
 			}
 
@@ -286,7 +340,10 @@ func (u *tdJSONUnmarshaler) resolveOp() func(json.Operator, json.Position) (any,
 			}
 		}
 
-		tdOp := vfn.Call(in)[0].Interface().(TestDeep)
+		tdOp, _ := vfn.Call(in)[0].Interface().(TestDeep)
+		if tdOp == nil {
+			return nil, fmt.Errorf("%s() returned a nil operator", jop.Name)
+		}
 
 		// let erroneous operators (tdOp.err != nil) pass
 
@@ -608,19 +665,22 @@ func jsonify(ctx ctxerr.Context, got reflect.Value, opts jsonv2Options) (any, *c
 //   - the optional 3rd parameter of [Between] has to be specified as a string
 //     and can be: "[]" or "BoundsInIn" (default), "[[" or "BoundsInOut",
 //     "]]" or "BoundsOutIn", "][" or "BoundsOutOut";
-//   - the optional 3rd parameter of [JSONPointer] (opts) is filled by all
-//     [encoding/json/v2.Options] values found in params, so it uses the
-//     same marshal/unmarshal semantics as JSON itself. If that's not
-//     desirable, do not embed [JSONPointer] and use a placeholder instead;
+//   - the optional 3rd parameter of [JSONPath] and [JSONPointer] (opts)
+//     is filled by all [encoding/json/v2.Options] values found in params,
+//     so it uses the same marshal/unmarshal semantics as JSON itself. If
+//     that's not desirable, do not embed [JSONPath] nor [JSONPointer] and
+//     use a placeholder instead;
 //   - not all operators are embeddable only the following are: [All],
 //     [Any], [ArrayEach], [Bag], [Between], [Contains],
 //     [ContainsKey], [Empty], [First], [Grep], [Gt], [Gte],
-//     [HasPrefix], [HasSuffix], [Ignore], [JSONPointer], [Keys],
-//     [Last], [Len], [Lt], [Lte], [MapEach], [N], [NaN], [Nil],
-//     [None], [Not], [NotAny], [NotEmpty], [NotNaN], [NotNil],
-//     [NotZero], [Re], [ReAll], [Set], [Sort], [Sorted], [SubBagOf],
-//     [SubMapOf], [SubSetOf], [SuperBagOf], [SuperMapOf],
-//     [SuperSetOf], [Values] and [Zero].
+//     [HasPrefix], [HasSuffix], [Ignore], [JSONPath], [JSONPointer],
+//     [JSONSchema], [Keys], [Last], [Len], [Lt], [Lte], [MapEach], [N],
+//     [NaN], [Nil], [None], [Not], [NotAny], [NotEmpty], [NotNaN],
+//     [NotNil], [NotZero], [Re], [ReAll], [Set], [Sort], [Sorted],
+//     [SubBagOf], [SubMapOf], [SubSetOf], [SuperBagOf], [SuperMapOf],
+//     [SuperSetOf], [Values] and [Zero];
+//   - operators implemented outside td package can be embedded too,
+//     once registered using [RegisterJSONOperator].
 //
 // It is also possible to embed operators in JSON strings. This way,
 // the JSON specification can be fulfilled. To avoid collision with
@@ -746,12 +806,12 @@ func (j *tdJSON) String() string {
 		return j.stringError()
 	}
 
-	return jsonStringify("JSON", j.expected)
+	return jsonStringify(j.GetLocation().Func, j.expected)
 }
 
 func jsonStringify(opName string, v reflect.Value) string {
 	if !v.IsValid() {
-		return "JSON(null)"
+		return opName + "(null)"
 	}
 
 	var b bytes.Buffer
@@ -944,19 +1004,22 @@ var _ TestDeep = &tdMapJSON{}
 //   - the optional 3rd parameter of [Between] has to be specified as a string
 //     and can be: "[]" or "BoundsInIn" (default), "[[" or "BoundsInOut",
 //     "]]" or "BoundsOutIn", "][" or "BoundsOutOut";
-//   - the optional 3rd parameter of [JSONPointer] (opts) is filled by all
-//     [encoding/json/v2.Options] values found in params, so it uses the
-//     same marshal/unmarshal semantics as JSON itself. If that's not
-//     desirable, do not embed [JSONPointer] and use a placeholder instead;
+//   - the optional 3rd parameter of [JSONPath] and [JSONPointer] (opts)
+//     is filled by all [encoding/json/v2.Options] values found in params,
+//     so it uses the same marshal/unmarshal semantics as JSON itself. If
+//     that's not desirable, do not embed [JSONPath] nor [JSONPointer] and
+//     use a placeholder instead;
 //   - not all operators are embeddable only the following are: [All],
 //     [Any], [ArrayEach], [Bag], [Between], [Contains],
 //     [ContainsKey], [Empty], [First], [Grep], [Gt], [Gte],
-//     [HasPrefix], [HasSuffix], [Ignore], [JSONPointer], [Keys],
-//     [Last], [Len], [Lt], [Lte], [MapEach], [N], [NaN], [Nil],
-//     [None], [Not], [NotAny], [NotEmpty], [NotNaN], [NotNil],
-//     [NotZero], [Re], [ReAll], [Set], [Sort], [Sorted], [SubBagOf],
-//     [SubMapOf], [SubSetOf], [SuperBagOf], [SuperMapOf],
-//     [SuperSetOf], [Values] and [Zero].
+//     [HasPrefix], [HasSuffix], [Ignore], [JSONPath], [JSONPointer],
+//     [JSONSchema], [Keys], [Last], [Len], [Lt], [Lte], [MapEach], [N],
+//     [NaN], [Nil], [None], [Not], [NotAny], [NotEmpty], [NotNaN],
+//     [NotNil], [NotZero], [Re], [ReAll], [Set], [Sort], [Sorted],
+//     [SubBagOf], [SubMapOf], [SubSetOf], [SuperBagOf], [SuperMapOf],
+//     [SuperSetOf], [Values] and [Zero];
+//   - operators implemented outside td package can be embedded too,
+//     once registered using [RegisterJSONOperator].
 //
 // It is also possible to embed operators in JSON strings. This way,
 // the JSON specification can be fulfilled. To avoid collision with
@@ -1232,19 +1295,22 @@ func SubJSONOf(expectedJSON any, params ...any) TestDeep {
 //   - the optional 3rd parameter of [Between] has to be specified as a string
 //     and can be: "[]" or "BoundsInIn" (default), "[[" or "BoundsInOut",
 //     "]]" or "BoundsOutIn", "][" or "BoundsOutOut";
-//   - the optional 3rd parameter of [JSONPointer] (opts) is filled by all
-//     [encoding/json/v2.Options] values found in params, so it uses the
-//     same marshal/unmarshal semantics as JSON itself. If that's not
-//     desirable, do not embed [JSONPointer] and use a placeholder instead;
+//   - the optional 3rd parameter of [JSONPath] and [JSONPointer] (opts)
+//     is filled by all [encoding/json/v2.Options] values found in params,
+//     so it uses the same marshal/unmarshal semantics as JSON itself. If
+//     that's not desirable, do not embed [JSONPath] nor [JSONPointer] and
+//     use a placeholder instead;
 //   - not all operators are embeddable only the following are: [All],
 //     [Any], [ArrayEach], [Bag], [Between], [Contains],
 //     [ContainsKey], [Empty], [First], [Grep], [Gt], [Gte],
-//     [HasPrefix], [HasSuffix], [Ignore], [JSONPointer], [Keys],
-//     [Last], [Len], [Lt], [Lte], [MapEach], [N], [NaN], [Nil],
-//     [None], [Not], [NotAny], [NotEmpty], [NotNaN], [NotNil],
-//     [NotZero], [Re], [ReAll], [Set], [Sort], [Sorted], [SubBagOf],
-//     [SubMapOf], [SubSetOf], [SuperBagOf], [SuperMapOf],
-//     [SuperSetOf], [Values] and [Zero].
+//     [HasPrefix], [HasSuffix], [Ignore], [JSONPath], [JSONPointer],
+//     [JSONSchema], [Keys], [Last], [Len], [Lt], [Lte], [MapEach], [N],
+//     [NaN], [Nil], [None], [Not], [NotAny], [NotEmpty], [NotNaN],
+//     [NotNil], [NotZero], [Re], [ReAll], [Set], [Sort], [Sorted],
+//     [SubBagOf], [SubMapOf], [SubSetOf], [SuperBagOf], [SuperMapOf],
+//     [SuperSetOf], [Values] and [Zero];
+//   - operators implemented outside td package can be embedded too,
+//     once registered using [RegisterJSONOperator].
 //
 // It is also possible to embed operators in JSON strings. This way,
 // the JSON specification can be fulfilled. To avoid collision with
@@ -0,0 +1,157 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/jsonpath"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+type tdJSONPath struct {
+	tdSmugglerBase
+	path    *jsonpath.Path
+	options jsonv2Options
+}
+
+var _ TestDeep = &tdJSONPath{}
+
+// summary(JSONPath): compares against JSON representation using a
+// JSONPath expression
+// input(JSONPath): nil,bool,str,int,float,array,slice,map,struct,ptr
+
+// JSONPath is a smuggler operator. It takes the JSON representation
+// of data, selects the nodes matching the JSONPath expression expr
+// (as [RFC 9535] specifies it) and compares them, as a slice, to
+// expectedValue.
+//
+// Contrary to [JSONPointer], which addresses exactly one node, expr
+// can select any number of nodes, thanks to wildcards (* or [*]),
+// descendant segments (..), unions ([0,2]), array slices ([1:5:2])
+// and filters ([?@.price < 10]):
+//
+//	got := json.RawMessage(`{
+//	  "books": [
+//	    {"title": "Moby Dick", "price": 8.99},
+//	    {"title": "The Lord of the Rings", "price": 22.99}
+//	  ]
+//	}`)
+//
+//	td.Cmp(t, got, td.JSONPath("$.books[*].title",
+//	  []string{"Moby Dick", "The Lord of the Rings"})) // succeeds
+//	td.Cmp(t, got, td.JSONPath("$..price", td.Len(2))) // succeeds
+//	td.Cmp(t, got, td.JSONPath("$.books[?@.price > 10].title",
+//	  td.Bag("The Lord of the Rings"))) // succeeds
+//
+// Filters support comparisons (==, !=, <, <=, >, >=), logical
+// operators (&&, || and !), parentheses, existence tests as
+// [?@.isbn] and length(), count(), match() and search() functions.
+// The legacy syntax [?(@.price > 10)] is also accepted.
+//
+// The selected nodes are always compared as a slice, even if only one
+// or no node is selected. As Go maps are not ordered, object members
+// are visited in the lexical order of their names.
+//
+// By default, [encoding/json] is used to marshal and unmarshal
+// data. But if opts are passed using [encoding/json/v2.Options]
+// values, then [encoding/json/v2] is used (go1.27 required), as
+// [JSONPointer] does.
+//
+// [Lax] mode is automatically enabled to simplify numeric tests.
+//
+// JSONPath does its best to convert back the selected nodes to the
+// type of expectedValue or to the type behind the expectedValue
+// operator, if it is an operator:
+//
+//	type Book struct {
+//	  Title string  `json:"title"`
+//	  Price float64 `json:"price"`
+//	}
+//	td.Cmp(t, got, td.JSONPath("$.books[?@.price < 10]",
+//	  []Book{{Title: "Moby Dick", Price: 8.99}})) // succeeds
+//
+// In the case the conversion cannot occur, data is compared as is,
+// in its freshly unmarshaled JSON form (so as a []any containing
+// bool, float64, string, []any, map[string]any or simply nil
+// values).
+//
+// It can be embedded in [JSON], [SubJSONOf] and [SuperJSONOf], the
+// json/v2 options of these operators being used:
+//
+//	td.Cmp(t, got, td.JSON(`{"books": JSONPath("$[*].price", [8.99, 22.99])}`))
+//
+// Note that when embedded, a first parameter starting with "$." or
+// "$[" does not introduce a placeholder, so the JSONPath expression
+// does not need to be escaped. Elsewhere, such strings are still
+// parsed as placeholders.
+//
+// TypeBehind method always returns nil as the expected type cannot be
+// guessed from a JSONPath expression.
+//
+// See also [JSONPointer], [JSON] and [Smuggle].
+//
+// [RFC 9535]: https://www.rfc-editor.org/rfc/rfc9535
+func JSONPath(expr string, expectedValue any, opts ...jsonv2Options) TestDeep {
+	p := tdJSONPath{
+		tdSmugglerBase: newSmugglerBase(expectedValue),
+	}
+	for _, o := range opts {
+		p.options = joinOptions(p.options, o)
+	}
+
+	var err error
+	p.path, err = jsonpath.Parse(expr)
+	if err != nil {
+		p.err = ctxerr.OpBad("JSONPath", "bad JSONPath %q: %s", expr, err)
+		return &p
+	}
+
+	if !p.isTestDeeper {
+		p.expectedValue = reflect.ValueOf(expectedValue)
+	}
+	return &p
+}
+
+func (p *tdJSONPath) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if p.err != nil {
+		return ctx.CollectError(p.err)
+	}
+
+	vgot, eErr := jsonify(ctx, got, p.options)
+	if eErr != nil {
+		return ctx.CollectError(eErr)
+	}
+
+	ctx = ctx.AddCustomLevel(".JSONPath<" + p.path.String() + ">")
+	ctx.BeLax = true
+
+	return p.jsonValueEqual(ctx, p.path.Select(vgot), p.options)
+}
+
+func (p *tdJSONPath) String() string {
+	if p.err != nil {
+		return p.stringError()
+	}
+
+	var expected string
+	switch {
+	case p.isTestDeeper:
+		expected = p.expectedValue.Interface().(TestDeep).String()
+	case p.expectedValue.IsValid():
+		expected = util.ToString(p.expectedValue.Interface())
+	default:
+		expected = "nil"
+	}
+	return fmt.Sprintf("JSONPath(%s, %s)", p.path, expected)
+}
+
+func (p *tdJSONPath) HandleInvalid() bool {
+	return true
+}
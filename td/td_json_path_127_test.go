@@ -0,0 +1,48 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build go1.27
+// +build go1.27
+
+package td_test
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestJSONPathv2(t *testing.T) {
+	type Item struct {
+		Val  int   `json:"val"`
+		Next *Item `json:"next"`
+	}
+	got := Item{Val: 1, Next: &Item{Val: 2}}
+
+	// json/v2 marshals nil pointers as null, as json/v1 does
+	checkOK(t, got, td.JSONPath("$..val", []int{1, 2}, json.DefaultOptionsV2()))
+
+	checkOK(t, map[string]any{"a": []any{"1", "2"}},
+		td.JSONPath("$.a[*]", []int8{1, 2},
+			json.DefaultOptionsV2(),
+			json.WithUnmarshalers(json.UnmarshalFunc(func(b []byte, v *int8) error {
+				_, err := fmt.Sscanf(string(b), `"%d"`, v)
+				return err
+			})),
+		))
+
+	// Options are inherited from JSON
+	checkOK(t, map[string]any{"a": map[string]any{"b": []any{"1", "2"}}},
+		td.JSON(`{"a": JSONPath("$.b[*]", $1)}`,
+			[]int8{1, 2},
+			json.WithUnmarshalers(json.UnmarshalFunc(func(b []byte, v *int8) error {
+				_, err := fmt.Sscanf(string(b), `"%d"`, v)
+				return err
+			})),
+		))
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestJSONPath(t *testing.T) {
+	type Item struct {
+		Name  string  `json:"name"`
+		Price float64 `json:"price"`
+		Tags  []string
+	}
+	type Store struct {
+		Items []Item `json:"items"`
+		Owner string `json:"owner"`
+	}
+
+	got := Store{
+		Items: []Item{
+			{Name: "pen", Price: 1.5, Tags: []string{"office"}},
+			{Name: "desk", Price: 150},
+			{Name: "lamp", Price: 25, Tags: []string{"office", "light"}},
+		},
+		Owner: "Bob",
+	}
+
+	t.Run("Selection", func(t *testing.T) {
+		checkOK(t, got, td.JSONPath("$.owner", []any{"Bob"}))
+		checkOK(t, got, td.JSONPath("$.owner", []string{"Bob"}))
+		checkOK(t, &got, td.JSONPath("$.items[*].name", []string{"pen", "desk", "lamp"}))
+		checkOK(t, got, td.JSONPath("$..price", []any{1.5, 150, 25})) // Lax
+		checkOK(t, got, td.JSONPath("$..price", []float64{1.5, 150, 25}))
+		checkOK(t, got, td.JSONPath("$.items[1:].name", []string{"desk", "lamp"}))
+		checkOK(t, got, td.JSONPath("$.items[::-2].name", []string{"lamp", "pen"}))
+		checkOK(t, got, td.JSONPath("$.items[-1:]",
+			[]Item{{Name: "lamp", Price: 25, Tags: []string{"office", "light"}}}))
+		checkOK(t, got, td.JSONPath("$.items[?@.price > 20].name", td.Bag("desk", "lamp")))
+		checkOK(t, got, td.JSONPath("$.items[?(@.price < 100 && @.price > 2)].name",
+			[]string{"lamp"}))
+		checkOK(t, got, td.JSONPath("$.items[?count(@.Tags[*]) > 0].name",
+			[]string{"pen", "lamp"}))
+		checkOK(t, got, td.JSONPath("$..Tags[?@ == 'office']", td.Len(2)))
+		checkOK(t, got, td.JSONPath("$.unknown", td.Empty()))
+		checkOK(t, got, td.JSONPath("$.unknown", []any{}))
+		checkOK(t, got, td.JSONPath("$.items[*]", td.ArrayEach(td.ContainsKey("name"))))
+
+		checkOK(t, json.RawMessage(`[1, 2, 3]`), td.JSONPath("$[0,2]", []int{1, 3}))
+
+		checkOK(t, nil, td.JSONPath("$", []any{nil}))
+		checkOK(t, (*Store)(nil), td.JSONPath("$.items", td.Empty()))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		checkError(t, got, td.JSONPath("$.items[*].name", []string{"pen", "desk", "bulb"}),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA.JSONPath<$.items[*].name>[2]"),
+				Got:      mustBe(`"lamp"`),
+				Expected: mustBe(`"bulb"`),
+			})
+
+		checkError(t, got, td.JSONPath("$.owner", td.Empty()),
+			expectedError{
+				Message: mustBe("not empty"),
+				Path:    mustBe("DATA.JSONPath<$.owner>"),
+			})
+
+		checkError(t, got, td.JSONPath("$.owner", []int{12}),
+			expectedError{
+				Message: mustBe("an error occurred while unmarshaling JSON into []int"),
+				Path:    mustBe("DATA.JSONPath<$.owner>"),
+			})
+
+		checkError(t, map[string]any{"x": make(chan int)}, td.JSONPath("$.x", nil),
+			expectedError{
+				Message: mustBe("json.Marshal failed"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("json: unsupported type: chan int"),
+			})
+	})
+
+	t.Run("Inside JSON", func(t *testing.T) {
+		checkOK(t, got, td.JSON(`
+{
+  "items": JSONPath("$[*].price", [1.5, 150, 25]),
+  "owner": "Bob"
+}`))
+		checkOK(t, got,
+			td.SuperJSONOf(`{"items": JSONPath("$[?@.price < 10].name", $1)}`,
+				[]string{"pen"}))
+
+		checkError(t, got, td.SuperJSONOf(`{"items": JSONPath("$[*].name", ["pen"])}`),
+			expectedError{
+				Message: mustBe("comparing slices, from index #1"),
+				Path:    mustBe(`DATA["items"].JSONPath<$[*].name>`),
+			})
+
+		checkError(t, "never tested",
+			td.JSON(`[ JSONPath("$") ]`),
+			expectedError{
+				Message: mustBe("bad usage of JSON operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(`JSON unmarshal error: JSONPath() requires 2 parameters at line 1:2 (pos 2)`),
+			})
+
+		// Only the first parameter of JSONPath is a JSONPath expression
+		checkError(t, "never tested",
+			td.JSON(`{"owner": "$.owner"}`),
+			expectedError{
+				Message: mustBe("bad usage of JSON operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain(`bad placeholder "$.owner"`),
+			})
+	})
+
+	//
+	// Bad usage
+	checkError(t, "never tested",
+		td.JSONPath("items[0]", 1234),
+		expectedError{
+			Message: mustBe("bad usage of JSONPath operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`bad JSONPath "items[0]": JSONPath must start with $ at position 0`),
+		})
+
+	checkError(t, "never tested",
+		td.JSONPath("$[?@.x == ]", 1234),
+		expectedError{
+			Message: mustBe("bad usage of JSONPath operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`bad JSONPath "$[?@.x == ]": operand expected at position 10`),
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.JSONPath("$.x[*]", td.Len(2)).String(),
+		"JSONPath($.x[*], len=2)")
+	test.EqualStr(t, td.JSONPath("$.x", []int{2}).String(),
+		"JSONPath($.x, ([]int) (len=1) {\n (int) 2\n})")
+	test.EqualStr(t, td.JSONPath("$.x", nil).String(),
+		"JSONPath($.x, nil)")
+
+	// Erroneous op
+	test.EqualStr(t, td.JSONPath("x", 1234).String(), "JSONPath(<ERROR>)")
+}
+
+func TestJSONPathTypeBehind(t *testing.T) {
+	equalTypes(t, td.JSONPath("$", 42), nil)
+
+	// Erroneous op
+	equalTypes(t, td.JSONPath("x", 1234), nil)
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	ejson "encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/jsonschema"
+	"github.com/maxatome/go-testdeep/internal/util"
+	"github.com/maxatome/go-testdeep/internal/yaml"
+)
+
+type tdJSONSchema struct {
+	baseOKNil
+	validator *jsonschema.Validator
+	schema    string // as displayed by String()
+}
+
+var _ TestDeep = &tdJSONSchema{}
+
+// summary(JSONSchema): checks the JSON representation of data fits
+// a JSON Schema
+// input(JSONSchema): nil,bool,str,int,float,array,slice,map,struct,ptr
+
+// JSONSchema operator checks that the JSON representation of data
+// fits the JSON Schema schema. schema can be a:
+//
+//   - string containing a JSON schema like `{"type":"integer"}`
+//   - string containing a filename, ending with ".json", ".yaml" or
+//     ".yml" (its content is [os.ReadFile] before unmarshaling)
+//   - []byte containing a JSON schema
+//   - [encoding/json.RawMessage] containing a JSON schema
+//   - bool or map[string]any, as an already unmarshaled JSON schema
+//
+// As [JSONPointer] does, data is first marshaled then unmarshaled
+// using [encoding/json], so the schema applies to its JSON
+// representation:
+//
+//	type Person struct {
+//	  Name string `json:"name"`
+//	  Age  int    `json:"age"`
+//	}
+//	got := Person{Name: "Bob", Age: 42}
+//
+//	td.Cmp(t, got, td.JSONSchema(`{
+//	  "type": "object",
+//	  "required": ["name", "age"],
+//	  "properties": {
+//	    "name": {"type": "string", "minLength": 1},
+//	    "age":  {"type": "integer", "minimum": 0}
+//	  }
+//	}`)) // succeeds
+//
+// A subset of JSON Schema draft 2020-12 is supported: type, enum,
+// const, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// multipleOf, minLength, maxLength, pattern, format, items,
+// prefixItems, contains, minItems, maxItems, uniqueItems,
+// properties, patternProperties, additionalProperties, required,
+// minProperties, maxProperties, propertyNames, dependentRequired,
+// allOf, anyOf, oneOf, not, if/then/else and $ref. Only local
+// references, as "#/$defs/person", are supported. Unknown keywords
+// are ignored.
+//
+// Each violation is reported as a separate error, located using the
+// JSON pointer of the faulty value and giving the JSON pointer of the
+// violated keyword in the schema.
+//
+// It can be embedded in [JSON], [SubJSONOf] and [SuperJSONOf]
+// operators, the schema being a JSON object or a filename:
+//
+//	td.Cmp(t, got, td.JSON(`{
+//	  "name":  "Bob",
+//	  "links": JSONSchema({"type": "array", "items": {"type": "string", "format": "uri"}})
+//	}`))
+//
+// TypeBehind method always returns nil as the expected type cannot be
+// guessed from a JSON schema.
+//
+// See also [JSON] and [JSONPointer].
+func JSONSchema(schema any) TestDeep {
+	s := tdJSONSchema{
+		baseOKNil: newBaseOKNil(3),
+	}
+
+	const usage = "(STRING_JSON|STRING_FILENAME|[]byte|json.RawMessage|bool|map[string]any)"
+
+	var (
+		root any
+		err  error
+	)
+	switch data := schema.(type) {
+	case string:
+		if strings.HasSuffix(data, ".json") ||
+			strings.HasSuffix(data, ".yaml") || strings.HasSuffix(data, ".yml") {
+			s.schema = data
+			root, err = loadJSONSchema(data)
+			if err != nil {
+				s.err = ctxerr.OpBad("JSONSchema", "JSON schema file %s: %s", data, err)
+				return &s
+			}
+			break
+		}
+		err = ejson.Unmarshal([]byte(data), &root)
+
+	case []byte:
+		err = ejson.Unmarshal(data, &root)
+
+	case ejson.RawMessage:
+		err = ejson.Unmarshal(data, &root)
+
+	case bool, map[string]any:
+		root = data
+
+	default:
+		s.err = ctxerr.OpBadUsage("JSONSchema", usage, schema, 1, true)
+		return &s
+	}
+	if err != nil {
+		s.err = ctxerr.OpBad("JSONSchema", "JSON schema unmarshal error: %s", err)
+		return &s
+	}
+
+	s.validator, err = jsonschema.New(root, "")
+	if err != nil {
+		s.err = ctxerr.OpBad("JSONSchema", "bad JSON schema: %s", err)
+		return &s
+	}
+
+	if s.schema == "" {
+		b, _ := ejson.Marshal(root) // cannot fail, root comes from JSON
+		s.schema = string(b)
+	}
+	return &s
+}
+
+// loadJSONSchema reads and unmarshals the JSON or YAML schema
+// contained in filename.
+func loadJSONSchema(filename string) (any, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var root any
+	if strings.HasSuffix(filename, ".json") {
+		err = ejson.Unmarshal(b, &root)
+	} else {
+		root, err = yaml.Parse(b)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal error: %s", err)
+	}
+	return root, nil
+}
+
+func (s *tdJSONSchema) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if s.err != nil {
+		return ctx.CollectError(s.err)
+	}
+
+	vgot, eErr := jsonify(ctx, got, nil)
+	if eErr != nil {
+		return ctx.CollectError(eErr)
+	}
+
+	violations := s.validator.Validate(vgot)
+	if violations == nil {
+		return nil
+	}
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+
+	for _, v := range violations {
+		vctx := ctx
+		if v.InstancePath != "" {
+			vctx = ctx.AddCustomLevel(".JSONSchema<" + v.InstancePath + ">")
+		}
+		err := vctx.CollectError(&ctxerr.Error{
+			Message: "does not fit JSON schema",
+			Summary: ctxerr.NewSummary(v.Message + "\nviolated keyword: " + v.SchemaPath),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *tdJSONSchema) String() string {
+	if s.err != nil {
+		return s.stringError()
+	}
+	return "JSONSchema(" + util.ToString(s.schema) + ")"
+}
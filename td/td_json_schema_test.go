@@ -0,0 +1,213 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestJSONSchema(t *testing.T) {
+	type Person struct {
+		Name     string    `json:"name"`
+		Age      int       `json:"age"`
+		Children []*Person `json:"children,omitempty"`
+	}
+
+	const personSchema = `{
+  "$defs": {
+    "person": {
+      "type": "object",
+      "required": ["name", "age"],
+      "properties": {
+        "name":     {"type": "string", "minLength": 1},
+        "age":      {"type": "integer", "minimum": 0},
+        "children": {"type": "array", "items": {"$ref": "#/$defs/person"}}
+      },
+      "additionalProperties": false
+    }
+  },
+  "$ref": "#/$defs/person"
+}`
+
+	t.Run("OK", func(t *testing.T) {
+		got := Person{
+			Name:     "Bob",
+			Age:      42,
+			Children: []*Person{{Name: "Alice", Age: 16}},
+		}
+		checkOK(t, got, td.JSONSchema(personSchema))
+		checkOK(t, &got, td.JSONSchema([]byte(personSchema)))
+		checkOK(t, got, td.JSONSchema(json.RawMessage(personSchema)))
+		checkOK(t, json.RawMessage(`{"name":"Bob","age":42}`),
+			td.JSONSchema(personSchema))
+
+		checkOK(t, nil, td.JSONSchema(`{"type":"null"}`))
+		checkOK(t, (*Person)(nil), td.JSONSchema(`{"type":"null"}`))
+		checkOK(t, 42, td.JSONSchema(`{"type":"integer","enum":[12,42]}`))
+		checkOK(t, "foo", td.JSONSchema(`{"type":"string","pattern":"^f"}`))
+		checkOK(t, []int{1, 2, 3},
+			td.JSONSchema(`{"type":"array","items":{"type":"integer"},"maxItems":3}`))
+		checkOK(t, 12, td.JSONSchema(`{"oneOf":[{"type":"string"},{"type":"integer"}]}`))
+		checkOK(t, 12, td.JSONSchema(true))
+		checkOK(t, 12, td.JSONSchema(map[string]any{"type": "number"}))
+	})
+
+	t.Run("Violations", func(t *testing.T) {
+		checkError(t, "foo", td.JSONSchema(`{"type":"integer"}`),
+			expectedError{
+				Message: mustBe("does not fit JSON schema"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("expected integer, got string\nviolated keyword: #/type"),
+			})
+
+		checkError(t, 12, td.JSONSchema(false),
+			expectedError{
+				Message: mustBe("does not fit JSON schema"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("violated keyword: #"),
+			})
+
+		got := Person{
+			Name:     "Bob",
+			Age:      42,
+			Children: []*Person{{Name: "Alice", Age: 16}, {Age: -1}},
+		}
+		checkError(t, got, td.JSONSchema(personSchema),
+			expectedError{
+				Message: mustBe("does not fit JSON schema"),
+				Path:    mustBe("DATA.JSONSchema</children/1/age>"),
+				Summary: mustBe("-1 should be ≥ 0\nviolated keyword: #/$defs/person/properties/age/minimum"),
+				Next: &expectedError{
+					Message: mustBe("does not fit JSON schema"),
+					Path:    mustBe("DATA.JSONSchema</children/1/name>"),
+					Summary: mustBe("length 0 should be ≥ 1\nviolated keyword: #/$defs/person/properties/name/minLength"),
+				},
+			})
+
+		checkError(t, map[string]any{"name": "Bob", "age": 42, "x": 1},
+			td.JSONSchema(personSchema),
+			expectedError{
+				Message: mustBe("does not fit JSON schema"),
+				Path:    mustBe("DATA.JSONSchema</x>"),
+				Summary: mustBe(`unexpected property "x"` + "\nviolated keyword: #/$defs/person/additionalProperties"),
+			})
+	})
+
+	t.Run("Each violation reported", func(t *testing.T) {
+		ttt := test.NewTestingT()
+		td.Cmp(ttt, Person{Children: []*Person{{Age: -1}}}, td.JSONSchema(personSchema))
+		for _, expected := range []string{
+			"DATA.JSONSchema</children/0/age>: does not fit JSON schema",
+			"DATA.JSONSchema</children/0/name>: does not fit JSON schema",
+			"DATA.JSONSchema</name>: does not fit JSON schema",
+		} {
+			test.IsTrue(t, strings.Contains(ttt.LastMessage(), expected), expected)
+		}
+	})
+
+	t.Run("Files", func(t *testing.T) {
+		dir := t.TempDir()
+
+		jsonFile := filepath.Join(dir, "person.json")
+		if err := os.WriteFile(jsonFile, []byte(personSchema), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		checkOK(t, Person{Name: "Bob"}, td.JSONSchema(jsonFile))
+		checkError(t, Person{}, td.JSONSchema(jsonFile),
+			expectedError{
+				Message: mustBe("does not fit JSON schema"),
+				Path:    mustBe("DATA.JSONSchema</name>"),
+			})
+
+		yamlFile := filepath.Join(dir, "int.yaml")
+		err := os.WriteFile(yamlFile, []byte("type: integer\nminimum: 10\n"), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkOK(t, 12, td.JSONSchema(yamlFile))
+		checkError(t, 8, td.JSONSchema(yamlFile),
+			expectedError{
+				Message: mustBe("does not fit JSON schema"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("8 should be ≥ 10\nviolated keyword: #/minimum"),
+			})
+
+		checkError(t, "never tested",
+			td.JSONSchema(filepath.Join(dir, "unknown.json")),
+			expectedError{
+				Message: mustBe("bad usage of JSONSchema operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("JSON schema file " + filepath.Join(dir, "unknown.json") + ": "),
+			})
+	})
+
+	t.Run("Inside JSON", func(t *testing.T) {
+		got := Person{
+			Name:     "Bob",
+			Age:      42,
+			Children: []*Person{{Name: "Alice", Age: 16}},
+		}
+		checkOK(t, got, td.JSON(`{
+  "name":     "Bob",
+  "age":      JSONSchema({"type": "integer", "minimum": 18}),
+  "children": JSONSchema({"type": "array", "minItems": 1})
+}`))
+		checkOK(t, got, td.SuperJSONOf(`{"age": "$^JSONSchema({\"type\": \"integer\"})"}`))
+
+		checkError(t, got,
+			td.SuperJSONOf(`{"children": JSONSchema({"type": "array", "maxItems": 0})}`),
+			expectedError{
+				Message: mustBe("does not fit JSON schema"),
+				Path:    mustBe(`DATA["children"]`),
+				Summary: mustBe("1 items, should be ≤ 0\nviolated keyword: #/maxItems"),
+			})
+	})
+
+	t.Run("Bad usage", func(t *testing.T) {
+		checkError(t, "never tested", td.JSONSchema(42),
+			expectedError{
+				Message: mustBe("bad usage of JSONSchema operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: JSONSchema(STRING_JSON|STRING_FILENAME|[]byte|json.RawMessage|bool|map[string]any), but received int as 1st parameter"),
+			})
+
+		checkError(t, "never tested", td.JSONSchema(`{"type":`),
+			expectedError{
+				Message: mustBe("bad usage of JSONSchema operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("JSON schema unmarshal error: "),
+			})
+
+		checkError(t, "never tested", td.JSONSchema(`{"$ref":"#/$defs/unknown"}`),
+			expectedError{
+				Message: mustBe("bad usage of JSONSchema operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("bad JSON schema: "),
+			})
+	})
+
+	//
+	// String
+	test.EqualStr(t, td.JSONSchema(`{ "type" : "integer" }`).String(),
+		"JSONSchema(`{\"type\":\"integer\"}`)")
+	test.EqualStr(t, td.JSONSchema("schema.json").String(), "JSONSchema(<ERROR>)")
+	test.EqualStr(t, td.JSONSchema(42).String(), "JSONSchema(<ERROR>)")
+}
+
+func TestJSONSchemaTypeBehind(t *testing.T) {
+	equalTypes(t, td.JSONSchema(`{"type":"integer"}`), nil)
+
+	// Erroneous op
+	equalTypes(t, td.JSONSchema(42), nil)
+}
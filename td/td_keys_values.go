@@ -41,7 +41,7 @@ type tdKeys struct {
 var _ TestDeep = &tdKeys{}
 
 // summary(Keys): checks keys of a map
-// input(Keys): map
+// input(Keys): map,func(iter.Seq2)
 
 // Keys is a smuggler operator. It takes a map and compares its
 // ordered keys to val.
@@ -58,6 +58,9 @@ var _ TestDeep = &tdKeys{}
 //	got := map[string]bool{"c": true, "a": false, "b": true}
 //	td.Cmp(t, got, td.Keys(td.Bag("c", "a", "b"))) // succeeds
 //
+// An [iter.Seq2] like function is also accepted. In this case, keys
+// are not sorted but kept in iteration order, duplicates included.
+//
 // See also [Values] and [ContainsKey].
 func Keys(val any) TestDeep {
 	k := tdKeys{}
@@ -72,6 +75,11 @@ func (k *tdKeys) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 		return ctx.CollectError(k.err)
 	}
 
+	if isSeq(got) == seq2 {
+		// Keys kept in iteration order, duplicates included
+		return deepValueEqual(ctx.AddFunctionCall("keys"), seqKeys(got), k.expectedValue)
+	}
+
 	if got.Kind() != reflect.Map {
 		if ctx.BooleanError {
 			return ctxerr.BooleanError
@@ -105,7 +113,7 @@ type tdValues struct {
 var _ TestDeep = &tdValues{}
 
 // summary(Values): checks values of a map
-// input(Values): map
+// input(Values): map,func(iter.Seq2)
 
 // Values is a smuggler operator. It takes a map and compares its
 // ordered values to val.
@@ -122,6 +130,9 @@ var _ TestDeep = &tdValues{}
 //	got := map[int]string{3: "c", 1: "a", 2: "b"}
 //	td.Cmp(t, got, td.Values(td.Bag("c", "a", "b"))) // succeeds
 //
+// An [iter.Seq2] like function is also accepted. In this case, values
+// are not sorted but kept in iteration order.
+//
 // See also [Keys].
 func Values(val any) TestDeep {
 	v := tdValues{}
@@ -136,6 +147,11 @@ func (v *tdValues) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 		return ctx.CollectError(v.err)
 	}
 
+	if isSeq(got) == seq2 {
+		// Values kept in iteration order
+		return deepValueEqual(ctx.AddFunctionCall("values"), seqValues(got), v.expectedValue)
+	}
+
 	if got.Kind() != reflect.Map {
 		if ctx.BooleanError {
 			return ctxerr.BooleanError
@@ -91,7 +91,7 @@ type tdLen struct {
 var _ TestDeep = &tdLen{}
 
 // summary(Len): checks an array, slice, map, string or channel length
-// input(Len): array,slice,map,chan
+// input(Len): array,slice,map,chan,func(iter.Seq & iter.Seq2)
 
 // Len is a smuggler operator. It takes data, applies len() function
 // on it and compares its result to expectedLen. Of course, the
@@ -106,6 +106,12 @@ var _ TestDeep = &tdLen{}
 //
 //	td.Cmp(t, gotSlice, td.Len(td.Between(3, 4)))
 //
+// The compared value can also be an [iter.Seq] or [iter.Seq2] like
+// function, in this case the number of yielded items is compared:
+//
+//	td.Cmp(t, slices.Values(gotSlice), td.Len(12))
+//	td.Cmp(t, maps.All(gotMap), td.Len(3))
+//
 // See also [Cap].
 func Len(expectedLen any) TestDeep {
 	l := tdLen{}
@@ -128,27 +134,40 @@ func (l *tdLen) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 		return ctx.CollectError(l.err)
 	}
 
+	var gotLen int
 	switch got.Kind() {
 	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
-		ret, err := l.isEqual(ctx.AddFunctionCall("len"), got.Len())
-		if ret {
-			return err
-		}
-		if ctx.BooleanError {
-			return ctxerr.BooleanError
+		gotLen = got.Len()
+
+	case reflect.Func:
+		if isSeq(got) == noSeq {
+			return l.badKind(ctx, got)
 		}
-		return ctx.CollectError(&ctxerr.Error{
-			Message:  "bad length",
-			Got:      types.RawInt(got.Len()),
-			Expected: types.RawInt(l.expectedValue.Int()),
-		})
+		gotLen = seqLen(got)
 
 	default:
-		if ctx.BooleanError {
-			return ctxerr.BooleanError
-		}
-		return ctx.CollectError(ctxerr.BadKind(got, "array OR chan OR map OR slice OR string"))
+		return l.badKind(ctx, got)
+	}
+
+	ret, err := l.isEqual(ctx.AddFunctionCall("len"), gotLen)
+	if ret {
+		return err
+	}
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+	return ctx.CollectError(&ctxerr.Error{
+		Message:  "bad length",
+		Got:      types.RawInt(gotLen),
+		Expected: types.RawInt(l.expectedValue.Int()),
+	})
+}
+
+func (l *tdLen) badKind(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
 	}
+	return ctx.CollectError(ctxerr.BadKind(got, "array OR chan OR map OR slice OR string OR iter.Seq OR iter.Seq2"))
 }
 
 type tdCap struct {
@@ -63,7 +63,7 @@ func TestLen(t *testing.T) {
 			Message:  mustBe("bad kind"),
 			Path:     mustBe("DATA"),
 			Got:      mustBe("int"),
-			Expected: mustBe("array OR chan OR map OR slice OR string"),
+			Expected: mustBe("array OR chan OR map OR slice OR string OR iter.Seq OR iter.Seq2"),
 		})
 
 	//
@@ -42,7 +42,7 @@ var _ TestDeep = &tdList{}
 
 // summary(List): compares the contents of an array or a slice with taking
 // care of the order of items
-// input(List): array,slice,ptr(ptr on array/slice)
+// input(List): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // List operator compares the contents of an array or a slice (or a
 // pointer on array/slice) with taking care of the order of items.
@@ -77,6 +77,9 @@ var _ TestDeep = &tdList{}
 // known non-interface types are equal, or if only interface types
 // are found (mostly issued from Isa()) and they are equal.
 //
+// got can also be an [iter.Seq] like function, the values it yields
+// being then compared in iteration order.
+//
 // See also [Bag], [Set] and [Sort].
 func List(expectedValues ...any) TestDeep {
 	return &tdList{
@@ -85,6 +88,8 @@ func List(expectedValues ...any) TestDeep {
 }
 
 func (l *tdList) Match(ctx ctxerr.Context, got reflect.Value) (err *ctxerr.Error) {
+	resolveSeq(&got)
+
 	switch got.Kind() {
 	case reflect.Ptr:
 		gotElem := got.Elem()
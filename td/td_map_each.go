@@ -24,7 +24,7 @@ type tdMapEach struct {
 var _ TestDeep = &tdMapEach{}
 
 // summary(MapEach): compares each map entry
-// input(MapEach): map,ptr(ptr on map)
+// input(MapEach): map,ptr(ptr on map),func(iter.Seq2)
 
 // MapEach operator has to be applied on maps. It compares each value
 // of data map against expectedValue. During a match, all values have
@@ -33,6 +33,12 @@ var _ TestDeep = &tdMapEach{}
 //	got := map[string]string{"test": "foo", "buzz": "bar"}
 //	td.Cmp(t, got, td.MapEach("bar"))     // fails, coz "foo" ≠ "bar"
 //	td.Cmp(t, got, td.MapEach(td.Len(3))) // succeeds as values are 3 chars long
+//
+// An [iter.Seq2] like function is handled as a map, each yielded
+// value being compared in iteration order, even if its key is
+// duplicated:
+//
+//	td.Cmp(t, maps.All(got), td.MapEach(td.Len(3))) // succeeds
 func MapEach(expectedValue any) TestDeep {
 	return &tdMapEach{
 		baseOKNil: newBaseOKNil(3),
@@ -75,6 +81,16 @@ func (m *tdMapEach) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 			return err == nil
 		})
 		return err
+
+	case reflect.Func:
+		if isSeq(got) == seq2 {
+			var err *ctxerr.Error
+			seqEach(got, func(k, v reflect.Value) bool {
+				err = deepValueEqual(ctx.AddMapKey(k), v, m.expected)
+				return err == nil
+			})
+			return err
+		}
 	}
 
 	if ctx.BooleanError {
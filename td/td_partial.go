@@ -0,0 +1,205 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"reflect"
+
+	"github.com/maxatome/go-testdeep/helpers/tdutil"
+)
+
+// summary(Partial): compares the contents of a struct or a pointer on
+// a struct against the non-zero fields of a model, recursively
+// input(Partial): struct,ptr(ptr on struct)
+
+// Partial operator compares the contents of a struct or a pointer on
+// a struct against the non-zero values of model (if any) and the
+// values of expectedFields, as [Struct] does. The difference is that
+// each non-zero field of model is itself partially compared:
+//   - a struct (or a pointer on a struct) only checks its non-zero
+//     fields, recursively;
+//   - a slice or an array must have the same length as the compared
+//     one, but its zero items are ignored and the others are
+//     partially compared;
+//   - a map only checks its own keys, extra ones being ignored (as
+//     [SuperMapOf] does), and their values are partially compared,
+//     except the zero ones, which are ignored;
+//   - an interface is partially compared using its dynamic value;
+//   - any other value is compared as is.
+//
+// A cyclic reference in model stops the partial comparison: the
+// pointer, map or slice already encountered is compared as is.
+//
+// So zero fields, at any depth, are treated as [Ignore]:
+//
+//	type Address struct {
+//	  City    string
+//	  Country string
+//	}
+//	type Person struct {
+//	  Name      string
+//	  Age       int
+//	  Addresses []Address
+//	  Tags      map[string]string
+//	}
+//	got := Person{
+//	  Name: "Bob",
+//	  Age:  42,
+//	  Addresses: []Address{
+//	    {City: "Paris", Country: "France"},
+//	    {City: "London", Country: "UK"},
+//	  },
+//	  Tags: map[string]string{"team": "blue", "role": "dev"},
+//	}
+//	td.Cmp(t, got, td.Partial(Person{
+//	  Name:      "Bob",
+//	  Addresses: []Address{{}, {Country: "UK"}},
+//	  Tags:      map[string]string{"team": "blue"},
+//	})) // succeeds
+//
+// Structs without any exported field, like [time.Time], are opaque
+// and so always compared as a whole.
+//
+// expectedFields work exactly as for [Struct] and are not affected by
+// Partial behavior: regexps and shell patterns are supported and a
+// non-zero field of model can be overridden using the ">" prefix:
+//
+//	td.Cmp(t, got, td.Partial(
+//	  Person{
+//	    Name: "Bob",
+//	    Age:  20,
+//	  },
+//	  td.StructFields{
+//	    "> Age": td.Between(40, 45),
+//	  })) // succeeds
+//
+// As for [Struct], model can be nil if the expected type is private
+// or anonymous. As such a lazy model has no non-zero field, only
+// expectedFields are then used.
+//
+// TypeBehind method returns the [reflect.Type] of model.
+//
+// See also [Struct], [SStruct] and [SuperJSONOf].
+func Partial(model any, expectedFields ...StructFields) TestDeep {
+	ef := mergeStructFields(expectedFields...)
+	if model == nil {
+		return newStructLazy(ef, false)
+	}
+	return anyStruct(newBase(3), reflect.ValueOf(model), ef, false, partialPath{})
+}
+
+type partialKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// partialPath records the pointers, maps and slices of a [Partial]
+// model currently traversed, to stop on cyclic references.
+type partialPath map[partialKey]bool
+
+// enter records v, a non-nil pointer, map or slice, in p. It returns
+// false if v is already being traversed.
+func (p partialPath) enter(v reflect.Value) bool {
+	k := partialKey{ptr: v.Pointer(), typ: v.Type()}
+	if p[k] {
+		return false
+	}
+	p[k] = true
+	return true
+}
+
+// leave forgets v, previously recorded by [partialPath.enter].
+func (p partialPath) leave(v reflect.Value) {
+	delete(p, partialKey{ptr: v.Pointer(), typ: v.Type()})
+}
+
+// partialExpected returns the expected value corresponding to v, a
+// non-zero model value of [Partial] operator. A pointer, a map or a
+// slice already traversed in path (a cyclic reference) is returned
+// as is.
+func partialExpected(b base, v reflect.Value, path partialPath) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			return partialExpected(b, v.Elem(), path)
+		}
+
+	case reflect.Ptr:
+		if !v.IsNil() && partialStruct(v.Type().Elem()) && path.enter(v) {
+			defer path.leave(v)
+			return reflect.ValueOf(anyStruct(b, v, nil, false, path))
+		}
+
+	case reflect.Struct:
+		if partialStruct(v.Type()) {
+			return reflect.ValueOf(anyStruct(b, v, nil, false, path))
+		}
+
+	case reflect.Slice:
+		if v.IsNil() || !path.enter(v) {
+			break
+		}
+		defer path.leave(v)
+		fallthrough
+
+	case reflect.Array:
+		a := tdArray{
+			tdExpectedType: tdExpectedType{
+				base:         b,
+				expectedType: v.Type(),
+			},
+			expectedEntries: make([]reflect.Value, v.Len()),
+		}
+		for i := range a.expectedEntries {
+			a.expectedEntries[i] = partialItem(b, v.Index(i), path)
+		}
+		return reflect.ValueOf(&a)
+
+	case reflect.Map:
+		if v.IsNil() || !path.enter(v) {
+			break
+		}
+		defer path.leave(v)
+		m := tdMap{
+			tdExpectedType: tdExpectedType{
+				base:         b,
+				expectedType: v.Type(),
+			},
+			expectedEntries: make([]mapEntryInfo, 0, v.Len()),
+			kind:            superMap,
+		}
+		tdutil.MapEach(v, func(k, v reflect.Value) bool {
+			m.expectedEntries = append(m.expectedEntries, mapEntryInfo{
+				key:      k,
+				expected: partialItem(b, v, path),
+			})
+			return true
+		})
+		return reflect.ValueOf(&m)
+	}
+	return v
+}
+
+// partialItem returns the expected value corresponding to v, an item
+// of a slice, an array or a map of a [Partial] model.
+func partialItem(b base, v reflect.Value, path partialPath) reflect.Value {
+	if v.IsZero() {
+		return reflect.ValueOf(Ignore())
+	}
+	return partialExpected(b, v, path)
+}
+
+// partialStruct returns true if t, a struct type, has at least one
+// exported field. Others are considered opaque by [Partial].
+func partialStruct(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestPartial(t *testing.T) {
+	type Address struct {
+		City    string
+		Country string
+	}
+	type Person struct {
+		Name      string
+		Age       int
+		Main      Address
+		Other     *Address
+		Addresses []Address
+		Codes     [3]int
+		Tags      map[string]string
+		Extra     any
+		Born      time.Time
+		secret    Address
+	}
+
+	born := time.Date(1980, time.May, 1, 0, 0, 0, 0, time.UTC)
+	got := Person{
+		Name:  "Bob",
+		Age:   42,
+		Main:  Address{City: "Paris", Country: "France"},
+		Other: &Address{City: "London", Country: "UK"},
+		Addresses: []Address{
+			{City: "Rome", Country: "Italy"},
+			{City: "Berlin", Country: "Germany"},
+		},
+		Codes:  [3]int{1, 2, 3},
+		Tags:   map[string]string{"team": "blue", "role": "dev"},
+		Extra:  Address{City: "Oslo", Country: "Norway"},
+		Born:   born,
+		secret: Address{City: "Nowhere", Country: "Neverland"},
+	}
+
+	checkOK(t, got, td.Partial(Person{}))
+	checkOK(t, got, td.Partial(Person{Name: "Bob"}))
+	checkOK(t, &got, td.Partial(&Person{Name: "Bob"}))
+	checkOK(t, got, td.Partial(Person{Main: Address{Country: "France"}}))
+	checkOK(t, got, td.Partial(Person{Other: &Address{City: "London"}}))
+	checkOK(t, got, td.Partial(Person{Addresses: []Address{{}, {City: "Berlin"}}}))
+	checkOK(t, got, td.Partial(Person{Codes: [3]int{0, 2}}))
+	checkOK(t, got, td.Partial(Person{Tags: map[string]string{"team": "blue"}}))
+	checkOK(t, got, td.Partial(Person{Tags: map[string]string{"role": ""}}))
+	checkOK(t, got, td.Partial(Person{Extra: Address{City: "Oslo"}}))
+	checkOK(t, got, td.Partial(Person{Born: born}))
+	checkOK(t, got, td.Partial(Person{secret: Address{City: "Nowhere"}}))
+
+	// expectedFields still apply
+	checkOK(t, got,
+		td.Partial(Person{Name: "Bob"}, td.StructFields{"Age": td.Between(40, 45)}))
+	checkOK(t, got,
+		td.Partial(Person{Age: 20}, td.StructFields{"> Age": td.Gt(40)}))
+	checkOK(t, got,
+		td.Partial(Person{}, td.StructFields{"=~^(Name|Age)$": td.NotZero()}))
+
+	// Lazy model
+	checkOK(t, got, td.Partial(nil, td.StructFields{"Name": "Bob"}))
+
+	// Cyclic references
+	type Node struct {
+		Name string
+		Age  int
+		Next *Node
+		Prev *Node
+		List []any
+	}
+	n := &Node{Name: "a"}
+	n.Next = n
+	checkOK(t, n, td.Partial(n))
+	checkOK(t, *n, td.Partial(Node{Next: n}))
+
+	l := []any{nil}
+	l[0] = l
+	checkOK(t, Node{List: l}, td.Partial(Node{List: l}))
+
+	// A shared pointer is not a cycle, so is still partially compared
+	shared := &Node{Name: "b"}
+	checkOK(t,
+		Node{Next: &Node{Name: "b", Age: 1}, Prev: &Node{Name: "b", Age: 2}},
+		td.Partial(Node{Next: shared, Prev: shared}))
+
+	checkError(t, &Node{Name: "b", Next: n}, td.Partial(n),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.Name"),
+			Got:      mustBe(`"b"`),
+			Expected: mustBe(`"a"`),
+		})
+
+	// Errors
+	checkError(t, got, td.Partial(Person{Name: "Alice"}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.Name"),
+			Got:      mustBe(`"Bob"`),
+			Expected: mustBe(`"Alice"`),
+		})
+
+	checkError(t, got, td.Partial(Person{Main: Address{Country: "Italy"}}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.Main.Country"),
+			Got:      mustBe(`"France"`),
+			Expected: mustBe(`"Italy"`),
+		})
+
+	checkError(t, got, td.Partial(Person{Addresses: []Address{{}, {City: "Madrid"}}}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.Addresses[1].City"),
+			Got:      mustBe(`"Berlin"`),
+			Expected: mustBe(`"Madrid"`),
+		})
+
+	checkError(t, got, td.Partial(Person{Addresses: []Address{{City: "Rome"}}}),
+		expectedError{
+			Message: mustBe("got value out of range"),
+			Path:    mustBe("DATA.Addresses[1]"),
+		})
+
+	checkError(t, got, td.Partial(Person{Tags: map[string]string{"x": "y"}}),
+		expectedError{
+			Message: mustBe("comparing hash keys of %%"),
+			Path:    mustBe("DATA.Tags"),
+			Summary: mustBe(`Missing key: ("x")`),
+		})
+
+	checkError(t, got, td.Partial(Person{Extra: Address{Country: "Sweden"}}),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA.Extra.Country"),
+		})
+
+	checkError(t, Person{}, td.Partial(Person{Other: &Address{City: "London"}}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.Other"),
+			Expected: mustBe("non-nil"),
+		})
+
+	checkError(t, got, td.Partial(Person{Born: born.Add(time.Hour)}),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA.Born.ext"), // compared as a whole
+		})
+
+	checkError(t, got,
+		td.Partial(Person{Name: "Bob"}, td.StructFields{"Name": "Bob"}),
+		expectedError{
+			Message: mustBe("bad usage of Partial operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("non zero field Name in model already exists in expectedFields"),
+		})
+
+	checkError(t, "never tested", td.Partial(12),
+		expectedError{
+			Message: mustBe("bad usage of Partial operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("usage: Partial(STRUCT|&STRUCT|nil, EXPECTED_FIELDS), but received int as 1st parameter"),
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.Partial(Address{City: "Paris"}).String(),
+		`Partial(td_test.Address{
+  City: "Paris"
+})`)
+	test.EqualStr(t, td.Partial(Person{Main: Address{City: "Paris"}}).String(),
+		`Partial(td_test.Person{
+  Main: Partial(td_test.Address{
+  City: "Paris"
+})
+})`)
+}
+
+func TestPartialTypeBehind(t *testing.T) {
+	type MyStruct struct{ A int }
+	equalTypes(t, td.Partial(MyStruct{}), MyStruct{})
+	equalTypes(t, td.Partial(&MyStruct{}), &MyStruct{})
+
+	// Erroneous op
+	equalTypes(t, td.Partial(12), nil)
+}
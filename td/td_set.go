@@ -8,7 +8,7 @@ package td
 
 // summary(Set): compares the contents of an array or a slice ignoring
 // duplicates and without taking care of the order of items
-// input(Set): array,slice,ptr(ptr on array/slice)
+// input(Set): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // Set operator compares the contents of an array or a slice (or a
 // pointer on array/slice) ignoring duplicates and without taking care
@@ -28,6 +28,12 @@ package td
 //	  Person{Name: "Alice", Age: 26},
 //	))
 //
+// On failure with such structured items, the report also shows the
+// got items matched by each expected item and, for each missing
+// expected item, the closest got item and why it does not match. It
+// is deliberately omitted for simple items (numbers, strings…), as
+// the missing and extra items are then enough.
+//
 // To flatten a non-[]any slice/array, use [Flatten] function
 // and so avoid boring and inefficient copies:
 //
@@ -45,6 +51,8 @@ package td
 // known non-interface types are equal, or if only interface types
 // are found (mostly issued from [Isa]) and they are equal.
 //
+// As for [Bag], got can also be an [iter.Seq] like function.
+//
 // See also [NotAny], [SubSetOf], [SuperSetOf], [Bag] and [List].
 func Set(expectedItems ...any) TestDeep {
 	return newSetBase(allSet, true, expectedItems)
@@ -53,7 +61,7 @@ func Set(expectedItems ...any) TestDeep {
 // summary(SubSetOf): compares the contents of an array or a slice
 // ignoring duplicates and without taking care of the order of items
 // but with potentially some exclusions
-// input(SubSetOf): array,slice,ptr(ptr on array/slice)
+// input(SubSetOf): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // SubSetOf operator compares the contents of an array or a slice (or a
 // pointer on array/slice) ignoring duplicates and without taking care
@@ -89,6 +97,8 @@ func Set(expectedItems ...any) TestDeep {
 // known non-interface types are equal, or if only interface types
 // are found (mostly issued from [Isa]) and they are equal.
 //
+// As for [Bag], got can also be an [iter.Seq] like function.
+//
 // See also [NotAny], [Set] and [SuperSetOf].
 func SubSetOf(expectedItems ...any) TestDeep {
 	return newSetBase(subSet, true, expectedItems)
@@ -97,7 +107,7 @@ func SubSetOf(expectedItems ...any) TestDeep {
 // summary(SuperSetOf): compares the contents of an array or a slice
 // ignoring duplicates and without taking care of the order of items
 // but with potentially some extra items
-// input(SuperSetOf): array,slice,ptr(ptr on array/slice)
+// input(SuperSetOf): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // SuperSetOf operator compares the contents of an array or a slice (or
 // a pointer on array/slice) ignoring duplicates and without taking
@@ -133,6 +143,8 @@ func SubSetOf(expectedItems ...any) TestDeep {
 // known non-interface types are equal, or if only interface types
 // are found (mostly issued from [Isa]) and they are equal.
 //
+// As for [Bag], got can also be an [iter.Seq] like function.
+//
 // See also [NotAny], [Set] and [SubSetOf].
 func SuperSetOf(expectedItems ...any) TestDeep {
 	return newSetBase(superSet, true, expectedItems)
@@ -140,7 +152,7 @@ func SuperSetOf(expectedItems ...any) TestDeep {
 
 // summary(NotAny): compares the contents of an array or a slice, no
 // values have to match
-// input(NotAny): array,slice,ptr(ptr on array/slice)
+// input(NotAny): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // NotAny operator checks that the contents of an array or a slice (or
 // a pointer on array/slice) does not contain any of "notExpectedItems".
@@ -174,6 +186,8 @@ func SuperSetOf(expectedItems ...any) TestDeep {
 // known non-interface types are equal, or if only interface types
 // are found (mostly issued from [Isa]) and they are equal.
 //
+// As for [Bag], got can also be an [iter.Seq] like function.
+//
 // See also [Set], [SubSetOf] and [SuperSetOf].
 func NotAny(notExpectedItems ...any) TestDeep {
 	return newSetBase(noneSet, true, notExpectedItems)
@@ -7,9 +7,11 @@
 package td
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 
+	"github.com/maxatome/go-testdeep/internal/bipartite"
 	"github.com/maxatome/go-testdeep/internal/ctxerr"
 	"github.com/maxatome/go-testdeep/internal/flat"
 	"github.com/maxatome/go-testdeep/internal/util"
@@ -42,6 +44,8 @@ func newSetBase(kind setKind, ignoreDups bool, expectedItems []any) *tdSetBase {
 }
 
 func (s *tdSetBase) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	resolveSeq(&got)
+
 	switch got.Kind() {
 	case reflect.Ptr:
 		gotElem := got.Elem()
@@ -62,37 +66,26 @@ func (s *tdSetBase) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 		var (
 			gotLen = got.Len()
 
-			foundItems    []reflect.Value
-			missingItems  []reflect.Value
-			foundGotIdxes = map[int]bool{}
+			pairs         [][]int
+			foundGotIdxes map[int]bool
+			err           *ctxerr.Error
 		)
 
-		for _, expected := range s.expectedItems {
-			found := false
-
-			for idx := 0; len(foundGotIdxes) < gotLen && idx < gotLen; idx++ {
-				if foundGotIdxes[idx] {
-					continue
-				}
-
-				ok, err := deepValueEqualFinalOK(ctx, got.Index(idx), expected)
-				if err != nil { // user error, stop asap
-					return err
-				}
-				if ok {
-					foundItems = append(foundItems, expected)
-
-					foundGotIdxes[idx] = true
-					found = true
-
-					if !s.ignoreDups {
-						break
-					}
-				}
-			}
+		if s.ignoreDups {
+			pairs, foundGotIdxes, err = s.matchSet(ctx, got)
+		} else {
+			pairs, foundGotIdxes, err = s.matchBag(ctx, got)
+		}
+		if err != nil { // user error
+			return err
+		}
 
-			if !found {
-				missingItems = append(missingItems, expected)
+		var foundItems, missingItems []reflect.Value
+		for i, gotIdxes := range pairs {
+			if gotIdxes == nil {
+				missingItems = append(missingItems, s.expectedItems[i])
+			} else {
+				foundItems = append(foundItems, s.expectedItems[i])
 			}
 		}
 
@@ -102,33 +95,11 @@ func (s *tdSetBase) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 		}
 
 		if s.kind != noneSet {
-			if s.kind != subSet {
-				// In Set* cases with missing items, try a second pass. Perhaps
-				// an already matching got item, matches another expected item?
-				if s.ignoreDups && len(missingItems) > 0 {
-					var newMissingItems []reflect.Value
-
-				nextExpected:
-					for _, expected := range missingItems {
-						for idxGot := range foundGotIdxes {
-							ok, _ := deepValueEqualFinalOK(ctx, got.Index(idxGot), expected)
-							if ok {
-								continue nextExpected
-							}
-						}
-
-						newMissingItems = append(newMissingItems, expected)
-					}
-
-					missingItems = newMissingItems
-				}
-
-				if len(missingItems) > 0 {
-					if ctx.BooleanError {
-						return ctxerr.BooleanError
-					}
-					res.Missing = missingItems
+			if len(missingItems) > 0 && s.kind != subSet {
+				if ctx.BooleanError {
+					return ctxerr.BooleanError
 				}
+				res.Missing = missingItems
 			}
 
 			if len(foundGotIdxes) < gotLen && s.kind != superSet {
@@ -154,6 +125,9 @@ func (s *tdSetBase) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 		if res.IsEmpty() {
 			return nil
 		}
+		if res.Missing != nil {
+			res.Explanation = s.explain(ctx, got, pairs, foundGotIdxes)
+		}
 		return ctx.CollectError(&ctxerr.Error{
 			Message: "comparing %% as a " + s.GetLocation().Func,
 			Summary: res.Summary(),
@@ -166,6 +140,246 @@ func (s *tdSetBase) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 	return ctx.CollectError(ctxerr.BadKind(got, "slice OR array OR *slice OR *array"))
 }
 
+// matchSet matches got items against s.expectedItems for Set*
+// operators: each expected item can match several got items. It
+// returns, for each expected item, the indexes of got items it
+// matches, and the indexes of all matched got items.
+func (s *tdSetBase) matchSet(ctx ctxerr.Context, got reflect.Value) (pairs [][]int, foundGotIdxes map[int]bool, err *ctxerr.Error) {
+	gotLen := got.Len()
+	pairs = make([][]int, len(s.expectedItems))
+	foundGotIdxes = map[int]bool{}
+
+	for i, expected := range s.expectedItems {
+		for idx := 0; len(foundGotIdxes) < gotLen && idx < gotLen; idx++ {
+			if foundGotIdxes[idx] {
+				continue
+			}
+
+			var ok bool
+			ok, err = deepValueEqualFinalOK(ctx, got.Index(idx), expected)
+			if err != nil { // user error, stop asap
+				return
+			}
+			if ok {
+				pairs[i] = append(pairs[i], idx)
+				foundGotIdxes[idx] = true
+			}
+		}
+	}
+
+	// With missing items, try a second pass. Perhaps an already
+	// matching got item, matches another expected item?
+	if s.kind != subSet && s.kind != noneSet {
+		for i, expected := range s.expectedItems {
+			if pairs[i] != nil {
+				continue
+			}
+			for idx := 0; idx < gotLen; idx++ {
+				if foundGotIdxes[idx] {
+					ok, _ := deepValueEqualFinalOK(ctx, got.Index(idx), expected)
+					if ok {
+						pairs[i] = []int{idx}
+						break
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// maxBagMatchingPairs is the maximum number of expected × got pairs
+// compared to find an optimal matching in Bag* operators. Beyond,
+// the greedy matching is kept as is.
+const maxBagMatchingPairs = 1 << 16
+
+// matchBag matches got items against s.expectedItems for Bag*
+// operators: each expected item matches at most one got item and
+// vice versa. A greedy matching is done first. If some expected and
+// got items remain unmatched, the matching is completed to become
+// maximum, so operators accepting several got items never make
+// another expected item miss.
+func (s *tdSetBase) matchBag(ctx ctxerr.Context, got reflect.Value) (pairs [][]int, foundGotIdxes map[int]bool, err *ctxerr.Error) {
+	gotLen := got.Len()
+
+	matchExpected := make([]int, len(s.expectedItems))
+	matchGot := make([]int, gotLen)
+	for i := range matchGot {
+		matchGot[i] = bipartite.Free
+	}
+
+	numMatched, numMissing := 0, 0
+	for i, expected := range s.expectedItems {
+		matchExpected[i] = bipartite.Free
+
+		for idx := 0; numMatched < gotLen && idx < gotLen; idx++ {
+			if matchGot[idx] != bipartite.Free {
+				continue
+			}
+
+			var ok bool
+			ok, err = deepValueEqualFinalOK(ctx, got.Index(idx), expected)
+			if err != nil { // user error, stop asap
+				return
+			}
+			if ok {
+				matchExpected[i], matchGot[idx] = idx, i
+				numMatched++
+				break
+			}
+		}
+
+		if matchExpected[i] == bipartite.Free {
+			numMissing++
+		}
+	}
+
+	if numMissing > 0 && numMatched < gotLen &&
+		len(s.expectedItems)*gotLen <= maxBagMatchingPairs {
+		adj := make([][]int, len(s.expectedItems))
+		for i, expected := range s.expectedItems {
+			for idx := 0; idx < gotLen; idx++ {
+				var ok bool
+				ok, err = deepValueEqualFinalOK(ctx, got.Index(idx), expected)
+				if err != nil { // user error, stop asap
+					return
+				}
+				if ok {
+					adj[i] = append(adj[i], idx)
+				}
+			}
+		}
+		bipartite.MaxMatching(adj, matchExpected, matchGot)
+	}
+
+	pairs = make([][]int, len(s.expectedItems))
+	for i, idx := range matchExpected {
+		if idx != bipartite.Free {
+			pairs[i] = []int{idx}
+		}
+	}
+	foundGotIdxes = make(map[int]bool, gotLen)
+	for idx, i := range matchGot {
+		if i != bipartite.Free {
+			foundGotIdxes[idx] = true
+		}
+	}
+	return
+}
+
+// maxSetExplainedItems is the maximum number of missing expected
+// items for which the closest got item is searched.
+const maxSetExplainedItems = 5
+
+// explain returns the explanation of a failure: the pairing between
+// expected and got items followed, for each missing expected item, by
+// the closest got item and why it does not match.
+//
+// Both parts are deliberately left out, and so "" is returned, if no
+// closest got item mismatches at a deeper level than the item itself,
+// typically for scalar items: the Missing and Extra lists already
+// show the unmatched items and, as all got items are as close as
+// each other, no closest one would be a relevant hint.
+func (s *tdSetBase) explain(ctx ctxerr.Context, got reflect.Value, pairs [][]int, foundGotIdxes map[int]bool) string {
+	var buf strings.Builder
+
+	num := 0
+	for i, gotIdxes := range pairs {
+		if gotIdxes == nil {
+			continue
+		}
+		if num == 0 {
+			buf.WriteString("Pairing (expected ↔ got):")
+		}
+		if num%8 == 0 {
+			buf.WriteString("\n  ")
+		} else {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "[%d]↔%v", i, gotIdxes)
+		num++
+	}
+
+	// Closest candidates are preferably unpaired got items
+	gotLen := got.Len()
+	candidates := make([]int, 0, gotLen-len(foundGotIdxes))
+	for idx := 0; idx < gotLen; idx++ {
+		if !foundGotIdxes[idx] {
+			candidates = append(candidates, idx)
+		}
+	}
+	if len(candidates) == 0 {
+		for idx := 0; idx < gotLen; idx++ {
+			candidates = append(candidates, idx)
+		}
+	}
+	if len(candidates) == 0 ||
+		len(candidates)*maxSetExplainedItems > maxBagMatchingPairs {
+		return ""
+	}
+
+	num = 0
+	nested := false
+	for i, gotIdxes := range pairs {
+		if gotIdxes != nil {
+			continue
+		}
+		if num == maxSetExplainedItems {
+			buf.WriteString("\n[…]")
+			break
+		}
+		num++
+
+		idx, err := closestItem(ctx, got, s.expectedItems[i], candidates)
+		if idx < 0 {
+			continue
+		}
+		if err.Context.Path.Len() > ctx.Path.Len()+1 {
+			nested = true
+		}
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		fmt.Fprintf(&buf, "Expected item [%d] is closest to got item [%d]:\n", i, idx)
+		err.Append(&buf, "  ", false)
+	}
+	if !nested {
+		return ""
+	}
+	return buf.String()
+}
+
+// closestItem returns the index, among candidates, of the got item
+// the closest to expected, with the corresponding error. The closest
+// item is the one generating the fewest errors, then the one with the
+// deepest first error. It returns -1 if no candidate can be compared.
+func closestItem(ctx ctxerr.Context, got, expected reflect.Value, candidates []int) (int, *ctxerr.Error) {
+	ctx.MaxErrors = -1
+	ctx.CurOperator = nil // the set operator location is already displayed
+
+	var (
+		best               = -1
+		bestErr            *ctxerr.Error
+		bestNum, bestDepth int
+	)
+	for _, idx := range candidates {
+		err := deepValueEqualFinal(ctx.ResetErrors().AddArrayIndex(idx), got.Index(idx), expected)
+		if err == nil || err.User {
+			continue
+		}
+
+		num := 0
+		for e := err; e != nil; e = e.Next {
+			num++
+		}
+		depth := err.Context.Path.Len()
+		if best < 0 || num < bestNum || (num == bestNum && depth > bestDepth) {
+			best, bestErr, bestNum, bestDepth = idx, err, num, depth
+		}
+	}
+	return best, bestErr
+}
+
 func (s *tdSetBase) String() string {
 	var b strings.Builder
 	b.WriteString(s.GetLocation().Func)
@@ -22,6 +22,8 @@ type tdSetResultKind uint8
 const (
 	itemsSetResult tdSetResultKind = iota
 	keysSetResult
+	attributesSetResult
+	elementsSetResult
 )
 
 // Implements fmt.Stringer.
@@ -31,6 +33,10 @@ func (k tdSetResultKind) String() string {
 		return "item"
 	case keysSetResult:
 		return "key"
+	case attributesSetResult:
+		return "attribute"
+	case elementsSetResult:
+		return "element"
 	default:
 		return "?"
 	}
@@ -42,6 +48,8 @@ type tdSetResult struct {
 	Extra   []reflect.Value
 	Kind    tdSetResultKind
 	Sort    bool
+	// Explanation, if not empty, is displayed after Missing & Extra
+	Explanation string
 }
 
 func (r tdSetResult) IsEmpty() bool {
@@ -87,5 +95,8 @@ func (r tdSetResult) Summary() ctxerr.ErrorSummary {
 		})
 	}
 
+	if r.Explanation != "" && len(summary) > 0 {
+		summary[len(summary)-1].Explanation = r.Explanation
+	}
 	return summary
 }
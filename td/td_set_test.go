@@ -238,6 +238,27 @@ func TestSet(t *testing.T) {
 			testName)
 	}
 
+	//
+	// Explanation
+	type Person struct {
+		Name string
+		Age  int
+	}
+	checkError(t,
+		[]Person{{"Bob", 40}, {"Bob", 40}, {"Alice", 31}},
+		td.SuperSetOf(Person{"Bob", 40}, Person{"Alice", 30}),
+		expectedError{
+			Message: mustBe("comparing %% as a SuperSetOf"),
+			Path:    mustBe("DATA"),
+			Summary: mustMatch(`
+Pairing \(expected ↔ got\):
+  \[0\]↔\[0 1\]
+Expected item \[1\] is closest to got item \[2\]:
+  DATA(\.Iface)?\[2\]\.Age: values differ
+  	     got: 31
+  	expected: 30`),
+		})
+
 	//
 	// String
 	test.EqualStr(t, td.Set(1).String(), "Set(1)")
@@ -17,7 +17,9 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/maxatome/go-testdeep/helpers/tdutil"
 	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/dark"
 	"github.com/maxatome/go-testdeep/internal/types"
 	"github.com/maxatome/go-testdeep/internal/util"
 )
@@ -65,22 +67,35 @@ var _ TestDeep = &tdSmuggle{}
 type smuggleValue struct {
 	Path  string
 	Value reflect.Value
+	// Paths, if not nil, are the concrete fields-paths of each item of
+	// Value, when Path contains [*] wildcards or ..Name recursive descents
+	Paths []string
 }
 
 var smuggleValueType = reflect.TypeOf(smuggleValue{})
 
 type smuggleField struct {
-	Name    string
-	Indexed bool
-	Method  bool
+	Name      string
+	Indexed   bool
+	Method    bool
+	Wildcard  bool // [*], Indexed is also true ([\*] is the "*" key)
+	Recursive bool // ..Name
 }
 
 func joinFieldsPath(path []smuggleField) string {
 	var buf strings.Builder
 	for i, part := range path {
-		if part.Indexed {
-			fmt.Fprintf(&buf, "[%s]", part.Name)
-		} else {
+		switch {
+		case part.Indexed:
+			name := part.Name
+			if name == "*" && !part.Wildcard {
+				name = `\*`
+			}
+			fmt.Fprintf(&buf, "[%s]", name)
+		case part.Recursive:
+			buf.WriteString("..")
+			buf.WriteString(part.Name)
+		default:
 			if i > 0 {
 				buf.WriteByte('.')
 			}
@@ -93,14 +108,22 @@ func joinFieldsPath(path []smuggleField) string {
 	return buf.String()
 }
 
+// appendField returns a new fields-path made of path followed by
+// field, path being never modified.
+func appendField(path []smuggleField, field smuggleField) []smuggleField {
+	return append(path[:len(path):len(path)], field)
+}
+
 func splitFieldsPath(origPath string) ([]smuggleField, error) {
 	if origPath == "" {
 		return nil, fmt.Errorf("FIELDS_PATH cannot be empty")
 	}
 
 	privateField := ""
+	afterRecursive := false
 	var res []smuggleField
 	for path := origPath; len(path) > 0; {
+		recursive := false
 		r, _ := utf8.DecodeRuneInString(path)
 		switch r {
 		case '[':
@@ -109,20 +132,32 @@ func splitFieldsPath(origPath string) ([]smuggleField, error) {
 			if end < 0 {
 				return nil, fmt.Errorf("cannot find final ']' in FIELDS_PATH %q", origPath)
 			}
-			res = append(res, smuggleField{Name: path[:end], Indexed: true})
+			field := smuggleField{
+				Name:     path[:end],
+				Indexed:  true,
+				Wildcard: path[:end] == "*",
+			}
+			if field.Name == `\*` { // escaped "*" key, not a wildcard
+				field.Name = "*"
+			}
+			res = append(res, field)
 			path = path[end+1:]
 
 		case '.':
-			if len(res) == 0 {
+			sep := "."
+			if strings.HasPrefix(path, "..") {
+				recursive, afterRecursive = true, true
+				sep = ".."
+			} else if len(res) == 0 {
 				return nil, fmt.Errorf("'.' cannot be the first rune in FIELDS_PATH %q", origPath)
 			}
-			path = path[1:]
+			path = path[len(sep):]
 			if path == "" {
-				return nil, fmt.Errorf("final '.' in FIELDS_PATH %q is not allowed", origPath)
+				return nil, fmt.Errorf("final '%s' in FIELDS_PATH %q is not allowed", sep, origPath)
 			}
 			r, _ = utf8.DecodeRuneInString(path)
 			if r == '.' || r == '[' {
-				return nil, fmt.Errorf("unexpected %q after '.' in FIELDS_PATH %q", r, origPath)
+				return nil, fmt.Errorf("unexpected %q after '%s' in FIELDS_PATH %q", r, sep, origPath)
 			}
 			fallthrough
 
@@ -150,6 +185,9 @@ func splitFieldsPath(origPath string) ([]smuggleField, error) {
 				if privateField != "" {
 					return nil, fmt.Errorf("cannot call method %s as it is based on an unexported field %q in FIELDS_PATH %q", field, privateField, origPath)
 				}
+				if afterRecursive {
+					return nil, fmt.Errorf("cannot call method %s after a '..' recursive descent in FIELDS_PATH %q", field, origPath)
+				}
 				res = append(res, smuggleField{Name: field[:len(field)-2], Method: true})
 			} else {
 				for j, r := range field {
@@ -160,7 +198,7 @@ func splitFieldsPath(origPath string) ([]smuggleField, error) {
 						return nil, fmt.Errorf("unexpected %q in field name %q in FIELDS_PATH %q", r, field, origPath)
 					}
 				}
-				res = append(res, smuggleField{Name: field})
+				res = append(res, smuggleField{Name: field, Recursive: recursive})
 			}
 		}
 	}
@@ -171,200 +209,435 @@ func nilFieldErr(path []smuggleField) error {
 	return fmt.Errorf("field %q is nil", joinFieldsPath(path))
 }
 
-func buildFieldsPathFn(path string) (func(any) (smuggleValue, error), error) {
-	parts, err := splitFieldsPath(path)
-	if err != nil {
-		return nil, err
+func notIndexableFieldErr(vgot reflect.Value, done []smuggleField) error {
+	if len(done) == 0 {
+		return fmt.Errorf("it is a %s, but a map, array or slice is expected",
+			vgot.Kind())
 	}
+	return fmt.Errorf(
+		"field %q is a %s, but a map, array or slice is expected",
+		joinFieldsPath(done), vgot.Kind())
+}
 
-	return func(got any) (smuggleValue, error) {
-		vgot := reflect.ValueOf(got)
-
-		for idxPart, field := range parts {
-			if field.Method {
-				var method reflect.Value
-				for {
-					method = vgot.MethodByName(field.Name)
-					if !method.IsValid() {
-						switch vgot.Kind() {
-						case reflect.Interface, reflect.Ptr:
-							if !vgot.IsNil() {
-								vgot = vgot.Elem()
-								continue
-							}
-							return smuggleValue{}, nilFieldErr(parts[:idxPart])
-						}
-						if idxPart > 0 {
-							return smuggleValue{}, fmt.Errorf(
-								"field %s (type %s) does not implement %s() method",
-								joinFieldsPath(parts[:idxPart]),
-								vgot.Type(),
-								field.Name)
-						}
-						return smuggleValue{}, fmt.Errorf(
-							"type %s has no method %s()", vgot.Type(), field.Name)
-					}
-					break
-				}
-				mt := method.Type()
-				if mt.NumIn() != 0 ||
-					(mt.NumOut() != 1 && (mt.NumOut() != 2 || mt.Out(1) != types.Error)) {
-					return smuggleValue{}, fmt.Errorf(
-						"cannot call %s, signature %s not handled, only func() A or func() (A, error) allowed",
-						joinFieldsPath(parts[:idxPart+1]),
-						method.Type())
-				}
-				var ret []reflect.Value
-				var panicked any
-				func() {
-					defer func() { panicked = recover() }()
-					ret = method.Call(nil)
-				}()
-				if panicked != nil {
-					return smuggleValue{}, fmt.Errorf(
-						"method %s panicked: %v",
-						joinFieldsPath(parts[:idxPart+1]),
-						panicked)
-				}
-				if len(ret) == 2 && !ret[1].IsNil() {
-					return smuggleValue{}, fmt.Errorf(
-						"method %s returned an error: %w",
-						joinFieldsPath(parts[:idxPart+1]),
-						ret[1].Interface().(error))
-				}
-				vgot = ret[0]
-				continue
-			}
+// derefFieldsPath resolves all interface and pointer dereferences of
+// vgot, reached using done fields-path.
+func derefFieldsPath(vgot reflect.Value, done []smuggleField) (reflect.Value, error) {
+	for vgot.Kind() == reflect.Interface || vgot.Kind() == reflect.Ptr {
+		if vgot.IsNil() {
+			return reflect.Value{}, nilFieldErr(done)
+		}
+		vgot = vgot.Elem()
+	}
+	return vgot, nil
+}
 
-			// Resolve all interface and pointer dereferences
-			origKind := vgot.Kind()
-			for {
-				switch vgot.Kind() {
-				case reflect.Interface, reflect.Ptr:
-					if vgot.IsNil() {
-						return smuggleValue{}, nilFieldErr(parts[:idxPart])
-					}
+// callFieldsPathMethod calls the method field of vgot, reached using
+// done fields-path.
+func callFieldsPathMethod(vgot reflect.Value, field smuggleField, done []smuggleField) (reflect.Value, error) {
+	var method reflect.Value
+	for {
+		method = vgot.MethodByName(field.Name)
+		if !method.IsValid() {
+			switch vgot.Kind() {
+			case reflect.Interface, reflect.Ptr:
+				if !vgot.IsNil() {
 					vgot = vgot.Elem()
 					continue
 				}
-				break
+				return reflect.Value{}, nilFieldErr(done)
 			}
+			if len(done) > 0 {
+				return reflect.Value{}, fmt.Errorf(
+					"field %s (type %s) does not implement %s() method",
+					joinFieldsPath(done),
+					vgot.Type(),
+					field.Name)
+			}
+			return reflect.Value{}, fmt.Errorf(
+				"type %s has no method %s()", vgot.Type(), field.Name)
+		}
+		break
+	}
+	mt := method.Type()
+	if mt.NumIn() != 0 ||
+		(mt.NumOut() != 1 && (mt.NumOut() != 2 || mt.Out(1) != types.Error)) {
+		return reflect.Value{}, fmt.Errorf(
+			"cannot call %s, signature %s not handled, only func() A or func() (A, error) allowed",
+			joinFieldsPath(appendField(done, field)),
+			method.Type())
+	}
+	var ret []reflect.Value
+	var panicked any
+	func() {
+		defer func() { panicked = recover() }()
+		ret = method.Call(nil)
+	}()
+	if panicked != nil {
+		return reflect.Value{}, fmt.Errorf(
+			"method %s panicked: %v",
+			joinFieldsPath(appendField(done, field)),
+			panicked)
+	}
+	if len(ret) == 2 && !ret[1].IsNil() {
+		return reflect.Value{}, fmt.Errorf(
+			"method %s returned an error: %w",
+			joinFieldsPath(appendField(done, field)),
+			ret[1].Interface().(error))
+	}
+	return ret[0], nil
+}
 
-			if !field.Indexed {
-				if vgot.Kind() == reflect.Struct {
-					vgot = vgot.FieldByName(field.Name)
-					if !vgot.IsValid() {
-						return smuggleValue{}, fmt.Errorf(
-							"field %q not found",
-							joinFieldsPath(parts[:idxPart+1]))
-					}
-					continue
-				}
-				// Accept map but only map[string]…
-				if vgot.Kind() != reflect.Map ||
-					vgot.Type().Key().Kind() != reflect.String {
-					deref := ""
-					if origKind != vgot.Kind() {
-						deref = " (after dereferencing)"
-					}
-					if idxPart == 0 {
-						return smuggleValue{}, fmt.Errorf(
-							"it is a %s%s and should be a struct or a map[string]…",
-							vgot.Kind(), deref)
-					}
-					if parts[idxPart-1].Method {
-						return smuggleValue{}, fmt.Errorf(
-							"method %s returned a %s%s and should be a struct or a map[string]…",
-							joinFieldsPath(parts[:idxPart]), vgot.Kind(), deref)
-					}
-					return smuggleValue{}, fmt.Errorf(
-						"field %q is a %s%s and should be a struct or a map[string]…",
-						joinFieldsPath(parts[:idxPart]), vgot.Kind(), deref)
-				}
+// followFieldsPath returns the field, key or item field of vgot,
+// reached using done fields-path.
+func followFieldsPath(vgot reflect.Value, field smuggleField, done []smuggleField) (reflect.Value, error) {
+	origKind := vgot.Kind()
+	vgot, err := derefFieldsPath(vgot, done)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if !field.Indexed {
+		if vgot.Kind() == reflect.Struct {
+			vgot = vgot.FieldByName(field.Name)
+			if !vgot.IsValid() {
+				return reflect.Value{}, fmt.Errorf(
+					"field %q not found",
+					joinFieldsPath(appendField(done, field)))
+			}
+			return vgot, nil
+		}
+		// Accept map but only map[string]…
+		if vgot.Kind() != reflect.Map ||
+			vgot.Type().Key().Kind() != reflect.String {
+			deref := ""
+			if origKind != vgot.Kind() {
+				deref = " (after dereferencing)"
 			}
+			if len(done) == 0 {
+				return reflect.Value{}, fmt.Errorf(
+					"it is a %s%s and should be a struct or a map[string]…",
+					vgot.Kind(), deref)
+			}
+			if done[len(done)-1].Method {
+				return reflect.Value{}, fmt.Errorf(
+					"method %s returned a %s%s and should be a struct or a map[string]…",
+					joinFieldsPath(done), vgot.Kind(), deref)
+			}
+			return reflect.Value{}, fmt.Errorf(
+				"field %q is a %s%s and should be a struct or a map[string]…",
+				joinFieldsPath(done), vgot.Kind(), deref)
+		}
+	}
 
-			switch vgot.Kind() {
-			case reflect.Map:
-				tkey := vgot.Type().Key()
-				var vkey reflect.Value
-				switch tkey.Kind() {
-				case reflect.String:
-					vkey = reflect.ValueOf(field.Name)
-				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-					i, err := strconv.ParseInt(field.Name, 10, 64)
-					if err != nil {
-						return smuggleValue{}, fmt.Errorf(
-							"field %q, %q is not an integer and so cannot match %s map key type",
-							joinFieldsPath(parts[:idxPart+1]), field.Name, tkey)
-					}
-					vkey = reflect.ValueOf(i).Convert(tkey)
-				case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-					i, err := strconv.ParseUint(field.Name, 10, 64)
-					if err != nil {
-						return smuggleValue{}, fmt.Errorf(
-							"field %q, %q is not an unsigned integer and so cannot match %s map key type",
-							joinFieldsPath(parts[:idxPart+1]), field.Name, tkey)
-					}
-					vkey = reflect.ValueOf(i).Convert(tkey)
-				case reflect.Float32, reflect.Float64:
-					f, err := strconv.ParseFloat(field.Name, 64)
-					if err != nil {
-						return smuggleValue{}, fmt.Errorf(
-							"field %q, %q is not a float and so cannot match %s map key type",
-							joinFieldsPath(parts[:idxPart+1]), field.Name, tkey)
-					}
-					vkey = reflect.ValueOf(f).Convert(tkey)
-				case reflect.Complex64, reflect.Complex128:
-					c, err := strconv.ParseComplex(field.Name, 128)
+	switch vgot.Kind() {
+	case reflect.Map:
+		tkey := vgot.Type().Key()
+		var vkey reflect.Value
+		switch tkey.Kind() {
+		case reflect.String:
+			vkey = reflect.ValueOf(field.Name)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i, err := strconv.ParseInt(field.Name, 10, 64)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf(
+					"field %q, %q is not an integer and so cannot match %s map key type",
+					joinFieldsPath(appendField(done, field)), field.Name, tkey)
+			}
+			vkey = reflect.ValueOf(i).Convert(tkey)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			i, err := strconv.ParseUint(field.Name, 10, 64)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf(
+					"field %q, %q is not an unsigned integer and so cannot match %s map key type",
+					joinFieldsPath(appendField(done, field)), field.Name, tkey)
+			}
+			vkey = reflect.ValueOf(i).Convert(tkey)
+		case reflect.Float32, reflect.Float64:
+			f, err := strconv.ParseFloat(field.Name, 64)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf(
+					"field %q, %q is not a float and so cannot match %s map key type",
+					joinFieldsPath(appendField(done, field)), field.Name, tkey)
+			}
+			vkey = reflect.ValueOf(f).Convert(tkey)
+		case reflect.Complex64, reflect.Complex128:
+			c, err := strconv.ParseComplex(field.Name, 128)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf(
+					"field %q, %q is not a complex number and so cannot match %s map key type",
+					joinFieldsPath(appendField(done, field)), field.Name, tkey)
+			}
+			vkey = reflect.ValueOf(c).Convert(tkey)
+		default:
+			return reflect.Value{}, fmt.Errorf(
+				"field %q, %q cannot match unsupported %s map key type",
+				joinFieldsPath(appendField(done, field)), field.Name, tkey)
+		}
+		vgot = vgot.MapIndex(vkey)
+		if !vgot.IsValid() {
+			return reflect.Value{}, fmt.Errorf("field %q, %q map key not found",
+				joinFieldsPath(appendField(done, field)), field.Name)
+		}
+
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.ParseInt(field.Name, 10, 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf(
+				"field %q, %q is not a slice/array index",
+				joinFieldsPath(appendField(done, field)), field.Name)
+		}
+		if i < 0 {
+			i = int64(vgot.Len()) + i
+		}
+		if i < 0 || i >= int64(vgot.Len()) {
+			return reflect.Value{}, fmt.Errorf(
+				"field %q, %d is out of slice/array range (len %d)",
+				joinFieldsPath(appendField(done, field)), i, vgot.Len())
+		}
+		vgot = vgot.Index(int(i))
+
+	default:
+		return reflect.Value{}, notIndexableFieldErr(vgot, done)
+	}
+	return vgot, nil
+}
+
+// walkFieldsPath follows parts fields-path from vgot, reached using
+// done fields-path, and calls emit for each value found at its
+// end. Several values can only be found if parts contains [*]
+// wildcards or ..Name recursive descents.
+func walkFieldsPath(vgot reflect.Value, parts, done []smuggleField, emit func(reflect.Value, []smuggleField)) error {
+	for idxPart, field := range parts {
+		var err error
+		switch {
+		case field.Method:
+			vgot, err = callFieldsPathMethod(vgot, field, done)
+
+		case field.Wildcard:
+			return walkFieldsPathWildcard(vgot, parts[idxPart+1:], done, emit)
+
+		case field.Recursive:
+			return walkFieldsPathRecursive(vgot, field.Name, parts[idxPart+1:], done,
+				fieldsPathVisits{}, emit)
+
+		default:
+			vgot, err = followFieldsPath(vgot, field, done)
+		}
+		if err != nil {
+			return err
+		}
+		done = appendField(done, field)
+	}
+	emit(vgot, done)
+	return nil
+}
+
+// walkFieldsPathWildcard follows next fields-path from each item of
+// vgot map, slice or array. Map items are visited in their keys
+// order.
+func walkFieldsPathWildcard(vgot reflect.Value, next, done []smuggleField, emit func(reflect.Value, []smuggleField)) error {
+	vgot, err := derefFieldsPath(vgot, done)
+	if err != nil {
+		return err
+	}
+
+	switch vgot.Kind() {
+	case reflect.Map:
+		for _, vkey := range tdutil.MapSortedKeys(vgot) {
+			err = walkFieldsPath(vgot.MapIndex(vkey), next,
+				appendField(done, smuggleField{Name: fmt.Sprint(vkey), Indexed: true}),
+				emit)
+			if err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < vgot.Len(); i++ {
+			err = walkFieldsPath(vgot.Index(i), next,
+				appendField(done, smuggleField{Name: strconv.Itoa(i), Indexed: true}),
+				emit)
+			if err != nil {
+				return err
+			}
+		}
+
+	default:
+		return notIndexableFieldErr(vgot, done)
+	}
+	return nil
+}
+
+type fieldsPathVisit struct {
+	ptr uintptr
+	len int // for slices
+	typ reflect.Type
+}
+
+type fieldsPathVisits map[fieldsPathVisit]bool
+
+// visit records vgot, a non-nil pointer, map or slice, in
+// visited. It returns false if vgot has already been visited.
+func (visited fieldsPathVisits) visit(vgot reflect.Value) bool {
+	v := fieldsPathVisit{ptr: vgot.Pointer(), typ: vgot.Type()}
+	if vgot.Kind() == reflect.Slice {
+		v.len = vgot.Len()
+	}
+	if visited[v] {
+		return false
+	}
+	visited[v] = true
+	return true
+}
+
+// walkFieldsPathRecursive follows next fields-path from vgot name
+// field or map[string]… key, then recursively does the same for each
+// field or item of vgot. Contrary to other fields-path steps, nil
+// values are silently skipped and each pointed value, map or slice
+// is visited only once.
+func walkFieldsPathRecursive(vgot reflect.Value, name string, next, done []smuggleField, visited fieldsPathVisits, emit func(reflect.Value, []smuggleField)) error {
+	for vgot.Kind() == reflect.Interface || vgot.Kind() == reflect.Ptr {
+		if vgot.IsNil() {
+			return nil
+		}
+		if vgot.Kind() == reflect.Ptr && !visited.visit(vgot) {
+			return nil
+		}
+		vgot = vgot.Elem()
+	}
+
+	switch vgot.Kind() {
+	case reflect.Map, reflect.Slice:
+		if vgot.IsNil() || !visited.visit(vgot) {
+			return nil
+		}
+	}
+
+	switch vgot.Kind() {
+	case reflect.Struct:
+		// Only direct fields, promoted ones are reached later when
+		// descending into embedded structs
+		if sf, ok := vgot.Type().FieldByName(name); ok && len(sf.Index) == 1 {
+			err := walkFieldsPath(vgot.Field(sf.Index[0]), next,
+				appendField(done, smuggleField{Name: name}), emit)
+			if err != nil {
+				return err
+			}
+		}
+		for i := 0; i < vgot.NumField(); i++ {
+			err := walkFieldsPathRecursive(vgot.Field(i), name, next,
+				appendField(done, smuggleField{Name: vgot.Type().Field(i).Name}),
+				visited, emit)
+			if err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		keys := tdutil.MapSortedKeys(vgot)
+		if vgot.Type().Key().Kind() == reflect.String {
+			for _, vkey := range keys {
+				if vkey.String() == name {
+					err := walkFieldsPath(vgot.MapIndex(vkey), next,
+						appendField(done, smuggleField{Name: name}), emit)
 					if err != nil {
-						return smuggleValue{}, fmt.Errorf(
-							"field %q, %q is not a complex number and so cannot match %s map key type",
-							joinFieldsPath(parts[:idxPart+1]), field.Name, tkey)
+						return err
 					}
-					vkey = reflect.ValueOf(c).Convert(tkey)
-				default:
-					return smuggleValue{}, fmt.Errorf(
-						"field %q, %q cannot match unsupported %s map key type",
-						joinFieldsPath(parts[:idxPart+1]), field.Name, tkey)
-				}
-				vgot = vgot.MapIndex(vkey)
-				if !vgot.IsValid() {
-					return smuggleValue{}, fmt.Errorf("field %q, %q map key not found",
-						joinFieldsPath(parts[:idxPart+1]), field.Name)
+					break
 				}
+			}
+		}
+		for _, vkey := range keys {
+			err := walkFieldsPathRecursive(vgot.MapIndex(vkey), name, next,
+				appendField(done, smuggleField{Name: fmt.Sprint(vkey), Indexed: true}),
+				visited, emit)
+			if err != nil {
+				return err
+			}
+		}
 
-			case reflect.Slice, reflect.Array:
-				i, err := strconv.ParseInt(field.Name, 10, 64)
-				if err != nil {
-					return smuggleValue{}, fmt.Errorf(
-						"field %q, %q is not a slice/array index",
-						joinFieldsPath(parts[:idxPart+1]), field.Name)
-				}
-				if i < 0 {
-					i = int64(vgot.Len()) + i
-				}
-				if i < 0 || i >= int64(vgot.Len()) {
-					return smuggleValue{}, fmt.Errorf(
-						"field %q, %d is out of slice/array range (len %d)",
-						joinFieldsPath(parts[:idxPart+1]), i, vgot.Len())
-				}
-				vgot = vgot.Index(int(i))
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < vgot.Len(); i++ {
+			err := walkFieldsPathRecursive(vgot.Index(i), name, next,
+				appendField(done, smuggleField{Name: strconv.Itoa(i), Indexed: true}),
+				visited, emit)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
 
-			default:
-				if idxPart == 0 {
-					return smuggleValue{},
-						fmt.Errorf("it is a %s, but a map, array or slice is expected",
-							vgot.Kind())
+// fieldsPathSlice returns values as a slice. Its items type is the
+// common type of values, or any if they differ or if there is no
+// value at all.
+func fieldsPathSlice(values []reflect.Value) reflect.Value {
+	typ := types.Interface
+	if len(values) > 0 {
+		typ = values[0].Type()
+		for _, v := range values[1:] {
+			if v.Type() != typ {
+				typ = types.Interface
+				break
+			}
+		}
+	}
+
+	s := reflect.MakeSlice(reflect.SliceOf(typ), len(values), len(values))
+	for i, v := range values {
+		if !v.CanInterface() {
+			v = reflect.ValueOf(dark.MustGetInterface(v))
+			if !v.IsValid() {
+				continue // nil interface
+			}
+		}
+		s.Index(i).Set(v)
+	}
+	return s
+}
+
+func buildFieldsPathFn(path string) (func(any) (smuggleValue, error), error) {
+	parts, err := splitFieldsPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	multi := false
+	for _, part := range parts {
+		if part.Wildcard || part.Recursive {
+			multi = true
+			break
+		}
+	}
+
+	return func(got any) (smuggleValue, error) {
+		var (
+			values []reflect.Value
+			paths  []string
+		)
+		err := walkFieldsPath(reflect.ValueOf(got), parts, nil,
+			func(v reflect.Value, done []smuggleField) {
+				values = append(values, v)
+				if multi {
+					paths = append(paths, joinFieldsPath(done))
 				}
-				return smuggleValue{}, fmt.Errorf(
-					"field %q is a %s, but a map, array or slice is expected",
-					joinFieldsPath(parts[:idxPart]), vgot.Kind())
+			})
+		if err != nil {
+			return smuggleValue{}, err
+		}
+
+		sv := smuggleValue{Path: path}
+		if multi {
+			sv.Value = fieldsPathSlice(values)
+			sv.Paths = paths
+			if sv.Paths == nil {
+				sv.Paths = []string{}
 			}
+		} else {
+			sv.Value = values[0]
 		}
-		return smuggleValue{
-			Path:  path,
-			Value: vgot,
-		}, nil
+		return sv, nil
 	}, nil
 }
 
@@ -824,6 +1097,37 @@ func smuggle(depth int, fn any, fnsThenExpectedValue []any) TestDeep {
 // or [foo]). Maps work only for simple key types (string or numbers),
 // without "" when using strings (e.g. [foo]).
 //
+// All items of an array, a slice or a map can be addressed at once
+// using the [*] wildcard, and ..Name recursively collects all struct
+// fields and map[string]… values named Name at any depth. In both
+// cases, the collected values are compared as a slice, typed after
+// them if they all share the same type, []any otherwise (or if no
+// value is collected at all). Map items are visited in the order of
+// their keys:
+//
+//	type Item struct{ Name string; Price float64 }
+//	got := struct{ Items []Item }{
+//	  Items: []Item{{"pen", 1.5}, {"desk", 150}},
+//	}
+//	td.Cmp(t, got, td.Smuggle("Items[*].Price", []float64{1.5, 150}))
+//	td.Cmp(t, got, td.Smuggle("..Name", td.Bag("desk", "pen")))
+//
+// When following a fields-path fails after a wildcard, the error
+// reports the concrete index or key (e.g. Items[1].Price), as does a
+// mismatch of a collected value. Contrary to [*], ..Name silently
+// skips nil values and no method can be called after it. It
+// traverses each pointer, map or slice only once, so cyclic data is
+// supported.
+//
+// Incompatibility: before the introduction of these features, [*]
+// addressed the map key "*" and "foo..bar" was an invalid
+// fields-path. Existing fields-paths using [*] to reach a "*" key
+// now collect all the items instead, and have to use [\*]:
+//
+//	got := map[string]int{"*": 1, "a": 2}
+//	td.Cmp(t, got, td.Smuggle(`[\*]`, 1))          // succeeds
+//	td.Cmp(t, got, td.Smuggle("[*]", []int{1, 2})) // succeeds
+//
 // Behind the scenes, a temporary function is automatically created to
 // achieve the same goal, but adds some checks against nil values and
 // auto-dereferences interfaces and pointers, even on several levels,
@@ -936,7 +1240,10 @@ func (s *tdSmuggle) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 		(ret[1].Kind() == reflect.Interface && ret[1].IsNil()) {
 		newGot := ret[0]
 
-		var newCtx ctxerr.Context
+		var (
+			newCtx ctxerr.Context
+			paths  []string
+		)
 		if newGot.IsValid() {
 			switch newGot.Type() {
 			case smuggledGotType:
@@ -951,13 +1258,17 @@ func (s *tdSmuggle) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 
 			case smuggleValueType:
 				smv := newGot.Interface().(smuggleValue)
-				newCtx, newGot = ctx.AddCustomLevel("."+smv.Path), smv.Value
+				newCtx, newGot = ctx.AddCustomLevel(fieldsPathLevel(smv.Path)), smv.Value
+				paths = smv.Paths
 
 			default:
 				newCtx = ctx.AddCustomLevel(smuggled)
 			}
 		}
-		return deepValueEqual(newCtx, newGot, s.expectedValue)
+		if paths == nil || ctx.BooleanError {
+			return deepValueEqual(newCtx, newGot, s.expectedValue)
+		}
+		return matchFieldsPathValues(ctx, newCtx, newGot, s.expectedValue, paths)
 	}
 
 	if ctx.BooleanError {
@@ -985,6 +1296,56 @@ func (s *tdSmuggle) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 	})
 }
 
+// fieldsPathLevel returns the path level corresponding to path
+// fields-path.
+func fieldsPathLevel(path string) string {
+	if strings.HasPrefix(path, "..") {
+		return path
+	}
+	return "." + path
+}
+
+// matchFieldsPathValues compares got, the values collected by a
+// fields-path containing [*] wildcards or ..Name recursive descents
+// from ctx, against expected. The path of an error occurring on the
+// i-th value is rewritten to use paths[i], its concrete location,
+// as DATA.Items[1].Name instead of DATA.Items[*].Name[1].
+func matchFieldsPathValues(ctx, newCtx ctxerr.Context, got, expected reflect.Value, paths []string) *ctxerr.Error {
+	var numErrors int
+	if ctx.Errors != nil {
+		numErrors = len(*ctx.Errors)
+	}
+
+	err := deepValueEqual(newCtx, got, expected)
+
+	concrete := func(i int) (ctxerr.Path, bool) {
+		if i < 0 || i >= len(paths) {
+			return nil, false
+		}
+		return ctx.Path.AddCustomLevel(fieldsPathLevel(paths[i])), true
+	}
+	var rewrite func(*ctxerr.Error)
+	rewrite = func(e *ctxerr.Error) {
+		for ; e != nil; e = e.Next {
+			if e == ctxerr.ErrTooManyErrors {
+				continue
+			}
+			if path, ok := e.Context.Path.ReplaceIndexedPrefix(newCtx.Path, concrete); ok {
+				e.Context.Path = path
+			}
+			rewrite(e.Origin)
+		}
+	}
+	if ctx.Errors != nil {
+		// Collected errors, not linked together yet
+		for _, e := range (*ctx.Errors)[numErrors:] {
+			rewrite(e)
+		}
+	}
+	rewrite(err)
+	return err
+}
+
 func (s *tdSmuggle) HandleInvalid() bool {
 	return true // Knows how to handle untyped nil values (aka invalid values)
 }
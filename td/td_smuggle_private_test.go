@@ -55,6 +55,24 @@ func TestFieldsPath(t *testing.T) {
 
 	check("[foo][bar]", "foo", "bar")
 	check("[0][foo][bar]", "0", "foo", "bar")
+	check("test[*].foo", "test", "*", "foo")
+	check("test..foo[*]", "test", "foo", "*")
+	check("..foo.bar", "foo", "bar")
+	// "foo..bar" was an error before ..Name recursive descents, see the
+	// "foo...bar" and "foo..[bar]" errors below for the replacing checks
+	check("foo..bar", "foo", "bar")
+	check(`test[\*].foo`, "test", "*", "foo")
+
+	got, err = splitFieldsPath("test[*]..foo[x]")
+	test.NoError(t, err)
+	test.IsTrue(t, got[1].Indexed && got[1].Wildcard)
+	test.IsTrue(t, got[2].Recursive && !got[2].Indexed)
+	test.IsFalse(t, got[3].Wildcard)
+
+	got, err = splitFieldsPath(`[\*][*]`)
+	test.NoError(t, err)
+	test.IsTrue(t, got[0].Indexed && !got[0].Wildcard)
+	test.IsTrue(t, got[1].Indexed && got[1].Wildcard)
 
 	//
 	// Errors
@@ -71,7 +89,11 @@ func TestFieldsPath(t *testing.T) {
 	checkErr("", "FIELDS_PATH cannot be empty")
 	checkErr(".test", `'.' cannot be the first rune in FIELDS_PATH ".test"`)
 	checkErr("foo.bar.", `final '.' in FIELDS_PATH "foo.bar." is not allowed`)
-	checkErr("foo..bar", `unexpected '.' after '.' in FIELDS_PATH "foo..bar"`)
+	checkErr("foo...bar", `unexpected '.' after '..' in FIELDS_PATH "foo...bar"`)
+	checkErr("foo..[bar]", `unexpected '[' after '..' in FIELDS_PATH "foo..[bar]"`)
+	checkErr("foo..", `final '..' in FIELDS_PATH "foo.." is not allowed`)
+	checkErr("Foo..Bar()", `cannot call method Bar() after a '..' recursive descent in FIELDS_PATH "Foo..Bar()"`)
+	checkErr("..Foo.Bar.Zip()", `cannot call method Zip() after a '..' recursive descent in FIELDS_PATH "..Foo.Bar.Zip()"`)
 	checkErr("foo.[bar]", `unexpected '[' after '.' in FIELDS_PATH "foo.[bar]"`)
 	checkErr("foo[bar", `cannot find final ']' in FIELDS_PATH "foo[bar"`)
 	checkErr("test.%foo", `unexpected '%' in field name "%foo" in FIELDS_PATH "test.%foo"`)
@@ -899,6 +899,132 @@ it failed coz: method Next.MayFollowIface() returned an error: Iface is nil`),
 		td.Smuggle(`Interface().Elem().Interface().Field.Path`, "pipo"))
 }
 
+func TestSmuggleFieldsPathWildcard(t *testing.T) {
+	type Item struct {
+		Name  string
+		Price float64
+		Attrs map[string]any
+	}
+	type Store struct {
+		Items []Item
+		ByRef map[string]*Item
+		items []Item
+		Owner *Item
+	}
+	pen := Item{Name: "pen", Price: 1.5, Attrs: map[string]any{"Name": "blue pen"}}
+	desk := Item{Name: "desk", Price: 150}
+	got := Store{
+		Items: []Item{pen, desk},
+		ByRef: map[string]*Item{"z": &pen, "a": &desk},
+		items: []Item{desk},
+	}
+
+	checkOK(t, got, td.Smuggle("Items[*].Name", []string{"pen", "desk"}))
+	checkOK(t, &got, td.Smuggle("Items[*].Price", []float64{1.5, 150}))
+	checkOK(t, got, td.Smuggle("ByRef[*].Name", []string{"desk", "pen"})) // sorted keys
+	checkOK(t, got, td.Smuggle("items[*].Name", []string{"desk"}))
+	checkOK(t, got, td.Smuggle("Items[*]", []Item{pen, desk}))
+	checkOK(t, got, td.Smuggle("Items[*].Attrs[*]", []any{"blue pen"}))
+	checkOK(t, Store{}, td.Smuggle("Items[*].Name", []any{}))
+	checkOK(t, got, td.Smuggle("Items[*].Name", td.Bag("desk", "pen")))
+
+	// Escaped "*" key
+	stars := map[string]int{"*": 1, "a": 2}
+	checkOK(t, stars, td.Smuggle(`[\*]`, 1))
+	checkOK(t, stars, td.Smuggle("[*]", []int{1, 2}))
+	checkOK(t, map[string]map[string]int{"*": stars}, td.Smuggle(`[\*][\*]`, 1))
+
+	// Recursive descent
+	checkOK(t, got, td.Smuggle("..Price", []float64{1.5, 150, 150, 1.5, 150}))
+	checkOK(t, got, td.Smuggle("..Name", // Attrs map shared by Items[0] & ByRef[z]
+		[]any{"pen", "blue pen", "desk", "desk", "pen", "desk"}))
+	checkOK(t, got, td.Smuggle("Items..Name", []any{"pen", "blue pen", "desk"}))
+	checkOK(t, got, td.Smuggle("..Attrs[*]", []any{"blue pen", "blue pen"}))
+	checkOK(t, got, td.Smuggle("..Unknown", td.Empty()))
+	checkOK(t, nil, td.Smuggle("..Unknown", td.Empty()))
+
+	type Node struct {
+		Val  int
+		Next *Node
+	}
+	loop := &Node{Val: 1, Next: &Node{Val: 2}}
+	loop.Next.Next = loop // pointed items are visited once
+	checkOK(t, loop, td.Smuggle("..Val", []int{1, 2}))
+
+	// so are maps and slices
+	m := map[string]any{"Name": "x"}
+	m["self"] = m
+	checkOK(t, m, td.Smuggle("..Name", []any{"x"}))
+	s := []any{map[string]any{"Name": "y"}, nil}
+	s[1] = s
+	checkOK(t, s, td.Smuggle("..Name", []any{"y"}))
+	checkOK(t, s, td.Smuggle("[0]..Name", []any{"y"}))
+
+	// Errors keep the concrete index
+	checkError(t, got, td.Smuggle("Items[*].Attrs.Name", []string{"blue pen"}),
+		expectedError{
+			Message: mustBe("ran smuggle code with %% as argument"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain(`
+it failed coz: field "Items[1].Attrs.Name", "Name" map key not found`),
+		})
+	checkError(t, Store{ByRef: map[string]*Item{"x": nil}}, td.Smuggle("ByRef[*].Name", 42),
+		expectedError{
+			Message: mustBe("ran smuggle code with %% as argument"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain(`
+it failed coz: field "ByRef[x]" is nil`),
+		})
+	checkError(t, got, td.Smuggle("Owner[*]", 42),
+		expectedError{
+			Message: mustBe("ran smuggle code with %% as argument"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain(`
+it failed coz: field "Owner" is nil`),
+		})
+	checkError(t, got, td.Smuggle("Items[0].Name[*]", 42),
+		expectedError{
+			Message: mustBe("ran smuggle code with %% as argument"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain(`
+it failed coz: field "Items[0].Name" is a string, but a map, array or slice is expected`),
+		})
+	checkError(t, got, td.Smuggle("..Attrs.Name.Foo", 42),
+		expectedError{
+			Message: mustBe("ran smuggle code with %% as argument"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain(`
+it failed coz: field "Items[0].Attrs.Name" is a string (after dereferencing) and should be a struct or a map[string]…`),
+		})
+
+	checkError(t, got, td.Smuggle("Items[*].Name", []string{"pen", "lamp"}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.Items[1].Name"),
+			Got:      mustBe(`"desk"`),
+			Expected: mustBe(`"lamp"`),
+		})
+	checkError(t, got, td.Smuggle("..Price", []float64{1.5, 150, 150, 1.5, 0}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.items[0].Price"),
+			Got:      mustBe("150.0"),
+			Expected: mustBe("0.0"),
+		})
+	checkError(t, got, td.Smuggle("ByRef[*]", td.ArrayEach(td.Struct(&Item{}, td.StructFields{"Price": 1.5}))),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.ByRef[a].Price"),
+			Got:      mustBe("150.0"),
+			Expected: mustBe("1.5"),
+		})
+	checkError(t, got, td.Smuggle("..Price", td.Len(2)),
+		expectedError{
+			Message: mustBe("bad length"),
+			Path:    mustBe("DATA..Price"),
+		})
+}
+
 func TestSmuggleTypeBehind(t *testing.T) {
 	// Type behind is the smuggle function parameter one
 
@@ -0,0 +1,236 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"bytes"
+	ejson "encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/dark"
+)
+
+// envUpdateSnapshots is the environment variable name used to force
+// [Snapshot] golden files regeneration.
+const envUpdateSnapshots = "TESTDEEP_UPDATE_SNAPSHOTS"
+
+// snapshotDir is the directory where [Snapshot] golden files live,
+// relative to the current directory, so the package one during go
+// test.
+const snapshotDir = "testdata"
+
+type tdSnapshot struct {
+	baseOKNil
+	name    string
+	file    string
+	params  []any
+	options jsonv2Options
+}
+
+var _ TestDeep = &tdSnapshot{}
+
+// summary(Snapshot): compares against a JSON golden file, creating or
+// updating it if needed
+// input(Snapshot): nil,bool,str,int,float,array,slice,map,struct,ptr
+
+// Snapshot operator compares the JSON representation of data against
+// the golden file testdata/name.json, as [JSON] operator does, except
+// that the golden file is written instead when:
+//   - it does not exist yet;
+//   - or the environment variable TESTDEEP_UPDATE_SNAPSHOTS is set to
+//     a true value as "1" or "true" (see [strconv.ParseBool]) and data
+//     does not match anymore the golden file.
+//
+// In both cases, Snapshot succeeds. The golden file is written using
+// [encoding/json] rules (or encoding/json/v2 ones if [JSONV2Options]
+// are passed in params), indented with 2 spaces.
+//
+//	td.Cmp(t, gotUser, td.Snapshot("user"))     // testdata/user.json
+//	td.Cmp(t, gotUser, td.Snapshot("api/user")) // testdata/api/user.json
+//
+// name can contain "/" to use sub-directories of testdata/, that are
+// created on the fly if needed, but no ".." element, so the golden
+// file cannot escape testdata/. If name ends with ".json", this
+// suffix is not doubled.
+//
+// The golden file is never written when Snapshot is evaluated as a
+// candidate, as inside [Any] or [Bag] operators, or by [EqDeeply].
+//
+// Once written, the golden file can be edited by hand, as any JSON
+// file used by [JSON] operator: comments, placeholders and operators
+// are allowed. It is the way to handle volatile fields as IDs or
+// timestamps:
+//
+//	{
+//	  "id":         NotZero(), // changes at each run
+//	  "name":       "Bob",
+//	  "created_at": $createdAt // placeholder set below
+//	}
+//
+//	td.Cmp(t, gotUser, td.Snapshot("user",
+//	  td.Tag("createdAt", td.Smuggle(parseTime, td.Between(before, after)))))
+//
+// params are the placeholders and options passed to [JSON] operator,
+// see it for details.
+//
+// As a golden file still matching data is never rewritten, even if
+// TESTDEEP_UPDATE_SNAPSHOTS is set, such hand-made changes survive
+// regenerations. On the contrary, they are lost each time a golden
+// file is rewritten.
+//
+// TypeBehind method always returns nil as the expected type cannot be
+// guessed before reading the golden file.
+//
+// See also [JSON].
+func Snapshot(name string, params ...any) TestDeep {
+	s := tdSnapshot{
+		baseOKNil: newBaseOKNil(3),
+		name:      name,
+		params:    params,
+	}
+
+	if name == "" {
+		s.err = ctxerr.OpBad("Snapshot", "usage: Snapshot(NAME, ...), NAME cannot be empty")
+		return &s
+	}
+
+	for _, part := range strings.Split(filepath.ToSlash(name), "/") {
+		if part == ".." {
+			s.err = ctxerr.OpBad("Snapshot",
+				`usage: Snapshot(NAME, ...), NAME cannot contain ".." elements`)
+			return &s
+		}
+	}
+
+	file := filepath.FromSlash(name)
+	if !strings.HasSuffix(file, ".json") {
+		file += ".json"
+	}
+	s.file = filepath.Join(snapshotDir, file)
+
+	for _, p := range params {
+		if opts, ok := p.(jsonv2Options); ok {
+			s.options = joinOptions(s.options, opts)
+		}
+	}
+	return &s
+}
+
+// updateSnapshots returns true if TESTDEEP_UPDATE_SNAPSHOTS
+// environment variable is set to a true value.
+func updateSnapshots() bool {
+	update, _ := strconv.ParseBool(os.Getenv(envUpdateSnapshots))
+	return update
+}
+
+// load reads and parses the golden file. exists is false if the
+// golden file does not exist.
+func (s *tdSnapshot) load() (expected reflect.Value, exists bool, err *ctxerr.Error) {
+	b, rerr := os.ReadFile(s.file)
+	if rerr != nil {
+		if errors.Is(rerr, fs.ErrNotExist) {
+			return
+		}
+		err = ctxerr.OpBad("Snapshot", "golden file %s cannot be read: %s", s.file, rerr)
+		return
+	}
+
+	ju := newJSONUnmarshaler(s.GetLocation())
+
+	// unmarshal alters params, so work on a copy as the golden file
+	// is read at each Match call
+	v, err := ju.unmarshal(b, append([]any(nil), s.params...))
+	if err != nil {
+		err.Summary = ctxerr.NewSummary(s.file + ": " + err.SummaryString())
+		return
+	}
+	return reflect.ValueOf(v), true, nil
+}
+
+// write writes the golden file using got.
+func (s *tdSnapshot) write(got reflect.Value) *ctxerr.Error {
+	gotIf, ok := dark.GetInterface(got, true)
+	if !ok {
+		return ctxerr.OpBad("Snapshot", "cannot write golden file %s: unexported data", s.file)
+	}
+
+	b, err := jsonMarshal(gotIf, s.options)
+	if err != nil {
+		return &ctxerr.Error{
+			Message: "json.Marshal failed",
+			Summary: ctxerr.NewSummary(err.Error()),
+		}
+	}
+
+	var buf bytes.Buffer
+	ejson.Indent(&buf, b, "", "  ") //nolint: errcheck
+	buf.WriteByte('\n')
+
+	err = os.MkdirAll(filepath.Dir(s.file), 0o755)
+	if err == nil {
+		err = os.WriteFile(s.file, buf.Bytes(), 0o644)
+	}
+	if err != nil {
+		return ctxerr.OpBad("Snapshot", "cannot write golden file %s: %s", s.file, err)
+	}
+	return nil
+}
+
+func (s *tdSnapshot) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if s.err != nil {
+		return ctx.CollectError(s.err)
+	}
+
+	expected, exists, err := s.load()
+	if err != nil {
+		return ctx.CollectError(err)
+	}
+
+	if exists {
+		gotJSON := got
+		err = gotViaJSON(ctx, &gotJSON, s.options)
+		if err != nil {
+			return ctx.CollectError(err)
+		}
+
+		ctx.BeLax = true
+
+		// Never rewrite the golden file while evaluating a candidate,
+		// as inside Any or Bag operators
+		if ctx.BooleanError || !updateSnapshots() {
+			return deepValueEqual(ctx, gotJSON, expected)
+		}
+
+		ok, err := deepValueEqualFinalOK(ctx, gotJSON, expected)
+		if err != nil || ok {
+			return err
+		}
+	}
+
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+
+	if err = s.write(got); err != nil {
+		return ctx.CollectError(err)
+	}
+	return nil
+}
+
+func (s *tdSnapshot) String() string {
+	if s.err != nil {
+		return s.stringError()
+	}
+	return "Snapshot(" + strconv.Quote(s.name) + ")"
+}
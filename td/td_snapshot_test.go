@@ -0,0 +1,201 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestSnapshot(t *testing.T) {
+	const dir = "snapshot_test"
+	t.Cleanup(func() {
+		os.RemoveAll(filepath.Join("testdata", dir)) //nolint: errcheck
+		os.Remove("testdata")                        //nolint: errcheck
+	})
+
+	type User struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	readGolden := func(t *testing.T, name string) string {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join("testdata", dir, name+".json"))
+		test.NoError(t, err)
+		return string(b)
+	}
+	writeGolden := func(t *testing.T, name, content string) {
+		t.Helper()
+		err := os.WriteFile(filepath.Join("testdata", dir, name+".json"), []byte(content), 0o644)
+		test.NoError(t, err)
+	}
+
+	t.Run("first run then compare", func(t *testing.T) {
+		setenv(t, "TESTDEEP_UPDATE_SNAPSHOTS", "")
+
+		checkOK(t, User{ID: 1, Name: "Bob"}, td.Snapshot(dir+"/user"))
+		test.EqualStr(t, readGolden(t, "user"), `{
+  "id": 1,
+  "name": "Bob"
+}
+`)
+
+		// .json suffix not doubled
+		checkOK(t, User{ID: 1, Name: "Bob"}, td.Snapshot(dir+"/user.json"))
+
+		checkError(t, User{ID: 1, Name: "Alice"}, td.Snapshot(dir+"/user"),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe(`DATA["name"]`),
+				Got:      mustBe(`"Alice"`),
+				Expected: mustBe(`"Bob"`),
+			})
+
+		// Golden file untouched
+		test.EqualStr(t, readGolden(t, "user"), `{
+  "id": 1,
+  "name": "Bob"
+}
+`)
+
+		// nil
+		checkOK(t, nil, td.Snapshot(dir+"/nil"))
+		test.EqualStr(t, readGolden(t, "nil"), "null\n")
+		checkOK(t, (*User)(nil), td.Snapshot(dir+"/nil"))
+		checkError(t, 12, td.Snapshot(dir+"/nil"),
+			expectedError{
+				Message: mustBe("values differ"),
+				Path:    mustBe("DATA"),
+			})
+	})
+
+	t.Run("operators & placeholders", func(t *testing.T) {
+		setenv(t, "TESTDEEP_UPDATE_SNAPSHOTS", "")
+
+		writeGolden(t, "volatile", `{
+  "id":   NotZero(), // volatile
+  "name": $name
+}`)
+
+		checkOK(t, User{ID: 123, Name: "Bob"},
+			td.Snapshot(dir+"/volatile", td.Tag("name", td.HasPrefix("B"))))
+		checkOK(t, User{ID: 456, Name: "Brian"},
+			td.Snapshot(dir+"/volatile", td.Tag("name", td.HasPrefix("B"))))
+
+		checkError(t, User{ID: 0, Name: "Bob"},
+			td.Snapshot(dir+"/volatile", td.Tag("name", td.HasPrefix("B"))),
+			expectedError{
+				Message:  mustBe("zero value"),
+				Path:     mustBe(`DATA["id"]`),
+				Got:      mustBe("0.0"),
+				Expected: mustBe("NotZero()"),
+				Under:    mustContain("under operator NotZero at line 2:10 (pos 12) inside operator Snapshot at td_snapshot_test.go:"),
+			})
+	})
+
+	t.Run("update mode", func(t *testing.T) {
+		setenv(t, "TESTDEEP_UPDATE_SNAPSHOTS", "1")
+
+		// Still matching: not rewritten, so operators survive
+		checkOK(t, User{ID: 789, Name: "Bill"},
+			td.Snapshot(dir+"/volatile", td.Tag("name", td.HasPrefix("B"))))
+		test.EqualStr(t, readGolden(t, "volatile"), `{
+  "id":   NotZero(), // volatile
+  "name": $name
+}`)
+
+		// Not matching anymore: rewritten
+		checkOK(t, User{ID: 789, Name: "Alice"},
+			td.Snapshot(dir+"/volatile", td.Tag("name", td.HasPrefix("B"))))
+		test.EqualStr(t, readGolden(t, "volatile"), `{
+  "id": 789,
+  "name": "Alice"
+}
+`)
+	})
+
+	t.Run("boolean context", func(t *testing.T) {
+		// Golden file never created nor rewritten when evaluating a
+		// candidate, as inside Any or Bag operators
+		setenv(t, "TESTDEEP_UPDATE_SNAPSHOTS", "")
+		test.IsFalse(t, td.EqDeeply(User{ID: 1}, td.Snapshot(dir+"/candidate")))
+		_, err := os.Stat(filepath.Join("testdata", dir, "candidate.json"))
+		test.IsTrue(t, os.IsNotExist(err))
+
+		checkOK(t, User{ID: 2, Name: "Bob"},
+			td.Any(td.Snapshot(dir+"/candidate"), td.Struct(User{ID: 2}, nil)))
+		_, err = os.Stat(filepath.Join("testdata", dir, "candidate.json"))
+		test.IsTrue(t, os.IsNotExist(err))
+
+		setenv(t, "TESTDEEP_UPDATE_SNAPSHOTS", "1")
+		writeGolden(t, "candidate", `{"id": 1, "name": "Bob"}`)
+		test.IsFalse(t, td.EqDeeply(User{ID: 3}, td.Snapshot(dir+"/candidate")))
+		test.EqualStr(t, readGolden(t, "candidate"), `{"id": 1, "name": "Bob"}`)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		setenv(t, "TESTDEEP_UPDATE_SNAPSHOTS", "")
+
+		writeGolden(t, "bad", `{"id": 1`)
+		checkError(t, User{}, td.Snapshot(dir+"/bad"),
+			expectedError{
+				Message: mustBe("bad usage of Snapshot operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(filepath.Join("testdata", dir, "bad.json") +
+					": JSON unmarshal error: syntax error: unexpected EOF, expecting '}' or ',' at line 1:7 (pos 7)"),
+			})
+
+		checkError(t, func() {}, td.Snapshot(dir+"/func"),
+			expectedError{
+				Message: mustBe("json.Marshal failed"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("unsupported type"),
+			})
+
+		// Golden file cannot be read
+		err := os.WriteFile(filepath.Join("testdata", dir, "file"), nil, 0o644)
+		test.NoError(t, err)
+		checkError(t, 1, td.Snapshot(dir+"/file/sub"),
+			expectedError{
+				Message: mustBe("bad usage of Snapshot operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("golden file " +
+					filepath.Join("testdata", dir, "file", "sub.json") + " cannot be read: "),
+			})
+
+		checkError(t, "never tested", td.Snapshot(""),
+			expectedError{
+				Message: mustBe("bad usage of Snapshot operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: Snapshot(NAME, ...), NAME cannot be empty"),
+			})
+
+		for _, name := range []string{"../user", dir + "/../../user", ".."} {
+			checkError(t, "never tested", td.Snapshot(name),
+				expectedError{
+					Message: mustBe("bad usage of Snapshot operator"),
+					Path:    mustBe("DATA"),
+					Summary: mustBe(`usage: Snapshot(NAME, ...), NAME cannot contain ".." elements`),
+				}, name)
+		}
+	})
+
+	//
+	// String
+	test.EqualStr(t, td.Snapshot("user").String(), `Snapshot("user")`)
+	test.EqualStr(t, td.Snapshot("").String(), "Snapshot(<ERROR>)")
+}
+
+func TestSnapshotTypeBehind(t *testing.T) {
+	equalTypes(t, td.Snapshot("user"), nil)
+	equalTypes(t, td.Snapshot(""), nil)
+}
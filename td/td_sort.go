@@ -179,7 +179,7 @@ type tdSort struct {
 var _ TestDeep = &tdSort{}
 
 // summary(Sort): sorts a slice or an array before comparing its content
-// input(Sort): array,slice,ptr(ptr on array/slice)
+// input(Sort): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // Sort is a smuggler operator. It takes an array, a slice or a
 // pointer on array/slice, it sorts it using how and compares the
@@ -199,7 +199,9 @@ var _ TestDeep = &tdSort{}
 //
 // A fields-path, also used by [Smuggle] and [Sorted] operators,
 // allows to access nested structs fields and maps & slices items. See
-// [Smuggle] for details on fields-path possibilities.
+// [Smuggle] for details on fields-path possibilities. When a
+// fields-path collects several values using [*] wildcards or ..Name
+// recursive descents, they are compared as slices.
 //
 //	type A struct{ props map[string]int }
 //	p12 := A{props: map[string]int{"priority": 12}}
@@ -250,6 +252,9 @@ var _ TestDeep = &tdSort{}
 //	  ])
 //	}`)) // succeeds
 //
+// got can also be an [iter.Seq] like function, in this case the
+// values it yields are collected in a new slice, then sorted.
+//
 // See also [Sorted], [Smuggle] and [Bag].
 func Sort(how any, expectedValue any) TestDeep {
 	s := tdSort{how: how}
@@ -343,7 +348,7 @@ var _ TestDeep = &tdSorted{}
 const sortedUsage = "(SORT_FUNC|int|[]string|string...)"
 
 // summary(Sorted): checks a slice or an array is sorted
-// input(Sorted): array,slice,ptr(ptr on array/slice)
+// input(Sorted): array,slice,ptr(ptr on array/slice),func(iter.Seq)
 
 // Sorted operator checks that data is an array, a slice or a pointer
 // on array/slice, and it is well sorted as how tells it should be.
@@ -395,6 +400,9 @@ const sortedUsage = "(SORT_FUNC|int|[]string|string...)"
 //	// sorted by age desc, then by name asc
 //	td.Cmp(t, got, td.JSON(`{ "people": Sorted("-age", "name") }`)) // succeeds
 //
+// got can also be an [iter.Seq] like function, in this case it
+// checks the values are yielded in order.
+//
 // See also [Sort].
 func Sorted(how ...any) TestDeep {
 	s := tdSorted{
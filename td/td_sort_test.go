@@ -23,6 +23,7 @@ func TestSort(t *testing.T) {
 		b int
 	}
 	type sortTest2 struct{ a, b, c int }
+	type sortTest3 struct{ m map[string]int }
 	testCases := []struct {
 		name        string
 		how         any
@@ -118,6 +119,20 @@ func TestSort(t *testing.T) {
 			got:      []sortTest2{{1, 9, 5}, {2, 0, 0}, {1, 9, 4}, {1, 8, 0}},
 			expected: []sortTest2{{1, 9, 4}, {1, 9, 5}, {1, 8, 0}, {2, 0, 0}},
 		},
+		{
+			name: "wildcard fields-path",
+			how:  "-m[*]",
+			got: []sortTest3{
+				{map[string]int{"a": 1, "b": 2}},
+				{map[string]int{"b": 3, "a": 1}},
+				{map[string]int{"a": 0, "b": 9}},
+			},
+			expected: []sortTest3{
+				{map[string]int{"a": 1, "b": 3}},
+				{map[string]int{"a": 1, "b": 2}},
+				{map[string]int{"a": 0, "b": 9}},
+			},
+		},
 		{
 			name:     "invalid fields-path",
 			how:      "",
@@ -245,12 +245,17 @@ func structTypeString(t reflect.Type) string {
 	return "struct " + t.String()
 }
 
-func anyStruct(base base, model reflect.Value, expectedFields StructFields, strict bool) *tdStruct {
+func anyStruct(base base, model reflect.Value, expectedFields StructFields, strict bool, partial partialPath) *tdStruct {
 	st, vmodel := newStruct(base, model)
 	if st.err != nil {
 		return st
 	}
+	return st.setFields(vmodel, expectedFields, strict, partial)
+}
 
+// setFields fills st expected fields using the non-zero fields of
+// vmodel, if valid, and expectedFields. st.expectedType must be set.
+func (st *tdStruct) setFields(vmodel reflect.Value, expectedFields StructFields, strict bool, partial partialPath) *tdStruct {
 	st.expectedFields = make([]fieldInfo, 0, len(expectedFields))
 	checkedFields := make(map[string]bool, len(expectedFields))
 	var matchers fieldMatcherSlice //nolint: prealloc
@@ -337,6 +342,10 @@ func anyStruct(base base, model reflect.Value, expectedFields StructFields, stri
 					return st
 				}
 
+				if partial != nil {
+					vfield = partialExpected(st.base, vfield, partial)
+				}
+
 				st.expectedFields = append(st.expectedFields, fieldInfo{
 					name:       fieldName,
 					expected:   vfield,
@@ -564,13 +573,13 @@ func (s *tdStruct) addExpectedValue(field reflect.StructField, expectedValue any
 //
 // TypeBehind method returns the [reflect.Type] of model.
 //
-// See also [SStruct].
+// See also [SStruct] and [Partial].
 func Struct(model any, expectedFields ...StructFields) TestDeep {
 	ef := mergeStructFields(expectedFields...)
 	if model == nil {
 		return newStructLazy(ef, false)
 	}
-	return anyStruct(newBase(3), reflect.ValueOf(model), ef, false)
+	return anyStruct(newBase(3), reflect.ValueOf(model), ef, false, nil)
 }
 
 // summary(SStruct): strictly compares the contents of a struct or a
@@ -720,7 +729,7 @@ func SStruct(model any, expectedFields ...StructFields) TestDeep {
 	if model == nil {
 		return newStructLazy(ef, false)
 	}
-	return anyStruct(newBase(3), reflect.ValueOf(model), ef, true)
+	return anyStruct(newBase(3), reflect.ValueOf(model), ef, true, nil)
 }
 
 func (s *tdStruct) Match(ctx ctxerr.Context, got reflect.Value) (err *ctxerr.Error) {
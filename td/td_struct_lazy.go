@@ -52,7 +52,7 @@ func (s *tdStructLazy) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Erro
 			return ctx.CollectError(ctxerr.BadKind(got, "struct OR *struct"))
 		}
 
-		tds = anyStruct(s.base, reflect.New(got.Type()).Elem(), s.expectedFields, s.strict)
+		tds = anyStruct(s.base, reflect.New(got.Type()).Elem(), s.expectedFields, s.strict, nil)
 		tds.location = s.location
 		s.cache[gotType] = tds
 	}
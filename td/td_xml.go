@@ -0,0 +1,756 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/dark"
+	"github.com/maxatome/go-testdeep/internal/flat"
+	"github.com/maxatome/go-testdeep/internal/json"
+	"github.com/maxatome/go-testdeep/internal/types"
+)
+
+// XMLOption is an option of [XML], [SubXMLOf] and [SuperXMLOf]
+// operators. Options are passed among params and are not counted as
+// placeholders.
+type XMLOption uint8
+
+const (
+	// XMLUnordered makes the order of children elements
+	// irrelevant. Each expected child is matched against a got child
+	// having the same name, whatever its position is.
+	XMLUnordered XMLOption = 1 << iota
+)
+
+type xmlKind uint8
+
+const (
+	allXML xmlKind = iota
+	subXML
+	superXML
+)
+
+// xmlAttr is an attribute of a xmlNode. In an expected tree, value
+// is a string, a raw placeholder value or a [TestDeep] operator. In a
+// got tree, it is always a string.
+type xmlAttr struct {
+	name  xml.Name
+	value any
+}
+
+// xmlNode is an element of a parsed XML document. xmlns declarations
+// are not kept as attributes, names are already resolved against
+// them.
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xmlAttr
+	children []*xmlNode
+	text     any // same as xmlAttr.value
+}
+
+// xmlLeafFn converts a raw attribute value or text at pos into its
+// xmlNode representation.
+type xmlLeafFn func(s string, pos json.Position) (any, error)
+
+func xmlGotLeaf(s string, _ json.Position) (any, error) {
+	return s, nil
+}
+
+// xmlDisplayName returns the name of an element or attribute, using
+// the {namespace}local notation if a namespace is set.
+func xmlDisplayName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+// parseXML parses the XML document b. Each attribute value and each
+// trimmed text is passed to leaf.
+func parseXML(b []byte, leaf xmlLeafFn) (*xmlNode, error) {
+	var (
+		root     *xmlNode
+		stack    []*xmlNode
+		texts    [][]byte
+		textsPos []json.Position
+	)
+
+	d := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		line, col := d.InputPos()
+		pos := json.Position{Pos: int(d.InputOffset()), Line: line, Col: col}
+
+		tok, err := d.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 && root != nil {
+				return nil, fmt.Errorf("only one root element expected %s", pos)
+			}
+
+			node := &xmlNode{name: tok.Name}
+			for _, attr := range tok.Attr {
+				if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					continue
+				}
+				v, err := leaf(attr.Value, pos)
+				if err != nil {
+					return nil, fmt.Errorf("attribute %s %s: %w", xmlDisplayName(attr.Name), pos, err)
+				}
+				node.attrs = append(node.attrs, xmlAttr{name: attr.Name, value: v})
+			}
+
+			if len(stack) == 0 {
+				root = node
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			}
+			stack = append(stack, node)
+			texts = append(texts, nil)
+			line, col = d.InputPos()
+			textsPos = append(textsPos,
+				json.Position{Pos: int(d.InputOffset()), Line: line, Col: col})
+
+		case xml.EndElement:
+			// xml.Decoder ensures start & end elements match
+			node := stack[len(stack)-1]
+			v, err := leaf(string(bytes.TrimSpace(texts[len(texts)-1])), textsPos[len(textsPos)-1])
+			if err != nil {
+				return nil, fmt.Errorf("text of %s %s: %w", xmlDisplayName(node.name), textsPos[len(textsPos)-1], err)
+			}
+			node.text = v
+			stack = stack[:len(stack)-1]
+			texts = texts[:len(texts)-1]
+			textsPos = textsPos[:len(textsPos)-1]
+
+		case xml.CharData:
+			if len(stack) == 0 {
+				if len(bytes.TrimSpace(tok)) != 0 {
+					return nil, fmt.Errorf("text found outside root element %s", pos)
+				}
+				continue
+			}
+			texts[len(texts)-1] = append(texts[len(texts)-1], tok...)
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("no root element found")
+	}
+	return root, nil
+}
+
+var xmlPlaceholderRe = regexp.MustCompile(`^\$(?:([1-9][0-9]*)|([a-zA-Z_][a-zA-Z0-9_]*))$`)
+
+// tdXMLUnmarshaler handles the parsing of XML, SubXMLOf and
+// SuperXMLOf first parameter.
+type tdXMLUnmarshaler struct {
+	*tdJSONUnmarshaler
+	numbered []any
+	byName   map[string]any
+	jsonNum  []any
+	jsonName map[string]any
+	options  XMLOption
+}
+
+// leaf converts an expected attribute value or text in its expected
+// value: a placeholder value, an embedded operator or the string
+// itself.
+func (u *tdXMLUnmarshaler) leaf(s string, pos json.Position) (any, error) {
+	if !strings.HasPrefix(s, "$") {
+		return s, nil
+	}
+
+	switch {
+	case strings.HasPrefix(s, "$$"):
+		return s[1:], nil
+
+	case strings.HasPrefix(s, "$^"):
+		op, err := json.Parse([]byte(s), json.ParseOpts{
+			Placeholders:       u.jsonNum,
+			PlaceholdersByName: u.jsonName,
+			OpFn: func(jop json.Operator, _ json.Position) (any, error) {
+				// Use the position of the XML token, not the one in s
+				return u.resolveOp()(jop, pos)
+			},
+			PathOperators: jsonPathOperators,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if e, ok := op.(*tdJSONEmbedded); ok {
+			return e.expectedValue.Interface(), nil
+		}
+		return nil, fmt.Errorf("%q is not an operator", s)
+	}
+
+	sm := xmlPlaceholderRe.FindStringSubmatch(s)
+	if sm == nil {
+		return nil, fmt.Errorf("invalid placeholder %q, use $$ to escape $", s)
+	}
+	if sm[1] != "" {
+		n, _ := strconv.Atoi(sm[1])
+		if n > len(u.numbered) {
+			return nil, fmt.Errorf("numeric placeholder %q, but no param #%d", s, n)
+		}
+		return u.numbered[n-1], nil
+	}
+	v, ok := u.byName[sm[2]]
+	if !ok {
+		return nil, fmt.Errorf("unknown placeholder %q", s)
+	}
+	return v, nil
+}
+
+// unmarshal parses expectedXML using placeholder parameters params.
+func (u *tdXMLUnmarshaler) unmarshal(expectedXML any, params []any) (*xmlNode, string, *ctxerr.Error) {
+	var (
+		err error
+		b   []byte
+	)
+
+	switch data := expectedXML.(type) {
+	case string:
+		// Try to load this file (if it seems it can be a filename and not
+		// a XML content)
+		if strings.HasSuffix(data, ".xml") {
+			b, err = os.ReadFile(data)
+			if err != nil {
+				return nil, "", ctxerr.OpBad(u.Func, "XML file %s cannot be read: %s", data, err)
+			}
+			break
+		}
+		b = []byte(data)
+
+	case []byte:
+		b = data
+
+	case io.Reader:
+		b, err = io.ReadAll(data)
+		if err != nil {
+			return nil, "", ctxerr.OpBad(u.Func, "XML read error: %s", err)
+		}
+
+	default:
+		return nil, "", ctxerr.OpBadUsage(
+			u.Func, "(STRING_XML|STRING_FILENAME|[]byte|io.Reader, ...)",
+			expectedXML, 1, false)
+	}
+
+	// Extract options, the remaining params are placeholders
+	params = flat.Interfaces(params...)
+	placeholders := params[:0:0]
+	for _, p := range params {
+		if opt, ok := p.(XMLOption); ok {
+			u.options |= opt
+			continue
+		}
+		placeholders = append(placeholders, p)
+	}
+
+	u.numbered = make([]any, len(placeholders))
+	for i, p := range placeholders {
+		if op, ok := p.(*tdTag); ok && op.err == nil {
+			if _, exists := u.byName[op.tag]; exists {
+				return nil, "", ctxerr.OpBad(u.Func, `2 params have the same tag "%s"`, op.tag)
+			}
+			if u.byName == nil {
+				u.byName = map[string]any{}
+			}
+			p = nil
+			if op.expectedValue.IsValid() {
+				p = op.expectedValue.Interface()
+			}
+			u.byName[op.tag] = p
+		}
+		u.numbered[i] = p
+	}
+
+	// Placeholders used in embedded operators parameters
+	var cerr *ctxerr.Error
+	u.jsonNum, u.jsonName, cerr = u.placeholders(append([]any(nil), placeholders...))
+	if cerr != nil {
+		return nil, "", cerr
+	}
+
+	root, err := parseXML(b, u.leaf)
+	if err != nil {
+		return nil, "", ctxerr.OpBad(u.Func, "XML unmarshal error: %s", err)
+	}
+	return root, string(bytes.TrimSpace(b)), nil
+}
+
+// tdXML is the XML, SubXMLOf and SuperXMLOf operator.
+type tdXML struct {
+	base
+	expected *xmlNode
+	src      string
+	kind     xmlKind
+	options  XMLOption
+}
+
+var _ TestDeep = &tdXML{}
+
+func newXML(kind xmlKind, expectedXML any, params []any) *tdXML {
+	x := tdXML{
+		base: newBase(4),
+		kind: kind,
+	}
+
+	u := tdXMLUnmarshaler{tdJSONUnmarshaler: newJSONUnmarshaler(x.GetLocation())}
+	u.embedIn = x.GetLocation().Func
+
+	x.expected, x.src, x.err = u.unmarshal(expectedXML, params)
+	x.options = u.options
+	return &x
+}
+
+// summary(XML): compares against XML representation
+// input(XML): str,slice([]byte),struct,ptr
+
+// XML operator allows to compare the XML representation of data
+// against expectedXML. expectedXML can be a:
+//
+//   - string containing XML data like `<user id="42">Bob</user>`
+//   - string containing a XML filename, ending with ".xml" (its
+//     content is [os.ReadFile] before parsing)
+//   - []byte containing XML data
+//   - [io.Reader] stream containing XML data (is [io.ReadAll]
+//     before parsing)
+//
+// If got is a string or a []byte, it is parsed as a XML document,
+// otherwise it is first marshaled using [encoding/xml.Marshal].
+//
+// Elements and attributes names are namespace aware: prefixes are
+// resolved against xmlns declarations, so only the namespace URI
+// matters, not the prefix used. xmlns declarations themselves are
+// not compared. Attributes order never matters. Comments, processing
+// instructions and directives are ignored. Texts are compared once
+// leading and trailing white spaces are trimmed.
+//
+//	got := `<user id="42"><name>Bob</name><age>42</age></user>`
+//	td.Cmp(t, got, td.XML(`
+//	<user id="42">
+//	  <name>Bob</name>
+//	  <age>42</age>
+//	</user>`)) // succeeds
+//
+// Children elements are compared in order. The [XMLUnordered] option,
+// passed among params, makes their order irrelevant:
+//
+//	td.Cmp(t, got, td.XML(`<user id="42"><age>42</age><name>Bob</name></user>`,
+//	  td.XMLUnordered)) // succeeds
+//
+// An attribute value or a text can be a placeholder. The params are
+// for any placeholder parameters in expectedXML. params can contain
+// [TestDeep] operators as well as raw values. A placeholder can be
+// numeric like $2 or named like $name and always references an item
+// in params. Numeric placeholders reference the n'th "operators" item
+// (starting at 1), [XMLOption] params are not counted. Named
+// placeholders are used with [Tag] operator as follows:
+//
+//	td.Cmp(t, got, td.XML(`<user id="$1"><name>$name</name><age>$3</age></user>`,
+//	  td.NotZero(),                        // matches only $1
+//	  td.Tag("name", td.HasPrefix("Bo")), // matches $2 and $name
+//	  td.Between(40, 45)))                // matches only $3
+//
+// XML does its best to convert the text corresponding to a
+// placeholder to the type of the placeholder or, if the placeholder
+// is an operator, to the type behind the operator. Booleans, numbers
+// and types implementing [encoding.TextUnmarshaler] are handled. In
+// the case the conversion cannot occur, the text is compared as a
+// string.
+//
+// Operators can also be directly embedded using the "$^" prefix, as
+// in [JSON] strings:
+//
+//	td.Cmp(t, got, td.XML(`<user id="$^NotZero"><name>$^Re(r<^B>)</name><age>$^Between(40, 45)</age></user>`))
+//
+// To avoid a legit "$" text or attribute prefix causes a bad
+// placeholder error, just double it to escape it: "$$foo" is the
+// "$foo" raw string.
+//
+// Errors paths are expressed as XPath-like locations. If the "name"
+// element text above differs, the path is DATA/user/name/text(), the
+// "id" attribute one is DATA/user/@id. When an element has several
+// siblings with the same name, its position among them, starting at
+// 1, is appended as in DATA/users/user[2]/@id.
+//
+// TypeBehind method returns nil as the type of got cannot be guessed.
+//
+// See also [SubXMLOf], [SuperXMLOf] and [JSON].
+func XML(expectedXML any, params ...any) TestDeep {
+	return newXML(allXML, expectedXML, params)
+}
+
+// summary(SubXMLOf): compares against XML representation but with
+// potentially some exclusions
+// input(SubXMLOf): str,slice([]byte),struct,ptr
+
+// SubXMLOf operator allows to compare the XML representation of data
+// against expectedXML. It works as [XML] operator does, except that
+// each attribute and each child element of got has to exist in
+// expectedXML, but some expected attributes and children elements
+// can be missing from got.
+//
+//	got := `<user id="42"><name>Bob</name></user>`
+//	td.Cmp(t, got, td.SubXMLOf(`<user id="42" admin="1"><name>Bob</name><age>42</age></user>`)) // succeeds
+//	td.Cmp(t, got, td.SubXMLOf(`<user><name>Bob</name></user>`))                              // fails, extra @id
+//
+// As for [XML], children elements are compared in order unless the
+// [XMLUnordered] option is passed among params. Placeholders and
+// embedded operators are handled the same way as [XML] does.
+//
+// TypeBehind method returns nil as the type of got cannot be guessed.
+//
+// See also [XML] and [SuperXMLOf].
+func SubXMLOf(expectedXML any, params ...any) TestDeep {
+	return newXML(subXML, expectedXML, params)
+}
+
+// summary(SuperXMLOf): compares against XML representation but with
+// potentially extra attributes and elements
+// input(SuperXMLOf): str,slice([]byte),struct,ptr
+
+// SuperXMLOf operator allows to compare the XML representation of
+// data against expectedXML. It works as [XML] operator does, except
+// that each attribute and each child element of expectedXML has to
+// exist in got, but got can contain some extra attributes and
+// children elements. An expected element without text and without
+// children matches any got element with the same name and matching
+// attributes.
+//
+//	got := `<user id="42" admin="1"><name>Bob</name><age>42</age></user>`
+//	td.Cmp(t, got, td.SuperXMLOf(`<user id="42"><name>Bob</name></user>`)) // succeeds
+//	td.Cmp(t, got, td.SuperXMLOf(`<user><city>NY</city></user>`))          // fails, missing city
+//
+// As for [XML], children elements are compared in order unless the
+// [XMLUnordered] option is passed among params. Placeholders and
+// embedded operators are handled the same way as [XML] does.
+//
+// TypeBehind method returns nil as the type of got cannot be guessed.
+//
+// See also [XML] and [SubXMLOf].
+func SuperXMLOf(expectedXML any, params ...any) TestDeep {
+	return newXML(superXML, expectedXML, params)
+}
+
+func (x *tdXML) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if x.err != nil {
+		return ctx.CollectError(x.err)
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	switch {
+	case got.Kind() == reflect.String:
+		b = []byte(got.String())
+	case got.Kind() == reflect.Slice && got.Type().Elem().Kind() == reflect.Uint8:
+		b = got.Bytes()
+	default:
+		gotIf, ok := dark.GetInterface(got, true)
+		if !ok {
+			return ctx.CollectError(ctx.CannotCompareError())
+		}
+		b, err = xml.Marshal(gotIf)
+		if err != nil {
+			if ctx.BooleanError {
+				return ctxerr.BooleanError
+			}
+			return ctx.CollectError(&ctxerr.Error{
+				Message: "xml.Marshal failed",
+				Summary: ctxerr.NewSummary(err.Error()),
+			})
+		}
+	}
+
+	gotRoot, err := parseXML(b, xmlGotLeaf)
+	if err != nil {
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		return ctx.CollectError(&ctxerr.Error{
+			Message: "XML parsing failed",
+			Summary: ctxerr.NewSummary(err.Error()),
+		})
+	}
+
+	if gotRoot.name != x.expected.name {
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		return ctx.CollectError(&ctxerr.Error{
+			Message:  "root element names differ",
+			Got:      types.RawString(xmlDisplayName(gotRoot.name)),
+			Expected: types.RawString(xmlDisplayName(x.expected.name)),
+		})
+	}
+
+	return x.matchElement(ctx.AddCustomLevel("/"+gotRoot.name.Local), gotRoot, x.expected)
+}
+
+// matchText compares a got text or attribute value against its
+// expected counterpart, converting it to the expected type if needed.
+func (x *tdXML) matchText(ctx ctxerr.Context, got string, expected any) *ctxerr.Error {
+	var typ reflect.Type
+	if op, ok := expected.(TestDeep); ok {
+		typ = op.TypeBehind()
+	} else if expected != nil {
+		typ = reflect.TypeOf(expected)
+	}
+	return deepValueEqual(ctx, xmlConvert(got, typ), reflect.ValueOf(expected))
+}
+
+// xmlConvert does its best to convert s to typ. If the conversion is
+// not possible, s is returned as is.
+func xmlConvert(s string, typ reflect.Type) reflect.Value {
+	if typ == nil {
+		return reflect.ValueOf(s)
+	}
+
+	if typ.Kind() != reflect.Interface &&
+		reflect.PtrTo(typ).Implements(types.TextUnmarshaler) {
+		v := reflect.New(typ)
+		if v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)) == nil {
+			return v.Elem()
+		}
+		return reflect.ValueOf(s)
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(s).Convert(typ)
+
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return reflect.ValueOf(b).Convert(typ)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(s, 10, typ.Bits()); err == nil {
+			return reflect.ValueOf(i).Convert(typ)
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u, err := strconv.ParseUint(s, 10, typ.Bits()); err == nil {
+			return reflect.ValueOf(u).Convert(typ)
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(s, typ.Bits()); err == nil {
+			return reflect.ValueOf(f).Convert(typ)
+		}
+	}
+	return reflect.ValueOf(s)
+}
+
+// xmlElemPath returns the path of the element n among siblings.
+func xmlElemPath(n *xmlNode, siblings []*xmlNode) string {
+	count, idx := 0, 0
+	for _, s := range siblings {
+		if s.name == n.name {
+			count++
+			if s == n {
+				idx = count
+			}
+		}
+	}
+	if count > 1 {
+		return "/" + n.name.Local + "[" + strconv.Itoa(idx) + "]"
+	}
+	return "/" + n.name.Local
+}
+
+func (x *tdXML) matchElement(ctx ctxerr.Context, got, expected *xmlNode) *ctxerr.Error {
+	var err *ctxerr.Error
+
+	// Attributes
+	var missing, extra []reflect.Value
+	gotAttrs := make(map[xml.Name]string, len(got.attrs))
+	for _, attr := range got.attrs {
+		gotAttrs[attr.name] = attr.value.(string)
+	}
+	for _, attr := range expected.attrs {
+		gotValue, ok := gotAttrs[attr.name]
+		if !ok {
+			if x.kind != subXML {
+				missing = append(missing, reflect.ValueOf(types.RawString(xmlDisplayName(attr.name))))
+			}
+			continue
+		}
+		delete(gotAttrs, attr.name)
+
+		err = x.matchText(ctx.AddCustomLevel("/@"+attr.name.Local), gotValue, attr.value)
+		if err != nil {
+			return err
+		}
+	}
+	if x.kind != superXML {
+		for _, attr := range got.attrs {
+			if _, ok := gotAttrs[attr.name]; ok {
+				extra = append(extra, reflect.ValueOf(types.RawString(xmlDisplayName(attr.name))))
+			}
+		}
+	}
+	if len(missing) > 0 || len(extra) > 0 {
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		err = ctx.CollectError(&ctxerr.Error{
+			Message: "comparing attributes of %%",
+			Summary: (tdSetResult{
+				Kind:    attributesSetResult,
+				Missing: missing,
+				Extra:   extra,
+				Sort:    true,
+			}).Summary(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Text
+	if s, ok := expected.text.(string); !ok || s != "" || x.kind != superXML {
+		err = x.matchText(ctx.AddCustomLevel("/text()"), got.text.(string), expected.text)
+		if err != nil {
+			return err
+		}
+	}
+
+	return x.matchChildren(ctx, got, expected)
+}
+
+// matchChildren compares children elements of got and expected.
+//
+// XML in order: each expected child is compared to the next got
+// child with the same name. Otherwise, each child of the reference
+// side (expected one, or got one for SubXMLOf) is paired with the
+// first free child with the same name it matches on the other side,
+// after the previously paired one if in order. If no matching child
+// is found, the reference child is compared to the first free child
+// with the same name to report the errors.
+func (x *tdXML) matchChildren(ctx ctxerr.Context, got, expected *xmlNode) *ctxerr.Error {
+	refs, others := expected.children, got.children
+	if x.kind == subXML {
+		refs, others = others, refs
+	}
+
+	// match compares ref & other children, ref belonging to refs
+	match := func(ctx ctxerr.Context, ref, other *xmlNode) *ctxerr.Error {
+		gotChild, expChild := other, ref
+		if x.kind == subXML {
+			gotChild, expChild = ref, other
+		}
+		return x.matchElement(
+			ctx.AddCustomLevel(xmlElemPath(gotChild, got.children)),
+			gotChild, expChild)
+	}
+
+	ordered := x.options&XMLUnordered == 0
+	paired := make([]bool, len(others))
+	var unpaired []reflect.Value
+	start := 0
+	for _, ref := range refs {
+		candidate, found := -1, -1
+		for i := start; i < len(others); i++ {
+			if paired[i] || others[i].name != ref.name {
+				continue
+			}
+			if candidate < 0 {
+				candidate = i
+			}
+			if ordered && x.kind == allXML {
+				break
+			}
+
+			bctx := ctx.ResetErrors()
+			bctx.BooleanError = true
+			bctx.Probing = true
+			berr := match(bctx, ref, others[i])
+			if berr == nil {
+				found = i
+				break
+			}
+			if berr != ctxerr.BooleanError {
+				return berr
+			}
+		}
+
+		if found < 0 && candidate >= 0 {
+			found = candidate
+			if err := match(ctx, ref, others[found]); err != nil {
+				return err
+			}
+		}
+
+		if found < 0 {
+			unpaired = append(unpaired, reflect.ValueOf(types.RawString(
+				strings.TrimPrefix(xmlElemPath(ref, refs), "/"))))
+			continue
+		}
+
+		paired[found] = true
+		if ordered {
+			start = found + 1
+		}
+	}
+
+	res := tdSetResult{Kind: elementsSetResult}
+	switch x.kind {
+	case subXML:
+		res.Extra = unpaired
+	case superXML:
+		res.Missing = unpaired
+	default:
+		res.Missing = unpaired
+		for i, gotChild := range got.children {
+			if !paired[i] {
+				res.Extra = append(res.Extra, reflect.ValueOf(types.RawString(
+					strings.TrimPrefix(xmlElemPath(gotChild, got.children), "/"))))
+			}
+		}
+	}
+	if res.IsEmpty() {
+		return nil
+	}
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+	return ctx.CollectError(&ctxerr.Error{
+		Message: "comparing children elements of %%",
+		Summary: res.Summary(),
+	})
+}
+
+func (x *tdXML) String() string {
+	if x.err != nil {
+		return x.stringError()
+	}
+	return x.GetLocation().Func + "(" + x.src + ")"
+}
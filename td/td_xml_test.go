@@ -0,0 +1,405 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+const underOpXML = "under operator XML at td_xml_test.go:"
+
+func TestXML(t *testing.T) {
+	got := `<?xml version="1.0"?>
+<!-- users list -->
+<users>
+  <user id="1" admin="true">
+    <name>Bob</name>
+    <age>42</age>
+  </user>
+  <user id="2">
+    <name>Alice</name>
+  </user>
+</users>`
+
+	checkOK(t, got, td.XML(`
+<users>
+  <user admin="true" id="1"><name>Bob</name><age>42</age></user>
+  <user id="2"><name>Alice</name></user>
+</users>`))
+	checkOK(t, []byte(got), td.XML([]byte(`
+<users>
+  <user admin="true" id="1"><name>Bob</name><age>42</age></user>
+  <user id="2"><name>Alice</name></user>
+</users>`)))
+	checkOK(t, got, td.XML(strings.NewReader(`
+<users>
+  <user admin="true" id="1"><name>Bob</name><age>42</age></user>
+  <user id="2"><name>Alice</name></user>
+</users>`)))
+
+	//
+	// Placeholders
+	checkOK(t, got, td.XML(`
+<users>
+  <user admin="$1" id="$2"><name>$3</name><age>$4</age></user>
+  <user id="$id"><name>$name</name></user>
+</users>`,
+		true,
+		td.Between(1, 9),
+		td.HasPrefix("Bo"),
+		td.Gt(40),
+		td.Tag("id", 2),
+		td.Tag("name", td.Re(`^Al`))))
+
+	// Embedded operators
+	checkOK(t, got, td.XML(`
+<users>
+  <user admin="$^NotEmpty" id="$^Between(1, 9)">
+    <name>$^Re($1)</name>
+    <age>$^Any(41, 42)</age>
+  </user>
+  <user id="$^NotZero"><name>$^Ignore</name></user>
+</users>`,
+		`^B.b$`))
+
+	// $ escape
+	checkOK(t, `<a b="$foo">$$bar</a>`, td.XML(`<a b="$$foo">$$$bar</a>`))
+
+	// TextUnmarshaler conversion
+	checkOK(t, `<a>2026-10-18T12:00:00Z</a>`,
+		td.XML(`<a>$1</a>`, td.Gt(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))))
+
+	// Conversion impossible, compared as string
+	checkError(t, `<a>zip</a>`, td.XML(`<a>$1</a>`, 42),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA/a/text()"),
+			Got:      mustBe("string"),
+			Expected: mustBe("int"),
+		})
+
+	//
+	// Namespaces
+	checkOK(t, `<x:a xmlns:x="urn:foo" xmlns:y="urn:bar" y:b="1"/>`,
+		td.XML(`<a xmlns="urn:foo" xmlns:z="urn:bar" z:b="1"/>`))
+
+	checkError(t, `<x:a xmlns:x="urn:foo"/>`, td.XML(`<x:a xmlns:x="urn:bar"/>`),
+		expectedError{
+			Message:  mustBe("root element names differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("{urn:foo}a"),
+			Expected: mustBe("{urn:bar}a"),
+		})
+
+	checkError(t, `<a xmlns:x="urn:foo" x:b="1"/>`, td.XML(`<a b="1"/>`),
+		expectedError{
+			Message: mustBe("comparing attributes of %%"),
+			Path:    mustBe("DATA/a"),
+			Summary: mustBe("Missing attribute: (b)\n  Extra attribute: ({urn:foo}b)"),
+		})
+
+	//
+	// Struct got
+	type User struct {
+		XMLName xml.Name `xml:"user"`
+		ID      int      `xml:"id,attr"`
+		Name    string   `xml:"name"`
+	}
+	checkOK(t, User{ID: 12, Name: "Bob"}, td.XML(`<user id="$1"><name>Bob</name></user>`, 12))
+	checkOK(t, &User{ID: 12, Name: "Bob"}, td.XML(`<user id="12"><name>Bob</name></user>`))
+
+	//
+	// Errors
+	checkError(t, got, td.XML(`
+<users>
+  <user admin="true" id="1"><name>Bob</name><age>$1</age></user>
+  <user id="2"><name>Alice</name></user>
+</users>`, td.Between(20, 30)),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA/users/user[1]/age/text()"),
+			Got:      mustBe("42"),
+			Expected: mustBe("20 ≤ got ≤ 30"),
+			Under:    mustContain("under operator Between at td_xml_test.go:"),
+		})
+
+	checkError(t, got, td.XML(`
+<users>
+  <user admin="true" id="1"><name>Bob</name><age>42</age></user>
+  <user id="3"><name>Alice</name></user>
+</users>`),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA/users/user[2]/@id"),
+			Got:      mustBe(`"2"`),
+			Expected: mustBe(`"3"`),
+			Under:    mustContain(underOpXML),
+		})
+
+	checkError(t, got, td.XML(`
+<users>
+  <user id="1"><name>Bob</name><age>42</age></user>
+  <user id="2"><name>Alice</name></user>
+</users>`),
+		expectedError{
+			Message: mustBe("comparing attributes of %%"),
+			Path:    mustBe("DATA/users/user[1]"),
+			Summary: mustBe("Extra attribute: (admin)"),
+		})
+
+	checkError(t, got, td.XML(`
+<users>
+  <user admin="true" id="1"><name>Bob</name></user>
+  <user id="2"><name>Alice</name></user>
+</users>`),
+		expectedError{
+			Message: mustBe("comparing children elements of %%"),
+			Path:    mustBe("DATA/users/user[1]"),
+			Summary: mustBe("Extra element: (age)"),
+		})
+
+	checkError(t, `<a><b>1</b><c>2</c></a>`, td.XML(`<a><c>2</c><b>1</b></a>`),
+		expectedError{
+			Message: mustBe("comparing children elements of %%"),
+			Path:    mustBe("DATA/a"),
+			Summary: mustBe("Missing element: (b)\n  Extra element: (b)"),
+		})
+
+	checkError(t, `<a>foo</a>`, td.XML(`<a/>`),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA/a/text()"),
+			Got:      mustBe(`"foo"`),
+			Expected: mustBe(`""`),
+		})
+
+	checkError(t, `<a>`, td.XML(`<a/>`),
+		expectedError{
+			Message: mustBe("XML parsing failed"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("XML syntax error on line 1: unexpected EOF"),
+		})
+
+	checkError(t, `<b/>`, td.XML(`<a/>`),
+		expectedError{
+			Message:  mustBe("root element names differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("b"),
+			Expected: mustBe("a"),
+		})
+
+	checkError(t, func() {}, td.XML(`<a/>`),
+		expectedError{
+			Message: mustBe("xml.Marshal failed"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("xml: unsupported type: func()"),
+		})
+
+	// Unexported field
+	type xmlUser struct {
+		Name string `xml:"name"`
+	}
+	type private struct{ user xmlUser }
+	checkOK(t, private{user: xmlUser{Name: "Bob"}},
+		td.Struct(private{}, td.StructFields{
+			"user": td.XML(`<xmlUser><name>Bob</name></xmlUser>`),
+		}))
+
+	//
+	// Bad usage
+	checkError(t, "never tested",
+		td.XML(42),
+		expectedError{
+			Message: mustBe("bad usage of XML operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("usage: XML(STRING_XML|STRING_FILENAME|[]byte|io.Reader, ...), but received int as 1st parameter"),
+			Under:   mustContain(underOpXML),
+		})
+
+	checkError(t, "never tested",
+		td.XML("uNkNoWnFiLe.xml"),
+		expectedError{
+			Message: mustBe("bad usage of XML operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain("XML file uNkNoWnFiLe.xml cannot be read: "),
+		})
+
+	checkError(t, "never tested",
+		td.XML(errReader{}),
+		expectedError{
+			Message: mustBe("bad usage of XML operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("XML read error: an error occurred"),
+		})
+
+	for xmlStr, errStr := range map[string]string{
+		`<a/><b/>`:         "only one root element expected at line 1:5 (pos 4)",
+		`<a/>foo`:          "text found outside root element at line 1:5 (pos 4)",
+		``:                 "no root element found",
+		`<a b="$1"/>`:      `attribute b at line 1:1 (pos 0): numeric placeholder "$1", but no param #1`,
+		`<a>$foo</a>`:      `text of a at line 1:4 (pos 3): unknown placeholder "$foo"`,
+		`<a>$1x</a>`:       `text of a at line 1:4 (pos 3): invalid placeholder "$1x", use $$ to escape $`,
+		`<a>$^Unknown</a>`: "text of a at line 1:4 (pos 3): unknown operator Unknown() at line 1:2 (pos 2)",
+		`<a>$^Len(</a>`:    "text of a at line 1:4 (pos 3): syntax error: unexpected EOF at line 1:5 (pos 5)",
+		`<a>$^Struct</a>`:  "text of a at line 1:4 (pos 3): Struct() is not usable in XML() at line 1:2 (pos 2)",
+	} {
+		checkError(t, "never tested",
+			td.XML(xmlStr),
+			expectedError{
+				Message: mustBe("bad usage of XML operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("XML unmarshal error: " + errStr),
+			}, xmlStr)
+	}
+
+	checkError(t, "never tested",
+		td.XML(`<a/>`, td.Tag("foo", 1), td.Tag("foo", 2)),
+		expectedError{
+			Message: mustBe("bad usage of XML operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`2 params have the same tag "foo"`),
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.XML(`  <a b="$1"/>  `, 12).String(), `XML(<a b="$1"/>)`)
+	test.EqualStr(t, td.XML(42).String(), "XML(<ERROR>)")
+}
+
+func TestXMLUnordered(t *testing.T) {
+	got := `<a><b id="1"/><c/><b id="2"/></a>`
+
+	checkOK(t, got, td.XML(`<a><b id="2"/><c/><b id="1"/></a>`, td.XMLUnordered))
+	checkOK(t, got, td.XML(`<a><c/><b id="$1"/><b id="1"/></a>`,
+		td.XMLUnordered, td.Gt(1))) // XMLUnordered is not a placeholder
+
+	checkError(t, got, td.XML(`<a><c/><b id="2"/><b id="3"/></a>`, td.XMLUnordered),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA/a/b[1]/@id"),
+			Got:      mustBe(`"1"`),
+			Expected: mustBe(`"3"`),
+		})
+
+	checkError(t, got, td.XML(`<a><c/><d/><b id="2"/><b id="1"/></a>`, td.XMLUnordered),
+		expectedError{
+			Message: mustBe("comparing children elements of %%"),
+			Path:    mustBe("DATA/a"),
+			Summary: mustBe("Missing element: (d)"),
+		})
+}
+
+func TestSubXMLOf(t *testing.T) {
+	got := `<user id="1"><name>Bob</name><age>42</age></user>`
+
+	checkOK(t, got, td.SubXMLOf(`
+<user id="1" admin="true">
+  <name>Bob</name>
+  <age>$1</age>
+  <zip>123</zip>
+</user>`, 42))
+	checkOK(t, `<user/>`, td.SubXMLOf(`<user id="1"><name>Bob</name></user>`))
+	checkOK(t, `<a><c/><b/></a>`, td.SubXMLOf(`<a><b/><c/><b/></a>`))
+
+	checkError(t, got, td.SubXMLOf(`<user><name>Bob</name><age>42</age></user>`),
+		expectedError{
+			Message: mustBe("comparing attributes of %%"),
+			Path:    mustBe("DATA/user"),
+			Summary: mustBe("Extra attribute: (id)"),
+		})
+
+	checkError(t, got, td.SubXMLOf(`<user id="1"><name>Bob</name></user>`),
+		expectedError{
+			Message: mustBe("comparing children elements of %%"),
+			Path:    mustBe("DATA/user"),
+			Summary: mustBe("Extra element: (age)"),
+		})
+
+	checkError(t, `<a><c/><b/></a>`, td.SubXMLOf(`<a><b/><c/></a>`),
+		expectedError{
+			Message: mustBe("comparing children elements of %%"),
+			Path:    mustBe("DATA/a"),
+			Summary: mustBe("Extra element: (b)"),
+		})
+	checkOK(t, `<a><c/><b/></a>`, td.SubXMLOf(`<a><b/><c/></a>`, td.XMLUnordered))
+
+	checkError(t, "never tested",
+		td.SubXMLOf(42),
+		expectedError{
+			Message: mustBe("bad usage of SubXMLOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("usage: SubXMLOf(STRING_XML|STRING_FILENAME|[]byte|io.Reader, ...), but received int as 1st parameter"),
+			Under:   mustContain("under operator SubXMLOf at td_xml_test.go:"),
+		})
+
+	checkError(t, "never tested",
+		td.SubXMLOf(`<a>$^XML</a>`),
+		expectedError{
+			Message: mustBe("bad usage of SubXMLOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain("XML() is not usable in SubXMLOf()"),
+		})
+
+	test.EqualStr(t, td.SubXMLOf(`<a/>`).String(), `SubXMLOf(<a/>)`)
+}
+
+func TestSuperXMLOf(t *testing.T) {
+	got := `<users>
+  <user id="1" admin="true"><name>Bob</name><age>42</age></user>
+  <user id="2"><name>Alice</name></user>
+</users>`
+
+	checkOK(t, got, td.SuperXMLOf(`<users><user id="1"><age>42</age></user></users>`))
+	checkOK(t, got, td.SuperXMLOf(`<users><user id="2"/></users>`))
+	checkOK(t, got, td.SuperXMLOf(`<users><user/><user id="$1"/></users>`, td.Gt(1)))
+	checkOK(t, got, td.SuperXMLOf(`<users/>`))
+
+	checkError(t, got, td.SuperXMLOf(`<users><user id="2"/><user id="1"/></users>`),
+		expectedError{
+			Message: mustBe("comparing children elements of %%"),
+			Path:    mustBe("DATA/users"),
+			Summary: mustBe("Missing element: (user[2])"),
+		})
+	checkOK(t, got,
+		td.SuperXMLOf(`<users><user id="2"/><user id="1"/></users>`, td.XMLUnordered))
+
+	checkError(t, got, td.SuperXMLOf(`<users><user id="3"/></users>`),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA/users/user[1]/@id"),
+			Got:      mustBe(`"1"`),
+			Expected: mustBe(`"3"`),
+		})
+
+	checkError(t, got, td.SuperXMLOf(`<users><user zip="1"/></users>`),
+		expectedError{
+			Message: mustBe("comparing attributes of %%"),
+			Path:    mustBe("DATA/users/user[1]"),
+			Summary: mustBe("Missing attribute: (zip)"),
+		})
+
+	checkError(t, got, td.SuperXMLOf(`<users><group/></users>`),
+		expectedError{
+			Message: mustBe("comparing children elements of %%"),
+			Path:    mustBe("DATA/users"),
+			Summary: mustBe("Missing element: (group)"),
+		})
+
+	test.EqualStr(t, td.SuperXMLOf(`<a/>`).String(), `SuperXMLOf(<a/>)`)
+}
+
+func TestXMLTypeBehind(t *testing.T) {
+	equalTypes(t, td.XML(`<a/>`), nil)
+	equalTypes(t, td.SubXMLOf(`<a/>`), nil)
+	equalTypes(t, td.SuperXMLOf(`<a/>`), nil)
+}
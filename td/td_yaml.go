@@ -0,0 +1,184 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"reflect"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/location"
+	"github.com/maxatome/go-testdeep/internal/yaml"
+)
+
+var yamlFormat = unmarshalFormat{
+	name:  "YAML",
+	exts:  []string{".yaml", ".yml"},
+	usage: "(STRING_YAML|STRING_FILENAME|[]byte|io.Reader, ...)",
+	parse: yaml.Parse,
+}
+
+// newYAMLUnmarshaler returns a new instance of tdJSONUnmarshaler
+// handling YAML.
+func newYAMLUnmarshaler(pos location.Location) *tdJSONUnmarshaler {
+	return &tdJSONUnmarshaler{
+		Location: pos,
+		embedIn:  pos.Func,
+		format:   &yamlFormat,
+	}
+}
+
+// summary(YAML): compares against JSON representation using YAML
+// expected data
+// input(YAML): nil,bool,str,int,float,array,slice,map,struct,ptr
+
+// YAML operator allows to compare the JSON representation of data
+// against expectedYAML. It works as [JSON] operator does, except
+// that expected data is written in YAML. expectedYAML can be a:
+//
+//   - string containing YAML data like "fullname: Bob\nage: 42"
+//   - string containing a YAML filename, ending with ".yaml" or
+//     ".yml" (its content is [os.ReadFile] before unmarshaling)
+//   - []byte containing YAML data
+//   - [io.Reader] stream containing YAML data (is [io.ReadAll]
+//     before unmarshaling)
+//
+// Only a subset of YAML 1.2 is supported: block and flow
+// collections, plain, quoted and block scalars, comments, anchors
+// and aliases. Tags, complex keys and multi-documents streams are
+// not. Once unmarshaled, YAML data is compared against the JSON
+// representation of got, exactly as [JSON] does. So mappings keys
+// are always strings and numbers are always float64.
+//
+// As in [JSON], scalars can be placeholders like $2 or $name, and
+// operators can be embedded using the "$^" prefix. The params are
+// for any placeholder parameters in expectedYAML:
+//
+//	td.Cmp(t, gotValue, td.YAML(`
+//	fullname: $name
+//	age: $^Between(41, 43)
+//	details: "$$info"   # literally "$info", thanks to "$" escape
+//	children:
+//	  - $2
+//	  - $^NotEmpty
+//	`,
+//	  td.Tag("name", td.HasPrefix("Foo")), // matches $1 and $name
+//	  td.Re(`^Bob`)))                      // matches only $2
+//
+// The operator call following "$^" is parsed as in [JSON], so its
+// parameters use the JSON syntax and can contain placeholders. Note
+// that literal (|) and folded (>) block scalars never contain
+// placeholders nor operators, their "$" are literal.
+//
+// Errors positions, as those of embedded operators, are reported
+// using YAML lines and columns.
+//
+// Note that [Lax] mode is automatically enabled by YAML operator to
+// simplify numeric tests.
+//
+// TypeBehind method returns the [reflect.Type] of the expectedYAML
+// once unmarshaled. So it can be bool, string, float64, []any,
+// map[string]any or any in case expectedYAML is "null".
+//
+// See also [JSON], [SubYAMLOf] and [SuperYAMLOf].
+func YAML(expectedYAML any, params ...any) TestDeep {
+	j := &tdJSON{
+		baseOKNil: newBaseOKNil(3),
+	}
+
+	yu := newYAMLUnmarshaler(j.GetLocation())
+
+	v, err := yu.unmarshal(expectedYAML, params)
+	if err != nil {
+		j.err = err
+	} else {
+		j.expected = reflect.ValueOf(v)
+		j.options = yu.options
+	}
+
+	return j
+}
+
+func newYAMLMap(kind mapKind, expectedYAML any, params []any) *tdMapJSON {
+	m := &tdMapJSON{
+		tdMap: tdMap{
+			tdExpectedType: tdExpectedType{
+				base:         newBase(4),
+				expectedType: reflect.TypeOf((map[string]any)(nil)),
+			},
+			kind: kind,
+		},
+	}
+
+	yu := newYAMLUnmarshaler(m.GetLocation())
+
+	v, err := yu.unmarshal(expectedYAML, params)
+	if err != nil {
+		m.err = err
+		return m
+	}
+
+	_, ok := v.(map[string]any)
+	if !ok {
+		m.err = ctxerr.OpBad(m.GetLocation().Func,
+			"%s() only accepts YAML mappings", m.GetLocation().Func)
+		return m
+	}
+
+	m.expected = reflect.ValueOf(v)
+	m.options = yu.options
+
+	m.populateExpectedEntries(nil, m.expected)
+	return m
+}
+
+// summary(SubYAMLOf): compares struct or map against JSON
+// representation using YAML expected data but with potentially
+// some exclusions
+// input(SubYAMLOf): map,struct,ptr(ptr on map/struct)
+
+// SubYAMLOf operator allows to compare the JSON representation of
+// data against expectedYAML. It works as [SubJSONOf] operator does,
+// except that expected data is written in YAML, see [YAML] operator
+// for the supported syntax. expectedYAML has to be a YAML mapping.
+//
+//	got := map[string]any{"fullname": "Bob", "age": 42}
+//	td.Cmp(t, got, td.SubYAMLOf(`
+//	fullname: Bob
+//	age: $^Between(40, 45)
+//	gender: male
+//	`)) // succeeds, gender is missing from got, but it is allowed
+//
+// TypeBehind method returns the map[string]any type.
+//
+// See also [YAML], [SuperYAMLOf] and [SubJSONOf].
+func SubYAMLOf(expectedYAML any, params ...any) TestDeep {
+	return newYAMLMap(subMap, expectedYAML, params)
+}
+
+// summary(SuperYAMLOf): compares struct or map against JSON
+// representation using YAML expected data but with potentially
+// extra entries
+// input(SuperYAMLOf): map,struct,ptr(ptr on map/struct)
+
+// SuperYAMLOf operator allows to compare the JSON representation of
+// data against expectedYAML. It works as [SuperJSONOf] operator
+// does, except that expected data is written in YAML, see [YAML]
+// operator for the supported syntax. expectedYAML has to be a YAML
+// mapping.
+//
+//	got := map[string]any{"fullname": "Bob", "age": 42, "gender": "male"}
+//	td.Cmp(t, got, td.SuperYAMLOf(`
+//	fullname: Bob
+//	age: $1
+//	`, td.Gt(40))) // succeeds, gender is ignored
+//
+// TypeBehind method returns the map[string]any type.
+//
+// See also [YAML], [SubYAMLOf] and [SuperJSONOf].
+func SuperYAMLOf(expectedYAML any, params ...any) TestDeep {
+	return newYAMLMap(superMap, expectedYAML, params)
+}
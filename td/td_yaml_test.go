@@ -0,0 +1,220 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+const underOpYAML = "under operator YAML at td_yaml_test.go:"
+
+func TestYAML(t *testing.T) {
+	type Person struct {
+		Name     string   `json:"name"`
+		Age      int      `json:"age"`
+		Children []string `json:"children"`
+	}
+	got := Person{Name: "Bob", Age: 42, Children: []string{"Alice", "Brian"}}
+
+	expected := `
+# A person
+name: Bob
+age: 42
+children:
+  - Alice
+  - Brian
+`
+	checkOK(t, got, td.YAML(expected))
+	checkOK(t, &got, td.YAML([]byte(expected)))
+	checkOK(t, got, td.YAML(strings.NewReader(expected)))
+	checkOK(t, got, td.YAML(`{name: Bob, age: 42, children: [Alice, Brian]}`))
+
+	//
+	// Placeholders
+	checkOK(t, got, td.YAML(`
+name: $name
+age: "$2"
+children: [$3, $^HasPrefix("Br")]
+`,
+		td.Tag("name", td.Re(`^B`)),
+		td.Between(40, 45),
+		"Alice"))
+
+	// $ escape
+	checkOK(t, "$foo", td.YAML(`$$foo`))
+	checkOK(t, "$foo", td.YAML(`"$$foo"`))
+	checkOK(t, "$foo", td.YAML("|-\n  $foo"))
+
+	//
+	// Errors
+	checkError(t, got, td.YAML(`
+name: Bob
+age: $^Between(20, 30)
+children: [Alice, Brian]
+`),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe(`DATA["age"]`),
+			Got:      mustBe("42.0"),
+			Expected: mustBe("20.0 ≤ got ≤ 30.0"),
+			Under:    mustContain("under operator Between at line 3:7 (pos 18) inside operator YAML at td_yaml_test.go:"),
+		})
+
+	checkError(t, got, td.YAML(`
+name: Alice
+age: 42
+children: [Alice, Brian]
+`),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe(`DATA["name"]`),
+			Got:      mustBe(`"Bob"`),
+			Expected: mustBe(`"Alice"`),
+			Under:    mustContain(underOpYAML),
+		})
+
+	//
+	// Bad usage
+	checkError(t, "never tested",
+		td.YAML(42),
+		expectedError{
+			Message: mustBe("bad usage of YAML operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("usage: YAML(STRING_YAML|STRING_FILENAME|[]byte|io.Reader, ...), but received int as 1st parameter"),
+			Under:   mustContain(underOpYAML),
+		})
+
+	for _, file := range []string{"uNkNoWnFiLe.yaml", "uNkNoWnFiLe.yml"} {
+		checkError(t, "never tested",
+			td.YAML(file),
+			expectedError{
+				Message: mustBe("bad usage of YAML operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("YAML file " + file + " cannot be read: "),
+			})
+	}
+
+	checkError(t, "never tested",
+		td.YAML(errReader{}),
+		expectedError{
+			Message: mustBe("bad usage of YAML operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("YAML read error: an error occurred"),
+		})
+
+	for yamlStr, errStr := range map[string]string{
+		"a: 1\na: 2":        `duplicated key "a" at line 2:0 (pos 5)`,
+		"a: $1":             `numeric placeholder "$1", but no params given at line 1:3 (pos 3)`,
+		"a:\n  b: $^Len(":   "unterminated operator call at line 2:5 (pos 8)",
+		"a: $^Unknown":      "unknown operator Unknown() at line 1:5 (pos 5)",
+		"a: $^Struct":       "Struct() is not usable in YAML() at line 1:5 (pos 5)",
+		"a: $^YAML(\"{}\")": "YAML() is not usable in YAML(), use literal JSON instead at line 1:5 (pos 5)",
+		"a: !!str 12":       "tags are not supported at line 1:3 (pos 3)",
+		"---\na\n---\nb\n":  "multiple documents are not supported at line 3:0 (pos 6)",
+	} {
+		checkError(t, "never tested",
+			td.YAML(yamlStr),
+			expectedError{
+				Message: mustBe("bad usage of YAML operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("YAML unmarshal error: " + errStr),
+			}, yamlStr)
+	}
+
+	//
+	// String
+	test.EqualStr(t, td.YAML("a: 1\nb: [2]").String(), `YAML({
+       "a": 1,
+       "b": [
+              2
+            ]
+     })`)
+	test.EqualStr(t, td.YAML(``).String(), "YAML(null)")
+	test.EqualStr(t, td.YAML(42).String(), "YAML(<ERROR>)")
+}
+
+func TestSubYAMLOf(t *testing.T) {
+	got := map[string]any{"name": "Bob", "age": 42}
+
+	checkOK(t, got, td.SubYAMLOf(`
+name: Bob
+age: $1
+gender: male
+`, td.Gt(40)))
+
+	checkError(t, got, td.SubYAMLOf("name: Bob\ngender: male"),
+		expectedError{
+			Message: mustBe("comparing hash keys of %%"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Missing key: (\"gender\")\n  Extra key: (\"age\")"),
+		})
+
+	checkError(t, "never tested", td.SubYAMLOf("- 1"),
+		expectedError{
+			Message: mustBe("bad usage of SubYAMLOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("SubYAMLOf() only accepts YAML mappings"),
+		})
+
+	checkError(t, "never tested", td.SubYAMLOf(`a: $^SubYAMLOf("{}")`),
+		expectedError{
+			Message: mustBe("bad usage of SubYAMLOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("YAML unmarshal error: SubYAMLOf() is not usable in SubYAMLOf(), use SubMapOf operator instead at line 1:5 (pos 5)"),
+		})
+
+	test.EqualStr(t, td.SubYAMLOf("a: 1").String(), `SubYAMLOf({
+            "a": 1
+          })`)
+	test.EqualStr(t, td.SubYAMLOf(42).String(), "SubYAMLOf(<ERROR>)")
+}
+
+func TestSuperYAMLOf(t *testing.T) {
+	got := map[string]any{"name": "Bob", "age": 42, "gender": "male"}
+
+	checkOK(t, got, td.SuperYAMLOf("name: Bob\nage: $^Between(40, 45)"))
+
+	checkError(t, got, td.SuperYAMLOf("name: Bob\nchildren: []"),
+		expectedError{
+			Message: mustBe("comparing hash keys of %%"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing key: ("children")`),
+		})
+
+	checkError(t, nil, td.SuperYAMLOf("name: Bob"),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("null"),
+			Expected: mustBe("non-null"),
+		})
+
+	checkError(t, "never tested", td.SuperYAMLOf("foo"),
+		expectedError{
+			Message: mustBe("bad usage of SuperYAMLOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("SuperYAMLOf() only accepts YAML mappings"),
+		})
+
+	test.EqualStr(t, td.SuperYAMLOf(42).String(), "SuperYAMLOf(<ERROR>)")
+}
+
+func TestYAMLTypeBehind(t *testing.T) {
+	equalTypes(t, td.YAML("a: 1"), map[string]any{})
+	equalTypes(t, td.YAML("- 1"), []any{})
+	equalTypes(t, td.YAML("$^Between(1, 2)"), float64(0))
+	equalTypes(t, td.YAML(42), nil)
+
+	mapType := reflect.TypeOf(map[string]any{})
+	test.IsTrue(t, td.SubYAMLOf("a: 1").TypeBehind() == mapType)
+	test.IsTrue(t, td.SuperYAMLOf("a: 1").TypeBehind() == mapType)
+}
@@ -0,0 +1,337 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build go1.18
+// +build go1.18
+
+package td
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+)
+
+// Expected is a typed expectation used by the generic builders
+// [StructOf], [SStructOf], [SliceOf] and [MapOf]. V is the type of
+// the compared value. An Expected is created by [Is] or [Op]. It is
+// the typed counterpart of an untyped expected value, as the values
+// of [StructFields], [ArrayEntries] or [MapEntries].
+type Expected[V any] struct {
+	expected any
+}
+
+// Is returns an [Expected] that matches value. It is the typed
+// counterpart of directly using value as an expected value, as in
+// td.StructFields{"Name": "Bob"}. As the type of value is the type
+// of the compared value, the compiler checks it:
+//
+//	td.StructOf(func(p *Person) []td.FieldOf {
+//	  return []td.FieldOf{
+//	    td.Field(&p.Name, td.Is("Bob")),     // Name is a string
+//	    td.Field(&p.Age, td.Is[int64](42)), // Age is an int64
+//	  }
+//	})
+//
+// As [Anchor] and [A] return a value of the anchored type, they can
+// be used in place of value to embed an operator and keep the check:
+//
+//	td.Field(&p.Age, td.Is(td.A[int64](t, td.Between(40, 45))))
+//
+// See also [Op].
+func Is[V any](value V) Expected[V] {
+	return Expected[V]{expected: value}
+}
+
+// Op returns an [Expected] that matches using operator. It is the
+// typed counterpart of directly using operator as an expected value,
+// as in td.StructFields{"Age": td.Between(40, 45)}. V has to be
+// explicitly set, as it cannot be deduced from operator:
+//
+//	td.Field(&p.Age, td.Op[int64](td.Between(40, 45)))
+//
+// Contrary to [Is], the type of operator cannot be checked at
+// compile time, only during the match as any [TestDeep] operator.
+//
+// See also [Is].
+func Op[V any](operator TestDeep) Expected[V] {
+	return Expected[V]{expected: operator}
+}
+
+// FieldOf is a field expectation of [StructOf] and [SStructOf], the
+// typed counterpart of a [StructFields] entry of [Struct] and
+// [SStruct]. It is created by [Field].
+type FieldOf struct {
+	field    reflect.Value // pointer on the field
+	expected any
+}
+
+// Field returns a [FieldOf] for [StructOf] and [SStructOf]. field
+// is a pointer on a field of the struct passed to the
+// [StructOf]/[SStructOf] function, so the compiler checks both the
+// field name and that expected has the same type as the field.
+//
+//	td.Field(&p.Name, td.Is("Bob"))
+//	td.Field(&p.Age, td.Op[int](td.Gt(40)))
+//
+// See [StructOf] for details.
+func Field[V any](field *V, expected Expected[V]) FieldOf {
+	return FieldOf{
+		field:    reflect.ValueOf(field),
+		expected: expected.expected,
+	}
+}
+
+// fieldsOf calls fn with a pointer on a new S value and resolves the
+// name of each returned field. Embedded struct pointers are
+// allocated before calling fn, so their promoted fields can be
+// selected too.
+func fieldsOf[S any](fn func(*S) []FieldOf) (sf StructFields, err error) {
+	var s S
+	vs := reflect.ValueOf(&s).Elem()
+	allocEmbedded(vs, map[reflect.Type]bool{})
+
+	var fields []FieldOf
+	func() {
+		defer func() {
+			if panicked := recover(); panicked != nil {
+				err = fmt.Errorf("function panicked: %v", panicked)
+			}
+		}()
+		fields = fn(&s)
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	sf = make(StructFields, len(fields))
+	for i, f := range fields {
+		if !f.field.IsValid() || f.field.IsNil() {
+			return nil, fmt.Errorf("field #%d is nil", i+1)
+		}
+
+		name, index := findField(vs, f.field.Pointer(), f.field.Type().Elem(), nil)
+		if name == "" {
+			return nil, fmt.Errorf("field #%d is not a field of %s", i+1, vs.Type())
+		}
+
+		// Check the field is reachable by its name, and not shadowed
+		// or ambiguous because of embedded structs
+		if sfield, ok := vs.Type().FieldByName(name); !ok ||
+			!reflect.DeepEqual(sfield.Index, index) {
+			return nil, fmt.Errorf("field #%d (%s) is not directly reachable in %s",
+				i+1, name, vs.Type())
+		}
+
+		if _, exists := sf[name]; exists {
+			return nil, fmt.Errorf("field %s is set twice", name)
+		}
+		sf[name] = f.expected
+	}
+	return sf, nil
+}
+
+// allocEmbedded allocates all nil exported embedded struct pointers
+// of v, recursively. seen avoids infinite recursion.
+func allocEmbedded(v reflect.Value, seen map[reflect.Type]bool) {
+	t := v.Type()
+	if seen[t] {
+		return
+	}
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+		fv := v.Field(i)
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			allocEmbedded(fv, seen)
+		case reflect.Ptr:
+			if field.Type.Elem().Kind() == reflect.Struct && fv.CanSet() {
+				fv.Set(reflect.New(field.Type.Elem()))
+				allocEmbedded(fv.Elem(), seen)
+			}
+		}
+	}
+}
+
+// findField returns the name and the index of the field of struct v
+// at address addr with type typ. It returns an empty name if not found.
+func findField(v reflect.Value, addr uintptr, typ reflect.Type, index []int) (string, []int) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		fIndex := append(index[:len(index):len(index)], i)
+
+		if fv.UnsafeAddr() == addr && field.Type == typ {
+			return field.Name, fIndex
+		}
+
+		if !field.Anonymous {
+			continue
+		}
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() || fv.Elem().Kind() != reflect.Struct {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct {
+			if name, idx := findField(fv, addr, typ, fIndex); name != "" {
+				return name, idx
+			}
+		}
+	}
+	return "", nil
+}
+
+func newStructOf[S any](fn func(*S) []FieldOf, strict bool) TestDeep {
+	st := &tdStruct{
+		tdExpectedType: tdExpectedType{
+			base: newBase(4),
+		},
+	}
+
+	// Go generics cannot constrain S to be a struct type
+	st.expectedType = reflect.TypeOf((*S)(nil)).Elem()
+	if st.expectedType.Kind() != reflect.Struct {
+		st.err = ctxerr.OpBad(st.location.Func,
+			"%s is not a struct type", st.expectedType)
+		return st
+	}
+
+	fields, err := fieldsOf(fn)
+	if err != nil {
+		st.err = ctxerr.OpBad(st.location.Func, "%s", err)
+		return st
+	}
+
+	// No model, as all its fields are zero and some of them can be
+	// nil embedded struct pointers
+	return st.setFields(reflect.Value{}, fields, strict, nil)
+}
+
+// StructOf operator is a type-safe variant of [Struct] operator. S
+// is the struct type of the compared data and fn returns the
+// fields to check. fn is called once, when StructOf is called, with
+// a pointer on a zero S. Each [FieldOf] is created by [Field]
+// using a pointer on a field of this S, so the compiler checks the
+// field exists and its expected value has the right type:
+//
+//	td.Cmp(t, got, td.StructOf(func(p *Person) []td.FieldOf {
+//	  return []td.FieldOf{
+//	    td.Field(&p.Name, td.Is("Bob")),
+//	    td.Field(&p.Age, td.Op[int](td.Between(40, 45))),
+//	  }
+//	}))
+//
+// is the same as:
+//
+//	td.Cmp(t, got, td.Struct(Person{}, td.StructFields{
+//	  "Name": "Bob",
+//	  "Age":  td.Between(40, 45),
+//	}))
+//
+// but a typo in a field name or a wrong type for "Bob" is caught at
+// compile time instead of during the test.
+//
+// Fields promoted from embedded structs, or exported embedded struct
+// pointers, can be used too. If fn panics, for example when using a
+// field promoted from a nil unexported embedded struct pointer, a bad
+// usage error is reported. As for [Struct], non-checked fields
+// are ignored. To compare a pointer on S, use [Ptr]:
+//
+//	td.Cmp(t, &got, td.Ptr(td.StructOf(func(p *Person) []td.FieldOf { … })))
+//
+// TypeBehind method returns the [reflect.Type] of S.
+//
+// See also [SStructOf], [SliceOf], [MapOf] and [Struct].
+func StructOf[S any](fn func(*S) []FieldOf) TestDeep {
+	return newStructOf(fn, false)
+}
+
+// SStructOf operator is a type-safe variant of [SStruct] operator. It
+// works as [StructOf] does, except that non-checked fields have to
+// be zero, as with [SStruct].
+//
+//	td.Cmp(t, got, td.SStructOf(func(p *Person) []td.FieldOf {
+//	  return []td.FieldOf{
+//	    td.Field(&p.Name, td.Is("Bob")),
+//	    td.Field(&p.Age, td.Op[int](td.Between(40, 45))),
+//	  }
+//	})) // all other fields of got must be zero
+//
+// TypeBehind method returns the [reflect.Type] of S.
+//
+// See also [StructOf] and [SStruct].
+func SStructOf[S any](fn func(*S) []FieldOf) TestDeep {
+	return newStructOf(fn, true)
+}
+
+// SliceOf operator is a type-safe variant of [Slice] operator. It
+// compares the contents of a []E against items, each item being the
+// expectation of the entry at the same index. The compared slice must
+// have exactly len(items) entries.
+//
+//	got := []int{12, 14, 17}
+//	td.Cmp(t, got, td.SliceOf(td.Is(12), td.Op[int](td.Gt(13)), td.Is(17))) // succeeds
+//
+// is the same as:
+//
+//	td.Cmp(t, got, td.Slice([]int{}, td.ArrayEntries{
+//	  0: 12,
+//	  1: td.Gt(13),
+//	  2: 17,
+//	}))
+//
+// but the compiler checks each item is an E.
+//
+// TypeBehind method returns the [reflect.Type] of []E.
+//
+// See also [StructOf], [MapOf] and [Slice].
+func SliceOf[E any](items ...Expected[E]) TestDeep {
+	entries := make(ArrayEntries, len(items))
+	for i, item := range items {
+		entries[i] = item.expected
+	}
+	return newArray(arraySlice, ([]E)(nil), entries)
+}
+
+// MapOf operator is a type-safe variant of [Map] operator. It
+// compares the contents of a map[K]V against entries. All entries
+// have to be present in the compared map, and it must not contain
+// any other key.
+//
+//	got := map[string]int{"foo": 12, "bar": 42}
+//	td.Cmp(t, got, td.MapOf(map[string]td.Expected[int]{
+//	  "foo": td.Is(12),
+//	  "bar": td.Op[int](td.Gt(40)),
+//	})) // succeeds
+//
+// is the same as:
+//
+//	td.Cmp(t, got, td.Map(map[string]int{}, td.MapEntries{
+//	  "foo": 12,
+//	  "bar": td.Gt(40),
+//	}))
+//
+// but the compiler checks each key is a K and each value is a V.
+//
+// TypeBehind method returns the [reflect.Type] of map[K]V.
+//
+// See also [StructOf], [SliceOf] and [Map].
+func MapOf[K comparable, V any](entries map[K]Expected[V]) TestDeep {
+	me := make(MapEntries, len(entries))
+	for k, v := range entries {
+		me[k] = v.expected
+	}
+	return newMap((map[K]V)(nil), allMap, me)
+}
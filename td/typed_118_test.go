@@ -0,0 +1,260 @@
+// Copyright (c) 2026, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build go1.18
+// +build go1.18
+
+package td_test
+
+import (
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestStructOf(t *testing.T) {
+	gotStruct := MyStruct{
+		MyStructMid: MyStructMid{
+			MyStructBase: MyStructBase{
+				ValBool: true,
+			},
+			ValStr: "foobar",
+		},
+		ValInt: 123,
+	}
+
+	checkOK(t, gotStruct,
+		td.StructOf(func(s *MyStruct) []td.FieldOf {
+			return []td.FieldOf{
+				td.Field(&s.ValBool, td.Is(true)),
+				td.Field(&s.ValStr, td.Op[string](td.HasPrefix("foo"))),
+				td.Field(&s.ValInt, td.Is(123)),
+			}
+		}))
+
+	checkOK(t, gotStruct,
+		td.StructOf(func(s *MyStruct) []td.FieldOf { return nil }))
+
+	checkOK(t, &gotStruct,
+		td.Ptr(td.StructOf(func(s *MyStruct) []td.FieldOf {
+			return []td.FieldOf{td.Field(&s.ValInt, td.Is(123))}
+		})))
+
+	// Anchors keep the type check
+	tt := td.NewT(t)
+	td.CmpTrue(t, tt.Cmp(gotStruct,
+		td.StructOf(func(s *MyStruct) []td.FieldOf {
+			return []td.FieldOf{
+				td.Field(&s.ValInt, td.Is(td.A[int](tt, td.Between(120, 125)))),
+			}
+		})))
+
+	checkError(t, gotStruct,
+		td.StructOf(func(s *MyStruct) []td.FieldOf {
+			return []td.FieldOf{
+				td.Field(&s.ValStr, td.Is("foobar")),
+				td.Field(&s.ValInt, td.Op[int](td.Gt(200))),
+			}
+		}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.ValInt"),
+			Got:      mustBe("123"),
+			Expected: mustBe("> 200"),
+		})
+
+	// Embedded struct pointers
+	type Base struct{ ID int }
+	type WithPtr struct {
+		*Base
+		Name string
+	}
+	checkOK(t, WithPtr{Base: &Base{ID: 12}, Name: "Bob"},
+		td.StructOf(func(s *WithPtr) []td.FieldOf {
+			return []td.FieldOf{
+				td.Field(&s.ID, td.Is(12)),
+				td.Field(&s.Name, td.Is("Bob")),
+			}
+		}))
+
+	//
+	// Bad usage
+	checkError(t, "never tested",
+		td.StructOf(func(n *int) []td.FieldOf { return nil }),
+		expectedError{
+			Message: mustBe("bad usage of StructOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("int is not a struct type"),
+		})
+
+	other := 0
+	checkError(t, "never tested",
+		td.StructOf(func(s *MyStruct) []td.FieldOf {
+			return []td.FieldOf{td.Field(&other, td.Is(0))}
+		}),
+		expectedError{
+			Message: mustBe("bad usage of StructOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("field #1 is not a field of td_test.MyStruct"),
+		})
+
+	checkError(t, "never tested",
+		td.StructOf(func(s *MyStruct) []td.FieldOf {
+			return []td.FieldOf{td.Field[int](nil, td.Is(0))}
+		}),
+		expectedError{
+			Message: mustBe("bad usage of StructOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("field #1 is nil"),
+		})
+
+	checkError(t, "never tested",
+		td.StructOf(func(s *MyStruct) []td.FieldOf {
+			return []td.FieldOf{
+				td.Field(&s.ValInt, td.Is(1)),
+				td.Field(&s.ValInt, td.Is(2)),
+			}
+		}),
+		expectedError{
+			Message: mustBe("bad usage of StructOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("field ValInt is set twice"),
+		})
+
+	type Shadowed struct {
+		MyStructBase
+		ValBool bool
+	}
+	checkError(t, "never tested",
+		td.StructOf(func(s *Shadowed) []td.FieldOf {
+			return []td.FieldOf{
+				td.Field(&s.MyStructBase.ValBool, td.Is(true)),
+			}
+		}),
+		expectedError{
+			Message: mustBe("bad usage of StructOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("field #1 (ValBool) is not directly reachable in td_test.Shadowed"),
+		})
+
+	checkError(t, "never tested",
+		td.StructOf(func(s *MyStruct) []td.FieldOf { panic("boom") }),
+		expectedError{
+			Message: mustBe("bad usage of StructOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("function panicked: boom"),
+		})
+
+	// Unexported embedded struct pointers cannot be allocated
+	type base struct{ Val int }
+	type hidden struct{ *base }
+	checkError(t, "never tested",
+		td.StructOf(func(s *hidden) []td.FieldOf {
+			return []td.FieldOf{td.Field(&s.Val, td.Is(1))}
+		}),
+		expectedError{
+			Message: mustBe("bad usage of StructOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain("function panicked: runtime error"),
+		})
+
+	//
+	// String
+	test.EqualStr(t,
+		td.StructOf(func(s *MyStruct) []td.FieldOf {
+			return []td.FieldOf{td.Field(&s.ValInt, td.Is(123))}
+		}).String(),
+		`StructOf(td_test.MyStruct{
+  ValInt: 123
+})`)
+}
+
+func TestSStructOf(t *testing.T) {
+	got := MyStruct{ValInt: 123}
+
+	checkOK(t, got,
+		td.SStructOf(func(s *MyStruct) []td.FieldOf {
+			return []td.FieldOf{td.Field(&s.ValInt, td.Is(123))}
+		}))
+
+	checkError(t, MyStruct{ValInt: 123, MyStructMid: MyStructMid{ValStr: "x"}},
+		td.SStructOf(func(s *MyStruct) []td.FieldOf {
+			return []td.FieldOf{td.Field(&s.ValInt, td.Is(123))}
+		}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.ValStr"),
+			Got:      mustBe(`"x"`),
+			Expected: mustBe(`""`),
+		})
+
+	checkError(t, "never tested",
+		td.SStructOf(func(n *int) []td.FieldOf { return nil }),
+		expectedError{
+			Message: mustBe("bad usage of SStructOf operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("int is not a struct type"),
+		})
+}
+
+func TestSliceOf(t *testing.T) {
+	got := []int{12, 14, 17}
+
+	checkOK(t, got, td.SliceOf(td.Is(12), td.Op[int](td.Gt(13)), td.Is(17)))
+	checkOK(t, []int{}, td.SliceOf[int]())
+
+	checkError(t, got, td.SliceOf(td.Is(12), td.Is(15), td.Is(17)),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("14"),
+			Expected: mustBe("15"),
+		})
+
+	checkError(t, got, td.SliceOf(td.Is(12)),
+		expectedError{
+			Message:  mustBe("got value out of range"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("14"),
+			Expected: mustBe("<non-existent value>"),
+		})
+
+	test.EqualStr(t, td.SliceOf(td.Is(12)).String(), `SliceOf([]int{
+  0: 12
+})`)
+}
+
+func TestMapOf(t *testing.T) {
+	got := map[string]int{"foo": 12, "bar": 42}
+
+	checkOK(t, got, td.MapOf(map[string]td.Expected[int]{
+		"foo": td.Is(12),
+		"bar": td.Op[int](td.Gt(40)),
+	}))
+
+	checkError(t, got, td.MapOf(map[string]td.Expected[int]{"foo": td.Is(12)}),
+		expectedError{
+			Message: mustBe("comparing hash keys of %%"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Extra key: ("bar")`),
+		})
+
+	test.EqualStr(t,
+		td.MapOf(map[string]td.Expected[int]{"foo": td.Is(12)}).String(),
+		`map[string]int{
+  "foo": 12,
+}`)
+}
+
+func TestTypedTypeBehind(t *testing.T) {
+	equalTypes(t, td.StructOf(func(s *MyStruct) []td.FieldOf { return nil }), MyStruct{})
+	equalTypes(t, td.SStructOf(func(s *MyStruct) []td.FieldOf { return nil }), MyStruct{})
+	equalTypes(t, td.SliceOf[int](), []int{})
+	equalTypes(t, td.MapOf(map[string]td.Expected[int]{}), map[string]int{})
+
+	equalTypes(t, td.StructOf(func(n *int) []td.FieldOf { return nil }), nil)
+}
@@ -74,6 +74,8 @@ func pkgFunc(full string) (string, string) {
 	// the/package.Foo         → "the/package", "Foo"
 	// the/package.(*T).Foo    → "the/package", "(*T).Foo"
 	// the/package.glob..func1 → "the/package", "glob..func1"
+	// the/package.Foo[...]    → "the/package", "Foo"
+	full = strings.TrimSuffix(full, "[...]")
 	sp := strings.LastIndexByte(full, '/')
 	if sp < 0 {
 		sp = 0 // std package without any '/' in name
@@ -87,23 +89,35 @@ func pkgFunc(full string) (string, string) {
 	return full[:dp], full[dp+1:]
 }
 
-// setLocation sets location using the stack trace going callDepth levels up.
-func (t *base) setLocation(callDepth int) {
-	if callDepth < 0 {
-		return
-	}
+// initLocation sets location using the stack trace going callDepth
+// levels up. It returns the package of the function found at this
+// level and true, or false if the location cannot be determined.
+func (t *base) initLocation(callDepth int) (string, bool) {
 	var ok bool
 	t.location, ok = location.New(callDepth)
 	if !ok {
 		t.location.File = "???"
 		t.location.Line = 0
+		return "", false
+	}
+
+	var pkg string
+	pkg, t.location.Func = pkgFunc(t.location.Func)
+	return pkg, true
+}
+
+// setLocation sets location using the stack trace going callDepth levels up.
+func (t *base) setLocation(callDepth int) {
+	if callDepth < 0 {
 		return
 	}
 
 	// Here package is github.com/maxatome/go-testdeep, or its vendored
 	// counterpart
-	var pkg string
-	pkg, t.location.Func = pkgFunc(t.location.Func)
+	pkg, ok := t.initLocation(callDepth + 1)
+	if !ok {
+		return
+	}
 
 	// Try to go one level upper, if we are still in go-testdeep package
 	cmpLoc, ok := location.New(callDepth + 1)
@@ -111,6 +111,7 @@ my %IGNORE_VARIADIC = (Between   => 'td.BoundsInIn',
                        # but we want only one here
                        Struct    => 'nil',
                        SStruct   => 'nil',
+                       Partial   => 'nil',
                        # These operators accept several MapEntries,
                        # but we want only one here
                        Map        => 'nil',
@@ -122,6 +123,7 @@ my %IGNORE_VARIADIC = (Between   => 'td.BoundsInIn',
                        Slice        => 'nil',
                        SuperSliceOf => 'nil',
                        # Other cases
+                       JSONPath    => -discard,
                        JSONPointer => -discard,
                        Smuggle     => -smuggle);
 
@@ -129,8 +131,9 @@ my %IGNORE_VARIADIC = (Between   => 'td.BoundsInIn',
 my %SMUGGLER_OPERATORS;
 
 # These operators should be renamed when used as *T method
-my %RENAME_METHOD = (Lax     => 'CmpLax',
-                     ErrorIs => 'CmpErrorIs');
+my %RENAME_METHOD = (Lax      => 'CmpLax',
+                     ErrorIs  => 'CmpErrorIs',
+                     Snapshot => 'CmpSnapshot');
 
 # These operators do not have *T method nor Cmp shortcut
 my %ONLY_OPERATORS = map { $_ => 1 } qw(Catch Delay Ignore Tag);
@@ -659,7 +662,7 @@ my $common_links = do
         . "\n\n"
         # Helpers
         . join("\n", map "[`$_`]: $URL_GODOC/helpers/$_",
-               qw(tdhttp tdsuite tdsynctest tdutil))
+               qw(tdhttp tdmock tdsuite tdsynctest tdutil))
         . "\n\n"
         # Specific links
         . "[`BeLax` config flag]: $td_url#ContextConfig.BeLax\n"